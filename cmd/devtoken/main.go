@@ -0,0 +1,133 @@
+// Команда devtoken выпускает JWT для локальной проверки аутентификации по bearer-токенам.
+// При первом запуске создает RSA-ключ и JWKS-файл, который указывается в JWT_JWKS_FILE.
+//
+//	go run ./cmd/devtoken -user <users.id> -scopes "prs:read prs:write"
+package main
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"flag"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+)
+
+func main() {
+	userID := flag.String("user", "", "users.id to put into the sub claim")
+	scopes := flag.String("scopes", "", "space-separated scopes, for example \"prs:read prs:write\"")
+	ttl := flag.Duration("ttl", time.Hour, "token lifetime")
+	issuer := flag.String("issuer", "", "iss claim, must match JWT_ISSUER if it is set")
+	audience := flag.String("audience", "", "aud claim, must match JWT_AUDIENCE if it is set")
+	keyPath := flag.String("key", ".dev/jwt-private.pem", "RSA private key, created if missing")
+	jwksPath := flag.String("jwks", ".dev/jwks.json", "JWKS file written next to the key")
+	flag.Parse()
+
+	if _, err := uuid.Parse(*userID); err != nil {
+		fail(fmt.Errorf("-user must be a user ID: %w", err))
+	}
+
+	key, err := loadOrCreateKey(*keyPath)
+	if err != nil {
+		fail(err)
+	}
+	kid := keyID(&key.PublicKey)
+	if err := writeJWKS(*jwksPath, &key.PublicKey, kid); err != nil {
+		fail(err)
+	}
+
+	now := time.Now()
+	claims := jwt.MapClaims{
+		"sub": *userID,
+		"iat": now.Unix(),
+		"nbf": now.Unix(),
+		"exp": now.Add(*ttl).Unix(),
+	}
+	if *scopes != "" {
+		claims["scope"] = *scopes
+	}
+	if *issuer != "" {
+		claims["iss"] = *issuer
+	}
+	if *audience != "" {
+		claims["aud"] = *audience
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = kid
+	signed, err := token.SignedString(key)
+	if err != nil {
+		fail(fmt.Errorf("failed to sign token: %w", err))
+	}
+	fmt.Println(signed)
+}
+
+func loadOrCreateKey(path string) (*rsa.PrivateKey, error) {
+	data, err := os.ReadFile(path)
+	if err == nil {
+		block, _ := pem.Decode(data)
+		if block == nil {
+			return nil, fmt.Errorf("%s is not a PEM file", path)
+		}
+		return x509.ParsePKCS1PrivateKey(block.Bytes)
+	}
+	if !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("failed to read key: %w", err)
+	}
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate key: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create key directory: %w", err)
+	}
+	pemData := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+	if err := os.WriteFile(path, pemData, 0o600); err != nil {
+		return nil, fmt.Errorf("failed to write key: %w", err)
+	}
+	return key, nil
+}
+
+// keyID - отпечаток открытого ключа, чтобы kid не менялся между запусками
+func keyID(pub *rsa.PublicKey) string {
+	sum := sha256.Sum256(x509.MarshalPKCS1PublicKey(pub))
+	return base64.RawURLEncoding.EncodeToString(sum[:8])
+}
+
+func writeJWKS(path string, pub *rsa.PublicKey, kid string) error {
+	jwks := map[string]any{
+		"keys": []map[string]string{{
+			"kty": "RSA",
+			"use": "sig",
+			"alg": "RS256",
+			"kid": kid,
+			"n":   base64.RawURLEncoding.EncodeToString(pub.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes()),
+		}},
+	}
+	data, err := json.MarshalIndent(jwks, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode JWKS: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("failed to create JWKS directory: %w", err)
+	}
+	return os.WriteFile(path, data, 0o644)
+}
+
+func fail(err error) {
+	fmt.Fprintln(os.Stderr, err)
+	os.Exit(1)
+}
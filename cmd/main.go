@@ -2,21 +2,40 @@ package main
 
 import (
 	"context"
-	"errors"
 	"go.uber.org/zap"
 	systemLog "log"
-	"net/http"
+	"os/signal"
+	"syscall"
 
 	"avito/internal/config"
+	"avito/internal/domain"
+	"avito/internal/events"
+	"avito/internal/metrics"
+	"avito/internal/ratelimit"
 	"avito/internal/repository/postgres"
 	"avito/internal/service"
+	"avito/internal/transport/graphql"
+	grpchandler "avito/internal/transport/grpc/handler"
+	"avito/internal/transport/grpc/interceptor"
+	grpcserver "avito/internal/transport/grpc/server"
 	"avito/internal/transport/http/handler"
+	"avito/internal/transport/http/middleware"
+	"avito/internal/transport/http/openapi"
 	"avito/internal/transport/http/router"
+	"avito/internal/transport/http/server"
+	"avito/internal/worker"
+	"avito/pkg/jwtauth"
 	"avito/pkg/logger"
+	"avito/pkg/tracing"
+
+	"golang.org/x/sync/errgroup"
 )
 
 func main() {
-	ctx := context.Background()
+	// ctx отменяется по SIGINT/SIGTERM: серверы перестают принимать соединения и дожидаются начатых запросов,
+	// после этого останавливаются фоновые задачи, а отложенные вызовы закрывают пул соединений и сбрасывают логгер
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
 	cfg := config.MustLoad()
 	log, err := logger.NewLogger(cfg.LogLevel)
 	if err != nil {
@@ -29,29 +48,176 @@ func main() {
 		}
 	}()
 
-	storeRepo, err := postgres.NewStore(ctx, cfg.UserRepo, cfg.PasswordRepo, cfg.HostRepo, cfg.PortRepo, cfg.DBName, cfg.SSLMode, log)
+	shutdownTracing, err := tracing.Init(ctx, tracing.Config{
+		Enabled:     cfg.Tracing.Enabled,
+		Exporter:    cfg.Tracing.Exporter,
+		Endpoint:    cfg.Tracing.OTLPEndpoint,
+		Insecure:    cfg.Tracing.OTLPInsecure,
+		ServiceName: cfg.Tracing.ServiceName,
+		SampleRatio: cfg.Tracing.SampleRatio,
+	})
+	if err != nil {
+		log.Error("Failed to initialize tracing", zap.Error(err))
+		return
+	}
+	defer func() {
+		if err := shutdownTracing(context.Background()); err != nil {
+			log.Error("Failed to shutdown tracing", zap.Error(err))
+		}
+	}()
+
+	storeRepo, err := postgres.NewStore(ctx, cfg.Database, log)
 	if err != nil {
 		log.Error("Failed to initialized to postgres", zap.Error(err))
 		return
 	}
+	defer storeRepo.Close()
+	appMetrics := metrics.New()
+	appMetrics.MustRegister(metrics.NewPoolCollector(storeRepo))
+
 	userRepo := storeRepo.UserRepository
 	prRepo := storeRepo.PullRequestRepository
 	statsRepo := storeRepo.StatsRepository
+	tagRepo := storeRepo.TagRepository
+	unavailabilityRepo := storeRepo.UnavailabilityRepository
+	jobRunRepo := storeRepo.JobRunRepository
+	apiKeyRepo := storeRepo.APIKeyRepository
+	auditRepo := storeRepo.AuditRepository
 
-	userSrv := service.NewUserService(&userRepo, &prRepo, log)
+	userSrv := service.NewUserService(&userRepo, &prRepo, &tagRepo, log)
 	teamSrv := service.NewTeamService(storeRepo, &userRepo, log)
-	prSrv := service.NewPullRequestService(&prRepo, userSrv, log)
-	statsSrv := service.NewStatsService(&statsRepo, log)
-
-	handl := handler.NewHandler(*teamSrv, *userSrv, *statsSrv, *prSrv)
-	rout := router.NewRouter(handl, cfg.LogLevel, log)
-	srv := &http.Server{
-		Addr:    cfg.HTTPAddr,
-		Handler: rout.GetEngine(),
-	}
-	log.Info("Starting server", zap.String("addr", srv.Addr))
-	if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
-		log.Error("Failed to listen and server", zap.Error(err))
+	selector := service.NewSkillBasedSelector(&tagRepo, &prRepo, log)
+	eventBus := events.NewBus(log)
+	prSrv := service.NewPullRequestService(&prRepo, userSrv, selector, appMetrics, eventBus, log)
+	statsSrv := service.NewStatsService(&statsRepo, storeRepo, log)
+	lookupSrv := service.NewLookupService(storeRepo, log)
+	unavailabilitySrv := service.NewUnavailabilityService(&unavailabilityRepo, userSrv, &prRepo, prSrv, log)
+	jobRunSrv := service.NewJobRunService(&jobRunRepo, log)
+	apiKeySrv := service.NewAPIKeyService(&apiKeyRepo, log)
+	auditSrv := service.NewAuditService(&auditRepo, log)
+
+	if err := apiKeySrv.EnsureBootstrapKey(ctx, cfg.Auth.BootstrapAdminKey); err != nil {
+		log.Error("Failed to register bootstrap API key", zap.Error(err))
+		return
+	}
+	if !cfg.Auth.Enabled {
+		log.Warn("API authentication is disabled, all requests run with admin rights")
+	}
+
+	// Интерфейс заполняется только при включенном JWT: nil-указатель в интерфейсе не считался бы отключением
+	var tokenAuth middleware.TokenAuthenticator
+	if cfg.Auth.JWT.Enabled {
+		verifier, err := jwtauth.New(ctx, jwtauth.Config{
+			JWKSURL:         cfg.Auth.JWT.JWKSURL,
+			JWKSFile:        cfg.Auth.JWT.JWKSFile,
+			Issuer:          cfg.Auth.JWT.Issuer,
+			Audience:        cfg.Auth.JWT.Audience,
+			RefreshInterval: cfg.Auth.JWT.RefreshInterval,
+			Leeway:          cfg.Auth.JWT.Leeway,
+			OnRefreshError: func(err error) {
+				log.Warn("Failed to refresh JWKS", zap.Error(err))
+			},
+		})
+		if err != nil {
+			log.Error("Failed to initialize JWT verification", zap.Error(err))
+			return
+		}
+		tokenAuth = service.NewTokenService(verifier, userSrv, service.TokenClaims{
+			UserClaim:  cfg.Auth.JWT.UserClaim,
+			ScopeClaim: cfg.Auth.JWT.ScopeClaim,
+		}, log)
+	}
+
+	escalationSrv := service.NewReviewEscalationService(&prRepo, prSrv, eventBus, service.EscalationThresholds{
+		ReviewSLA:  cfg.Jobs.StaleReviewSLA,
+		Escalation: cfg.Jobs.StaleReviewEscalation,
+	}, log)
+
+	scheduler := worker.NewScheduler(&jobRunRepo, log)
+	scheduler.Register(worker.NewLeaveJob(unavailabilitySrv, log), cfg.Jobs.LeaveInterval)
+	scheduler.Register(worker.NewStaleReviewJob(escalationSrv, log), cfg.Jobs.StaleReviewInterval)
+
+	idempotencyRepo := storeRepo.IdempotencyRepository
+	scheduler.Register(worker.NewIdempotencyCleanupJob(&idempotencyRepo, log), cfg.Idempotency.CleanupInterval)
+
+	rateLimits := router.RateLimits{TrustedProxies: cfg.RateLimit.TrustedProxies}
+	if cfg.RateLimit.Enabled {
+		rateLimits.Limits = make(map[string]domain.RateLimit, len(cfg.RateLimit.Limits))
+		for group, rule := range cfg.RateLimit.Limits {
+			rateLimits.Limits[group] = domain.RateLimit{Rate: rule.RPS, Burst: rule.Burst}
+		}
+		switch cfg.RateLimit.Store {
+		case "postgres":
+			rateLimitRepo := storeRepo.RateLimitRepository
+			rateLimits.Limiter = &rateLimitRepo
+			scheduler.Register(worker.NewRateLimitCleanupJob(&rateLimitRepo, log), cfg.RateLimit.CleanupInterval)
+		default:
+			rateLimits.Limiter = ratelimit.NewMemoryStore()
+		}
+	}
+	// Планировщик не зависит от сигнала: начатые запросы еще могут рассчитывать на фоновые задачи,
+	// поэтому он останавливается явно после серверов. Отложенный Stop нужен для ранних выходов
+	scheduler.Start(context.Background())
+	defer scheduler.Stop()
+
+	healthSrv := service.NewHealthService(storeRepo, scheduler, log)
+
+	spec, err := openapi.Load()
+	if err != nil {
+		log.Error("Failed to load OpenAPI spec", zap.Error(err))
+		return
+	}
+
+	handl := handler.NewHandler(*teamSrv, *userSrv, *statsSrv, *prSrv, *unavailabilitySrv, *jobRunSrv, *healthSrv, *apiKeySrv, *auditSrv)
+	rout := router.NewRouter(handl, appMetrics, router.Security{
+		Enabled:       cfg.Auth.Enabled,
+		Authenticator: apiKeySrv,
+		Tokens:        tokenAuth,
+		Audit:         auditSrv,
+	}, rateLimits, router.Idempotency{
+		Store:       &idempotencyRepo,
+		TTL:         cfg.Idempotency.TTL,
+		LockTimeout: cfg.Idempotency.LockTimeout,
+	}, router.OpenAPI{
+		Spec:             spec,
+		ValidateRequests: cfg.HTTP.ValidateRequests,
+	}, router.LegacyRoutes{
+		DeprecatedAt: cfg.HTTP.LegacyDeprecatedAt,
+		SunsetAt:     cfg.HTTP.LegacySunsetAt,
+	}, graphql.NewHandler(graphql.Services{
+		Lookup: lookupSrv,
+		Stats:  statsSrv,
+	}, log), cfg.Tracing.ServiceName, cfg.LogLevel, log)
+	srv := server.New(cfg.HTTP, rout.GetEngine(), log)
+
+	// Серверы работают до сигнала остановки. Если один из них не запустился или упал, errgroup отменяет
+	// общий контекст и второй тоже останавливается
+	servers, serversCtx := errgroup.WithContext(ctx)
+	servers.Go(func() error {
+		return srv.Run(serversCtx)
+	})
+	if cfg.GRPC.Enabled {
+		grpcSrv := grpcserver.New(cfg.GRPC, cfg.HTTP.ShutdownTimeout, grpchandler.Services{
+			Teams:        teamSrv,
+			Users:        userSrv,
+			PullRequests: prSrv,
+			Stats:        statsSrv,
+			Events:       eventBus,
+		}, interceptor.Security{
+			Enabled:       cfg.Auth.Enabled,
+			Authenticator: apiKeySrv,
+			Tokens:        tokenAuth,
+			Audit:         auditSrv,
+		}, log)
+		servers.Go(func() error {
+			return grpcSrv.Run(serversCtx)
+		})
+	}
+	err = servers.Wait()
+	scheduler.Stop()
+	if err != nil {
+		log.Error("Server stopped with error", zap.Error(err))
 		return
 	}
+	log.Info("Shutdown complete")
 }
@@ -0,0 +1,22 @@
+// Package auth переносит аутентифицированного вызывающего через context.Context,
+// чтобы сервисы видели его независимо от транспорта.
+package auth
+
+import (
+	"context"
+
+	"avito/internal/domain"
+)
+
+type principalKey struct{}
+
+// WithPrincipal сохраняет вызывающего в контексте
+func WithPrincipal(ctx context.Context, principal domain.Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, principal)
+}
+
+// PrincipalFromContext возвращает вызывающего из контекста, ok=false для неаутентифицированных вызовов
+func PrincipalFromContext(ctx context.Context) (domain.Principal, bool) {
+	principal, ok := ctx.Value(principalKey{}).(domain.Principal)
+	return principal, ok
+}
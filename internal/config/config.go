@@ -1,37 +1,309 @@
+// Package config загружает настройки сервиса: сначала YAML-файл (если указан CONFIG_PATH),
+// затем переменные окружения поверх него, для незаданных полей подставляются значения по умолчанию.
+// После загрузки конфигурация проверяется целиком, ошибка перечисляет все некорректные поля.
 package config
 
 import (
+	"errors"
+	"fmt"
 	"log"
+	"maps"
+	"net"
 	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
 
+	"github.com/ilyakaznacheev/cleanenv"
 	"github.com/joho/godotenv"
 )
 
+// configPathEnv - переменная окружения с путем к YAML-файлу конфигурации
+const configPathEnv = "CONFIG_PATH"
+
 type Config struct {
-	HTTPAddr     string
-	LogLevel     string
-	UserRepo     string
-	PasswordRepo string
-	HostRepo     string
-	PortRepo     string
-	DBName       string
-	SSLMode      string
+	LogLevel string `yaml:"log_level" env:"LOG_LEVEL" env-default:"info" env-description:"Log level: debug, info, warn, error"`
+
+	HTTP        HTTPConfig        `yaml:"http"`
+	GRPC        GRPCConfig        `yaml:"grpc"`
+	Database    DatabaseConfig    `yaml:"database"`
+	Jobs        JobsConfig        `yaml:"jobs"`
+	Tracing     TracingConfig     `yaml:"tracing"`
+	Auth        AuthConfig        `yaml:"auth"`
+	RateLimit   RateLimitConfig   `yaml:"rate_limit"`
+	Idempotency IdempotencyConfig `yaml:"idempotency"`
+}
+
+// HTTPConfig - настройки HTTP-сервера
+type HTTPConfig struct {
+	Addr              string        `yaml:"addr" env:"HTTP_ADDR" env-default:"0.0.0.0:8080" env-description:"HTTP listen address, host:port"`
+	ReadHeaderTimeout time.Duration `yaml:"read_header_timeout" env:"HTTP_READ_HEADER_TIMEOUT" env-default:"5s" env-description:"Time to read request headers"`
+	ReadTimeout       time.Duration `yaml:"read_timeout" env:"HTTP_READ_TIMEOUT" env-default:"30s" env-description:"Time to read the whole request"`
+	WriteTimeout      time.Duration `yaml:"write_timeout" env:"HTTP_WRITE_TIMEOUT" env-default:"0s" env-description:"Time to write the response, 0 disables the limit (needed for long exports)"`
+	IdleTimeout       time.Duration `yaml:"idle_timeout" env:"HTTP_IDLE_TIMEOUT" env-default:"120s" env-description:"Keep-alive idle connection timeout"`
+	ShutdownTimeout   time.Duration `yaml:"shutdown_timeout" env:"SHUTDOWN_TIMEOUT" env-default:"15s" env-description:"How long to drain in-flight requests on shutdown"`
+	ValidateRequests  bool          `yaml:"validate_requests" env:"HTTP_VALIDATE_REQUESTS" env-default:"false" env-description:"Validate request parameters and JSON bodies against the OpenAPI spec"`
+	// LegacyDeprecatedAt - дата выхода /api/v1, с которой пути без версии объявлены устаревшими
+	LegacyDeprecatedAt time.Time `yaml:"legacy_deprecated_at" env:"HTTP_LEGACY_DEPRECATED_AT" env-layout:"2006-01-02" env-default:"2026-10-18" env-description:"Date (YYYY-MM-DD) sent in the Deprecation header of unversioned routes"`
+	// LegacySunsetAt - дата отключения путей без версии. Пока она не задана, заголовок Sunset не отправляется
+	LegacySunsetAt time.Time `yaml:"legacy_sunset_at" env:"HTTP_LEGACY_SUNSET_AT" env-layout:"2006-01-02" env-description:"Date (YYYY-MM-DD) when unversioned routes are removed, sent in the Sunset header"`
+}
+
+// GRPCConfig - настройки gRPC-сервера. Он останавливается вместе с HTTP-сервером и ждет начатые вызовы SHUTDOWN_TIMEOUT
+type GRPCConfig struct {
+	Enabled bool   `yaml:"enabled" env:"GRPC_ENABLED" env-default:"true" env-description:"Serve the gRPC API"`
+	Addr    string `yaml:"addr" env:"GRPC_ADDR" env-default:"0.0.0.0:9090" env-description:"gRPC listen address, host:port"`
+}
+
+// DatabaseConfig - подключение к PostgreSQL, настройки пула и миграций
+type DatabaseConfig struct {
+	User     string `yaml:"user" env:"DB_USER" env-description:"PostgreSQL user"`
+	Password string `yaml:"password" env:"DB_PASSWORD" env-description:"PostgreSQL password"`
+	Host     string `yaml:"host" env:"DB_HOST" env-default:"localhost" env-description:"PostgreSQL host"`
+	Port     int    `yaml:"port" env:"DB_PORT" env-default:"5432" env-description:"PostgreSQL port"`
+	Name     string `yaml:"name" env:"DB_NAME" env-description:"Database name"`
+	SSLMode  string `yaml:"ssl_mode" env:"DB_SSLMODE" env-default:"disable" env-description:"sslmode: disable, allow, prefer, require, verify-ca, verify-full"`
+
+	MaxConns          int32         `yaml:"max_conns" env:"DB_MAX_CONNS" env-default:"50" env-description:"Maximum pool size"`
+	MinConns          int32         `yaml:"min_conns" env:"DB_MIN_CONNS" env-default:"2" env-description:"Minimum number of idle connections kept open"`
+	MaxConnLifetime   time.Duration `yaml:"max_conn_lifetime" env:"DB_MAX_CONN_LIFETIME" env-default:"1h" env-description:"Maximum lifetime of a connection"`
+	MaxConnIdleTime   time.Duration `yaml:"max_conn_idle_time" env:"DB_MAX_CONN_IDLE_TIME" env-default:"30m" env-description:"Idle time after which a connection is closed"`
+	HealthCheckPeriod time.Duration `yaml:"health_check_period" env:"DB_HEALTH_CHECK_PERIOD" env-default:"30s" env-description:"How often idle connections are checked"`
+	ConnectTimeout    time.Duration `yaml:"connect_timeout" env:"DB_CONNECT_TIMEOUT" env-default:"5s" env-description:"Timeout for establishing a connection"`
+
+	RunMigrations  bool   `yaml:"run_migrations" env:"DB_RUN_MIGRATIONS" env-default:"true" env-description:"Apply migrations on startup"`
+	MigrationsPath string `yaml:"migrations_path" env:"MIGRATE_PATH" env-default:"./migrations" env-description:"Directory with migration files"`
+	QueryTracing   bool   `yaml:"query_tracing" env:"DB_QUERY_TRACING" env-default:"true" env-description:"Create a span for every SQL query"`
+}
+
+// JobsConfig - расписание фоновых задач и пороги просроченных ревью
+type JobsConfig struct {
+	// LeaveInterval определяет, как часто проверяются начавшиеся периоды недоступности
+	LeaveInterval time.Duration `yaml:"leave_interval" env:"LEAVE_JOB_INTERVAL" env-default:"1m" env-description:"Interval of the leave reassignment job"`
+	// StaleReviewInterval определяет, как часто ищутся просроченные ревью
+	StaleReviewInterval time.Duration `yaml:"stale_review_interval" env:"STALE_REVIEW_JOB_INTERVAL" env-default:"5m" env-description:"Interval of the stale review job"`
+	// StaleReviewSLA - время без действий ревьюера, после которого отправляется напоминание
+	StaleReviewSLA time.Duration `yaml:"stale_review_sla" env:"STALE_REVIEW_SLA" env-default:"24h" env-description:"Reviewer inactivity before a reminder"`
+	// StaleReviewEscalation - время без действий ревьюера, после которого ревью переназначается
+	StaleReviewEscalation time.Duration `yaml:"stale_review_escalation" env:"STALE_REVIEW_ESCALATION" env-default:"48h" env-description:"Reviewer inactivity before reassignment"`
+}
+
+// TracingConfig - настройки OpenTelemetry. Exporter: "otlp" или "stdout"
+type TracingConfig struct {
+	Enabled      bool    `yaml:"enabled" env:"TRACING_ENABLED" env-default:"false" env-description:"Enable OpenTelemetry tracing"`
+	Exporter     string  `yaml:"exporter" env:"TRACING_EXPORTER" env-default:"otlp" env-description:"Span exporter: otlp or stdout"`
+	OTLPEndpoint string  `yaml:"otlp_endpoint" env:"OTLP_ENDPOINT" env-default:"localhost:4318" env-description:"OTLP/HTTP collector address"`
+	OTLPInsecure bool    `yaml:"otlp_insecure" env:"OTLP_INSECURE" env-default:"true" env-description:"Send spans to the collector without TLS"`
+	ServiceName  string  `yaml:"service_name" env:"TRACING_SERVICE_NAME" env-default:"avito-service" env-description:"Service name in spans"`
+	SampleRatio  float64 `yaml:"sample_ratio" env:"TRACING_SAMPLE_RATIO" env-default:"1" env-description:"Share of sampled traces, from 0 to 1"`
+}
+
+// AuthConfig - настройки аутентификации по API-ключам
+type AuthConfig struct {
+	Enabled bool `yaml:"enabled" env:"AUTH_ENABLED" env-default:"true" env-description:"Require an API key for API endpoints"`
+	// BootstrapAdminKey регистрируется при запуске с правом admin, чтобы выпустить первые ключи
+	BootstrapAdminKey string `yaml:"bootstrap_admin_key" env:"AUTH_BOOTSTRAP_ADMIN_KEY" env-description:"Admin API key registered on startup, at least 32 characters"`
+
+	JWT JWTConfig `yaml:"jwt"`
+}
+
+// JWTConfig - проверка bearer JWT провайдера удостоверений. Ключи берутся из JWKSURL или JWKSFile
+type JWTConfig struct {
+	Enabled         bool          `yaml:"enabled" env:"JWT_ENABLED" env-default:"false" env-description:"Accept bearer JWTs"`
+	JWKSURL         string        `yaml:"jwks_url" env:"JWT_JWKS_URL" env-description:"JWKS URL of the identity provider"`
+	JWKSFile        string        `yaml:"jwks_file" env:"JWT_JWKS_FILE" env-description:"Local JWKS file, used instead of the URL for local testing"`
+	RefreshInterval time.Duration `yaml:"refresh_interval" env:"JWT_JWKS_REFRESH_INTERVAL" env-default:"1h" env-description:"How often the JWKS URL is refetched"`
+	Issuer          string        `yaml:"issuer" env:"JWT_ISSUER" env-description:"Expected iss claim, empty disables the check"`
+	Audience        string        `yaml:"audience" env:"JWT_AUDIENCE" env-description:"Expected aud claim, empty disables the check"`
+	Leeway          time.Duration `yaml:"leeway" env:"JWT_LEEWAY" env-default:"30s" env-description:"Allowed clock skew for exp and nbf"`
+	UserClaim       string        `yaml:"user_claim" env:"JWT_USER_CLAIM" env-default:"sub" env-description:"Claim holding users.id"`
+	ScopeClaim      string        `yaml:"scope_claim" env:"JWT_SCOPE_CLAIM" env-default:"scope" env-description:"Claim holding scopes"`
+}
+
+// RateLimitConfig - ограничение частоты запросов корзинами токенов. Store: "memory" для одного экземпляра
+// или "postgres" для общих корзин нескольких экземпляров
+type RateLimitConfig struct {
+	Enabled bool   `yaml:"enabled" env:"RATE_LIMIT_ENABLED" env-default:"false" env-description:"Limit request rate per client IP and per caller"`
+	Store   string `yaml:"store" env:"RATE_LIMIT_STORE" env-default:"memory" env-description:"Token bucket store: memory or postgres"`
+	// Limits - лимиты по группам маршрутов в виде группа:запросов_в_секунду:емкость.
+	// ip ограничивает адрес клиента до аутентификации, default - группы без своего лимита
+	Limits map[string]RateLimitRule `yaml:"limits" env:"RATE_LIMIT_LIMITS" env-default:"ip:50:100,default:10:20,stats:2:10,admin:1:5" env-description:"Comma-separated group:rps:burst, groups: ip, default, stats, jobs, users, team, pullRequest, admin, graphql"`
+	// TrustedProxies - адреса или подсети прокси, которым доверяется X-Forwarded-For
+	TrustedProxies []string `yaml:"trusted_proxies" env:"RATE_LIMIT_TRUSTED_PROXIES" env-description:"Comma-separated proxies whose X-Forwarded-For is trusted"`
+	// CleanupInterval определяет, как часто из базы удаляются наполнившиеся корзины
+	CleanupInterval time.Duration `yaml:"cleanup_interval" env:"RATE_LIMIT_CLEANUP_INTERVAL" env-default:"10m" env-description:"Interval of the postgres bucket cleanup job"`
+}
+
+// rateLimitGroups - группы, для которых можно задать лимит
+var rateLimitGroups = []string{"ip", "default", "stats", "jobs", "users", "team", "pullRequest", "admin", "graphql"}
+
+// RateLimitRule - лимит группы: RPS запросов в секунду в среднем и до Burst подряд
+type RateLimitRule struct {
+	RPS   float64
+	Burst int
+}
+
+// UnmarshalText разбирает лимит вида rps:burst, например 10:20
+func (r *RateLimitRule) UnmarshalText(text []byte) error {
+	rps, burst, ok := strings.Cut(string(text), ":")
+	if !ok {
+		return fmt.Errorf("rate limit %q must be rps:burst", text)
+	}
+	var err error
+	if r.RPS, err = strconv.ParseFloat(rps, 64); err != nil {
+		return fmt.Errorf("invalid rate limit rps %q: %w", rps, err)
+	}
+	if r.Burst, err = strconv.Atoi(burst); err != nil {
+		return fmt.Errorf("invalid rate limit burst %q: %w", burst, err)
+	}
+	return nil
+}
+
+// IdempotencyConfig - хранение ответов на запросы с заголовком Idempotency-Key
+type IdempotencyConfig struct {
+	TTL time.Duration `yaml:"ttl" env:"IDEMPOTENCY_TTL" env-default:"24h" env-description:"How long responses are kept for retries with the same Idempotency-Key"`
+	// LockTimeout - через сколько незавершенный первый запрос перестает занимать ключ
+	LockTimeout     time.Duration `yaml:"lock_timeout" env:"IDEMPOTENCY_LOCK_TIMEOUT" env-default:"1m" env-description:"After this time an unfinished request no longer holds its key"`
+	CleanupInterval time.Duration `yaml:"cleanup_interval" env:"IDEMPOTENCY_CLEANUP_INTERVAL" env-default:"1h" env-description:"Interval of the expired idempotency key cleanup job"`
 }
 
+// minBootstrapKeyLength - минимальная длина ключа администратора из конфигурации
+const minBootstrapKeyLength = 32
+
+// MustLoad загружает конфигурацию и завершает процесс, если она некорректна
 func MustLoad() *Config {
+	cfg, err := Load()
+	if err != nil {
+		log.Fatalf("invalid configuration:\n%v\n\n%s", err, Usage())
+	}
+	return cfg
+}
 
+// Load читает .env (если есть), YAML-файл из CONFIG_PATH (если задан) и переменные окружения,
+// затем проверяет результат
+func Load() (*Config, error) {
 	if err := godotenv.Load(); err != nil {
 		log.Println("No .env file found, reading from environment variables")
 	}
 
-	return &Config{
-		HTTPAddr:     os.Getenv("HTTP_ADDR"),
-		LogLevel:     os.Getenv("LOG_LEVEL"),
-		UserRepo:     os.Getenv("DB_USER"),
-		PasswordRepo: os.Getenv("DB_PASSWORD"),
-		HostRepo:     os.Getenv("DB_HOST"),
-		PortRepo:     os.Getenv("DB_PORT"),
-		DBName:       os.Getenv("DB_NAME"),
-		SSLMode:      os.Getenv("DB_SSLMODE"),
+	var cfg Config
+	if path := os.Getenv(configPathEnv); path != "" {
+		// ReadConfig сам применяет переменные окружения поверх значений из файла
+		if err := cleanenv.ReadConfig(path, &cfg); err != nil {
+			return nil, fmt.Errorf("failed to read config file %s: %w", path, err)
+		}
+	} else if err := cleanenv.ReadEnv(&cfg); err != nil {
+		return nil, fmt.Errorf("failed to read environment: %w", err)
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return &cfg, nil
+}
+
+// Usage возвращает описание всех переменных окружения с их значениями по умолчанию
+func Usage() string {
+	var cfg Config
+	description, err := cleanenv.GetDescription(&cfg, nil)
+	if err != nil {
+		return err.Error()
+	}
+	return description
+}
+
+// Validate проверяет все поля и возвращает одну ошибку со списком всех нарушений
+func (c *Config) Validate() error {
+	var errs []error
+	check := func(ok bool, field, format string, args ...any) {
+		if !ok {
+			errs = append(errs, fmt.Errorf("%s: %s", field, fmt.Sprintf(format, args...)))
+		}
+	}
+
+	switch c.LogLevel {
+	case "debug", "info", "warn", "error":
+	default:
+		check(false, "LOG_LEVEL", "unknown level %q", c.LogLevel)
+	}
+
+	check(validAddr(c.HTTP.Addr), "HTTP_ADDR", "must be host:port with a non-zero port, got %q", c.HTTP.Addr)
+	check(c.HTTP.ReadHeaderTimeout > 0, "HTTP_READ_HEADER_TIMEOUT", "must be positive")
+	check(c.HTTP.ReadTimeout >= 0, "HTTP_READ_TIMEOUT", "must not be negative")
+	check(c.HTTP.WriteTimeout >= 0, "HTTP_WRITE_TIMEOUT", "must not be negative")
+	check(c.HTTP.IdleTimeout >= 0, "HTTP_IDLE_TIMEOUT", "must not be negative")
+	check(c.HTTP.ShutdownTimeout > 0, "SHUTDOWN_TIMEOUT", "must be positive")
+	check(!c.HTTP.LegacyDeprecatedAt.IsZero(), "HTTP_LEGACY_DEPRECATED_AT", "is required")
+	check(c.HTTP.LegacySunsetAt.IsZero() || c.HTTP.LegacySunsetAt.After(c.HTTP.LegacyDeprecatedAt),
+		"HTTP_LEGACY_SUNSET_AT", "must be after HTTP_LEGACY_DEPRECATED_AT")
+	if c.GRPC.Enabled {
+		check(validAddr(c.GRPC.Addr), "GRPC_ADDR", "must be host:port with a non-zero port, got %q", c.GRPC.Addr)
+		check(c.GRPC.Addr != c.HTTP.Addr, "GRPC_ADDR", "must differ from HTTP_ADDR")
+	}
+
+	db := c.Database
+	check(db.User != "", "DB_USER", "is required")
+	check(db.Name != "", "DB_NAME", "is required")
+	check(db.Host != "", "DB_HOST", "is required")
+	check(db.Port > 0 && db.Port <= 65535, "DB_PORT", "must be between 1 and 65535, got %d", db.Port)
+	switch db.SSLMode {
+	case "disable", "allow", "prefer", "require", "verify-ca", "verify-full":
+	default:
+		check(false, "DB_SSLMODE", "unknown mode %q", db.SSLMode)
+	}
+	check(db.MaxConns > 0, "DB_MAX_CONNS", "must be positive")
+	check(db.MinConns >= 0 && db.MinConns <= db.MaxConns, "DB_MIN_CONNS", "must be between 0 and DB_MAX_CONNS (%d), got %d", db.MaxConns, db.MinConns)
+	check(db.MaxConnLifetime > 0, "DB_MAX_CONN_LIFETIME", "must be positive")
+	check(db.MaxConnIdleTime > 0, "DB_MAX_CONN_IDLE_TIME", "must be positive")
+	check(db.HealthCheckPeriod > 0, "DB_HEALTH_CHECK_PERIOD", "must be positive")
+	check(db.ConnectTimeout > 0, "DB_CONNECT_TIMEOUT", "must be positive")
+	check(!db.RunMigrations || db.MigrationsPath != "", "MIGRATE_PATH", "is required when migrations are enabled")
+
+	check(c.Jobs.LeaveInterval > 0, "LEAVE_JOB_INTERVAL", "must be positive")
+	check(c.Jobs.StaleReviewInterval > 0, "STALE_REVIEW_JOB_INTERVAL", "must be positive")
+	check(c.Jobs.StaleReviewSLA > 0, "STALE_REVIEW_SLA", "must be positive")
+	check(c.Jobs.StaleReviewEscalation > c.Jobs.StaleReviewSLA, "STALE_REVIEW_ESCALATION", "must be greater than STALE_REVIEW_SLA (%s), got %s", c.Jobs.StaleReviewSLA, c.Jobs.StaleReviewEscalation)
+
+	if c.Tracing.Enabled {
+		check(c.Tracing.Exporter == "otlp" || c.Tracing.Exporter == "stdout", "TRACING_EXPORTER", "must be otlp or stdout, got %q", c.Tracing.Exporter)
+		check(c.Tracing.Exporter != "otlp" || c.Tracing.OTLPEndpoint != "", "OTLP_ENDPOINT", "is required for the otlp exporter")
+		check(c.Tracing.ServiceName != "", "TRACING_SERVICE_NAME", "is required")
+	}
+	check(c.Tracing.SampleRatio >= 0 && c.Tracing.SampleRatio <= 1, "TRACING_SAMPLE_RATIO", "must be between 0 and 1, got %g", c.Tracing.SampleRatio)
+
+	check(c.Auth.BootstrapAdminKey == "" || len(c.Auth.BootstrapAdminKey) >= minBootstrapKeyLength,
+		"AUTH_BOOTSTRAP_ADMIN_KEY", "must be at least %d characters", minBootstrapKeyLength)
+	if jwt := c.Auth.JWT; jwt.Enabled {
+		check((jwt.JWKSURL == "") != (jwt.JWKSFile == ""), "JWT_JWKS_URL", "exactly one of JWT_JWKS_URL and JWT_JWKS_FILE must be set")
+		check(jwt.JWKSURL == "" || jwt.RefreshInterval > 0, "JWT_JWKS_REFRESH_INTERVAL", "must be positive")
+		check(jwt.Leeway >= 0, "JWT_LEEWAY", "must not be negative")
+		check(jwt.UserClaim != "", "JWT_USER_CLAIM", "is required")
+	}
+
+	if rl := c.RateLimit; rl.Enabled {
+		check(rl.Store == "memory" || rl.Store == "postgres", "RATE_LIMIT_STORE", "must be memory or postgres, got %q", rl.Store)
+		for _, group := range slices.Sorted(maps.Keys(rl.Limits)) {
+			rule := rl.Limits[group]
+			check(slices.Contains(rateLimitGroups, group), "RATE_LIMIT_LIMITS", "unknown group %q", group)
+			check(rule.RPS > 0 && rule.Burst >= 1, "RATE_LIMIT_LIMITS", "%s: rps must be positive and burst at least 1", group)
+		}
+		check(rl.Store != "postgres" || rl.CleanupInterval > 0, "RATE_LIMIT_CLEANUP_INTERVAL", "must be positive")
+	}
+
+	check(c.Idempotency.TTL > 0, "IDEMPOTENCY_TTL", "must be positive")
+	check(c.Idempotency.LockTimeout > 0 && c.Idempotency.LockTimeout < c.Idempotency.TTL, "IDEMPOTENCY_LOCK_TIMEOUT", "must be positive and shorter than IDEMPOTENCY_TTL (%s), got %s", c.Idempotency.TTL, c.Idempotency.LockTimeout)
+	check(c.Idempotency.CleanupInterval > 0, "IDEMPOTENCY_CLEANUP_INTERVAL", "must be positive")
+
+	return errors.Join(errs...)
+}
+
+// validAddr проверяет адрес вида host:port. Пустой хост допустим, порт обязателен
+func validAddr(addr string) bool {
+	_, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return false
 	}
+	p, err := strconv.Atoi(port)
+	return err == nil && p > 0 && p <= 65535
 }
@@ -0,0 +1,82 @@
+package domain
+
+import "errors"
+
+// ErrorCode - машинный код ошибки предметной области. Транспорты отдают его клиенту без изменений
+// и по нему выбирают статус ответа
+type ErrorCode string
+
+const (
+	CodeInvalidArgument ErrorCode = "PARAMETERS_INCORRECT"
+	CodeNotFound        ErrorCode = "NOT_FOUND"
+	CodeTeamExists      ErrorCode = "TEAM_EXISTS"
+	CodePRExists        ErrorCode = "PR_EXISTS"
+	CodePRMerged        ErrorCode = "PR_MERGED"
+	CodeNoCandidate     ErrorCode = "NO_CANDIDATE"
+	CodeNotAssigned     ErrorCode = "NOT_ASSIGNED"
+	CodeAuthorInactive  ErrorCode = "AUTHOR_INACTIVE"
+	CodeInvalidPeriod   ErrorCode = "INVALID_PERIOD"
+	CodeInvalidCalendar ErrorCode = "INVALID_CALENDAR"
+	CodeInvalidCapacity ErrorCode = "INVALID_CAPACITY"
+	CodeInvalidSLA      ErrorCode = "INVALID_SLA"
+	CodeInvalidScope    ErrorCode = "INVALID_SCOPE"
+	CodeInvalidRole     ErrorCode = "INVALID_ROLE"
+	CodeInvalidVerdict  ErrorCode = "INVALID_VERDICT"
+	CodeUnauthorized    ErrorCode = "UNAUTHORIZED"
+	CodeForbidden       ErrorCode = "FORBIDDEN"
+	CodeVersionConflict ErrorCode = "PRECONDITION_FAILED"
+)
+
+// Error - ошибка предметной области с кодом. Сервисы возвращают ее как есть или оборачивают через %w,
+// транспорт находит ее через errors.As. Сообщение предназначено клиенту и не содержит внутренних деталей
+type Error struct {
+	Code    ErrorCode
+	Message string
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+func newError(code ErrorCode, message string) *Error {
+	return &Error{Code: code, Message: message}
+}
+
+// AsError возвращает ошибку предметной области из цепочки err
+func AsError(err error) (*Error, bool) {
+	var domainErr *Error
+	if errors.As(err, &domainErr) {
+		return domainErr, true
+	}
+	return nil, false
+}
+
+var (
+	// ErrNotFound означает, что какой-то элемент не был найден в базе данных
+	ErrNotFound = newError(CodeNotFound, "resource not found")
+
+	// ErrOneOfParametersNil означает, что один из обязательных параметров,
+	// переданных в метод сервиса, был пустым
+	ErrOneOfParametersNil = newError(CodeInvalidArgument, "one of the parameters is incorrect")
+	ErrUserIDNil          = newError(CodeInvalidArgument, "user ID is required")
+	ErrTeamExists         = newError(CodeTeamExists, "team already exists")
+	ErrPRExists           = newError(CodePRExists, "PR id already exists")
+	ErrPRNotExist         = newError(CodeNotFound, "pull request not found")
+	ErrAuthorCannotDelete = newError(CodeNotAssigned, "pull request author is not a reviewer and cannot be reassigned")
+	ErrNoCandidate        = newError(CodeNoCandidate, "no active replacement candidate in team")
+	ErrPRMerged           = newError(CodePRMerged, "pull request is already merged")
+	ErrUserNotAssigned    = newError(CodeNotAssigned, "reviewer is not assigned to this PR")
+	ErrAuthorIsInactive   = newError(CodeAuthorInactive, "author is inactive and cannot create pull requests")
+	ErrInvalidPeriod      = newError(CodeInvalidPeriod, "period end must be after its start")
+	ErrInvalidCalendar    = newError(CodeInvalidCalendar, "invalid iCalendar data")
+	ErrInvalidCapacity    = newError(CodeInvalidCapacity, "review capacity must not be negative")
+	ErrInvalidSLA         = newError(CodeInvalidSLA, "review SLA must be positive and shorter than escalation threshold")
+	ErrInvalidGrouping    = newError(CodeInvalidArgument, "group_by must be team, author or reviewer, bucket must be day or week")
+	ErrUnauthorized       = newError(CodeUnauthorized, "missing or invalid credentials")
+	ErrInvalidScope       = newError(CodeInvalidScope, "unknown scope")
+	ErrForbidden          = newError(CodeForbidden, "caller role does not allow this action")
+	ErrInvalidRole        = newError(CodeInvalidRole, "role must be one of admin, team_lead, member")
+	ErrInvalidVerdict     = newError(CodeInvalidVerdict, "verdict must be APPROVED or CHANGES_REQUESTED")
+	// ErrVersionConflict означает, что запись изменилась после того, как клиент прочитал ее версию
+	ErrVersionConflict = newError(CodeVersionConflict, "resource was modified, fetch the current version and retry")
+)
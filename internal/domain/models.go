@@ -1,29 +1,13 @@
 package domain
 
 import (
-	"errors"
 	"time"
 
 	"github.com/google/uuid"
 )
 
-var (
-	// ErrNotFound означает, что какой-то элемент не был найден в базе данных
-	ErrNotFound = errors.New("not found")
-
-	// ErrOneOfParametersNil означает, что один из обязательных параметров,
-	// переданных в метод сервиса, был пустым
-	ErrOneOfParametersNil = errors.New("one of parameters is nil")
-	ErrUserIDNil          = errors.New("userID is nil")
-	ErrTeamExists         = errors.New("team already exists")
-	ErrPRExists           = errors.New("pull request already exists")
-	ErrPRNotExist         = errors.New("pull request does not exist")
-	ErrAuthorCannotDelete = errors.New("author cannot be deleted")
-	ErrNoCandidate        = errors.New("no active replacement candidate in team")
-	ErrPRMerged           = errors.New("cannot reassign on a merged PR")
-	ErrUserNotAssigned    = errors.New("user to be reassigned is not currently a reviewer")
-	ErrAuthorIsInactive   = errors.New("author is inactive and cannot create pull requests")
-)
+// InitialVersion - версия только что созданного PR или команды, совпадает со значением по умолчанию в схеме
+const InitialVersion int64 = 1
 
 type StatusPR string
 
@@ -32,16 +16,46 @@ const (
 	StatusMerged StatusPR = "MERGED"
 )
 
+// Role - роль пользователя, определяющая, что он может делать в сервисе
+type Role string
+
+const (
+	// RoleAdmin может все, в том числе создавать команды и назначать роли
+	RoleAdmin Role = "admin"
+	// RoleTeamLead управляет участниками, настройками и переназначениями своей команды
+	RoleTeamLead Role = "team_lead"
+	// RoleMember меняет только свою активность, ставит вердикты на свои ревью и создает PR от своего имени
+	RoleMember Role = "member"
+	// RoleIntegration - API-ключ без права admin. Его ограничивают права ключа, действия администратора ему недоступны
+	RoleIntegration Role = "integration"
+)
+
+// Valid сообщает, что роль можно назначить пользователю
+func (r Role) Valid() bool {
+	return r == RoleAdmin || r == RoleTeamLead || r == RoleMember
+}
+
 type User struct {
 	ID       uuid.UUID
 	Username string
 	IsActive bool
 	TeamName string
+	Role     Role
+	Tags     []string
+	// ReviewCapacity - личный лимит открытых ревью, nil означает лимит команды
+	ReviewCapacity *int
 }
 
 type Team struct {
 	Name    string
 	Members []User
+	// DefaultReviewCapacity - лимит открытых ревью по умолчанию для участников, nil означает отсутствие лимита
+	DefaultReviewCapacity *int
+	// ReviewSLAMinutes и EscalationMinutes переопределяют пороги напоминания и переназначения ревью
+	ReviewSLAMinutes  *int
+	EscalationMinutes *int
+	// Version увеличивается при каждом изменении настроек или участников команды
+	Version int64
 }
 
 type PullRequest struct {
@@ -50,19 +64,519 @@ type PullRequest struct {
 	Status            StatusPR
 	AuthorID          uuid.UUID
 	AssignedReviewers []uuid.UUID
+	Labels            []string
 	CreatedAt         time.Time
 	MergedAt          *time.Time
+	// Version увеличивается при каждом изменении PR: мерже и переназначении ревьюеров
+	Version int64
+}
+
+// ReviewLoad описывает текущую нагрузку ревьюера и его действующий лимит
+type ReviewLoad struct {
+	OpenReviews int
+	Capacity    *int
+}
+
+// AtCapacity сообщает, что ревьюер не может принять еще одно ревью
+func (l ReviewLoad) AtCapacity() bool {
+	return l.Capacity != nil && l.OpenReviews >= *l.Capacity
+}
+
+// ReviewerSelection - результат подбора ревьюеров
+type ReviewerSelection struct {
+	Reviewers []uuid.UUID
+	// SkippedAtCapacity - количество кандидатов, пропущенных из-за достигнутого лимита
+	SkippedAtCapacity int
+}
+
+// AssignmentReport описывает, сколько ревьюеров требовалось назначить на PR и сколько удалось
+type AssignmentReport struct {
+	Required          int
+	Assigned          int
+	SkippedAtCapacity int
+}
+
+// LimitedByCapacity сообщает, что слоты остались незаполненными из-за лимитов ревьюеров
+func (r AssignmentReport) LimitedByCapacity() bool {
+	return r.Assigned < r.Required && r.SkippedAtCapacity > 0
 }
 
 type Reassignment struct {
 	PullRequestID string
 	OldUserID     uuid.UUID
 	NewUserID     uuid.UUID
+	// ExpectedVersion - версия PR, на основе которой принято решение. nil отключает проверку
+	ExpectedVersion *int64
+}
+
+// Unavailability описывает период, в который пользователь не может проводить ревью
+type Unavailability struct {
+	ID                  uuid.UUID
+	UserID              uuid.UUID
+	StartsAt            time.Time
+	EndsAt              time.Time
+	Reason              string
+	ReviewsReassignedAt *time.Time
+	CreatedAt           time.Time
+	// UID - идентификатор события календаря, из которого импортирован период; пустой для добавленных вручную
+	UID string
+}
+
+// StaleReview - назначение ревьюера на открытый PR, по которому истек SLA команды
+type StaleReview struct {
+	PullRequestID string
+	ReviewerID    uuid.UUID
+	TeamName      string
+	AssignedAt    time.Time
+	RemindedAt    *time.Time
+	// Escalate означает, что истек и второй порог, и ревью нужно переназначить
+	Escalate bool
+}
+
+// StaleReviewReport - итог одной обработки просроченных ревью
+type StaleReviewReport struct {
+	Stale     int
+	Reminded  int
+	Escalated int
+	// Skipped - просроченные ревью, которые не удалось переназначить (например, нет кандидатов)
+	Skipped int
+	// Failed - просроченные ревью, обработка которых завершилась ошибкой. Они будут обработаны при следующем запуске
+	Failed int
+}
+
+// LeaveReassignmentReport - итог одной обработки начавшихся периодов недоступности
+type LeaveReassignmentReport struct {
+	Periods    int
+	Reassigned int
+	// Failed - ревью и периоды, обработка которых завершилась ошибкой. Период с ошибкой не отмечается
+	// обработанным и повторяется при следующем запуске
+	Failed int
+}
+
+type ReviewEventType string
+
+const (
+	// EventReviewerAssigned - ревьюер назначен на новый PR
+	EventReviewerAssigned ReviewEventType = "REVIEWER_ASSIGNED"
+	// EventReviewerReassigned - ревью передано другому ревьюеру, новый указан в NewReviewerID
+	EventReviewerReassigned ReviewEventType = "REVIEWER_REASSIGNED"
+	EventReviewReminder     ReviewEventType = "REVIEW_REMINDER"
+	EventReviewEscalated    ReviewEventType = "REVIEW_ESCALATED"
+)
+
+// ReviewEvent - событие, связанное с назначением ревьюера
+type ReviewEvent struct {
+	Type          ReviewEventType
+	PullRequestID string
+	ReviewerID    uuid.UUID
+	// NewReviewerID заполняется, если ревью было переназначено
+	NewReviewerID *uuid.UUID
+	OccurredAt    time.Time
+}
+
+type JobRunStatus string
+
+const (
+	JobRunSucceeded JobRunStatus = "SUCCEEDED"
+	JobRunFailed    JobRunStatus = "FAILED"
+)
+
+// JobRun - запись об одном запуске фоновой задачи
+type JobRun struct {
+	ID         uuid.UUID
+	JobName    string
+	StartedAt  time.Time
+	FinishedAt time.Time
+	Status     JobRunStatus
+	Details    map[string]any
+	Error      string
+}
+
+// StatsFilter ограничивает выборку статистики. Пустые поля означают отсутствие фильтра.
+// Интервал полуоткрытый: [From, To)
+type StatsFilter struct {
+	From     *time.Time
+	To       *time.Time
+	TeamName string
+	Status   *StatusPR
 }
 
 type UserReviewStat struct {
+	UserID          uuid.UUID
+	Username        string
+	IsActive        bool
+	ReviewCount     int
+	OpenReviewCount int
+	ReviewCapacity  *int
+}
+
+// Utilization возвращает долю занятого лимита открытых ревью. Для пользователей без лимита возвращает nil
+func (s UserReviewStat) Utilization() *float64 {
+	if s.ReviewCapacity == nil || *s.ReviewCapacity == 0 {
+		return nil
+	}
+	utilization := float64(s.OpenReviewCount) / float64(*s.ReviewCapacity)
+	return &utilization
+}
+
+// TeamReviewStat - агрегаты по PR, авторы которых состоят в команде
+type TeamReviewStat struct {
+	TeamName            string
+	PRsOpened           int
+	PRsMerged           int
+	AvgReviewersPerPR   float64
+	PRsWithoutReviewers int
+}
+
+// ZeroReviewersShare возвращает долю открытых за период PR, на которые не назначено ни одного ревьюера
+func (s TeamReviewStat) ZeroReviewersShare() float64 {
+	if s.PRsOpened == 0 {
+		return 0
+	}
+	return float64(s.PRsWithoutReviewers) / float64(s.PRsOpened)
+}
+
+// LatencyGroupBy определяет, по какому признаку группируется аналитика задержек. Команды и авторы
+// получают время до мержа, ревьюеры - время от назначения до вердикта
+type LatencyGroupBy string
+
+const (
+	LatencyByTeam     LatencyGroupBy = "team"
+	LatencyByAuthor   LatencyGroupBy = "author"
+	LatencyByReviewer LatencyGroupBy = "reviewer"
+)
+
+// LatencyBucket - размер интервала для построения тренда
+type LatencyBucket string
+
+const (
+	BucketDay  LatencyBucket = "day"
+	BucketWeek LatencyBucket = "week"
+)
+
+// LatencyFilter ограничивает выборку для аналитики задержек. Период [From, To) применяется к времени мержа,
+// а при группировке по ревьюерам - к времени вердикта. TeamName - команда автора или ревьюера.
+// Пустой Bucket отключает разбивку по интервалам
+type LatencyFilter struct {
+	From     *time.Time
+	To       *time.Time
+	TeamName string
+	GroupBy  LatencyGroupBy
+	Bucket   LatencyBucket
+}
+
+// LatencyPercentiles - перцентили длительности по выборке из Count PR или ревью
+type LatencyPercentiles struct {
+	Count int
+	P50   time.Duration
+	P90   time.Duration
+	P99   time.Duration
+}
+
+// LatencyBucketStat - перцентили для PR, смерженных (или ревью, завершенных) в интервале, начинающемся в Start
+type LatencyBucketStat struct {
+	Start time.Time
+	LatencyPercentiles
+}
+
+// LatencySeries - задержки одной группы: время до мержа команды или автора либо время до вердикта ревьюера.
+// Повторный вердикт заменяет предыдущий, поэтому для ревьюера считается время до последнего вердикта
+type LatencySeries struct {
+	Key     string
+	Name    string
+	Overall LatencyPercentiles
+	Buckets []LatencyBucketStat
+}
+
+// FairnessFilter задает период и команду для отчета о равномерности назначений.
+// Пустой TeamName означает все команды
+type FairnessFilter struct {
+	From     time.Time
+	To       time.Time
+	TeamName string
+}
+
+// MemberAssignments - число назначений участника команды на ревью за период
+type MemberAssignments struct {
+	TeamName    string
 	UserID      uuid.UUID
 	Username    string
 	IsActive    bool
-	ReviewCount int
+	Assignments int
+}
+
+// MemberFairness - доля назначений участника в сравнении с ожидаемой по его активным дням
+type MemberFairness struct {
+	UserID        uuid.UUID
+	Username      string
+	IsActive      bool
+	Assignments   int
+	ActiveDays    float64
+	Share         float64
+	ExpectedShare float64
+	Deviation     float64
+}
+
+// TeamFairness - отчет о равномерности назначений в команде.
+// Gini считается по числу назначений на активный день: 0 - полное равенство, близко к 1 - все ревью у одного участника
+type TeamFairness struct {
+	TeamName         string
+	TotalAssignments int
+	Gini             float64
+	Members          []MemberFairness
+}
+
+// PullRequestFilter ограничивает выборку PR для выгрузки. Пустые поля означают отсутствие фильтра.
+// Период [From, To) применяется ко времени создания PR
+type PullRequestFilter struct {
+	From     *time.Time
+	To       *time.Time
+	TeamName string
+	AuthorID *uuid.UUID
+	Status   *StatusPR
+}
+
+// MigrationState - версия схемы базы в сравнении с версией, которую ожидает приложение
+type MigrationState struct {
+	Current  uint
+	Expected uint
+	Dirty    bool
+}
+
+// UpToDate сообщает, что схема доведена до ожидаемой версии и последняя миграция завершилась
+func (s MigrationState) UpToDate() bool {
+	return !s.Dirty && s.Current == s.Expected
+}
+
+// JobState - состояние фоновой задачи для проверки готовности
+type JobState struct {
+	Name           string
+	Interval       time.Duration
+	Running        bool
+	LastStartedAt  *time.Time
+	LastFinishedAt *time.Time
+	LastStatus     JobRunStatus
+	LastError      string
+}
+
+// SchedulerState - состояние планировщика фоновых задач
+type SchedulerState struct {
+	Running bool
+	Jobs    []JobState
+}
+
+type HealthStatus string
+
+const (
+	HealthOK   HealthStatus = "ok"
+	HealthFail HealthStatus = "fail"
+)
+
+// HealthCheckResult - результат одной проверки готовности
+type HealthCheckResult struct {
+	Name    string
+	Status  HealthStatus
+	Latency time.Duration
+	Error   string
+	Details map[string]any
+}
+
+// ReadinessReport - итог проверки готовности: сервис готов, только если прошли все проверки
+type ReadinessReport struct {
+	Status HealthStatus
+	Checks []HealthCheckResult
+}
+
+// Scope - право доступа API-ключа к группе эндпоинтов
+type Scope string
+
+const (
+	ScopeTeamsRead  Scope = "teams:read"
+	ScopeTeamsWrite Scope = "teams:write"
+	ScopeUsersRead  Scope = "users:read"
+	ScopeUsersWrite Scope = "users:write"
+	ScopePRsRead    Scope = "prs:read"
+	ScopePRsWrite   Scope = "prs:write"
+	ScopeStatsRead  Scope = "stats:read"
+	ScopeJobsRead   Scope = "jobs:read"
+	// ScopeAdmin дает все остальные права и доступ к управлению ключами и журналу аудита
+	ScopeAdmin Scope = "admin"
+)
+
+// Scopes - все известные права доступа
+var Scopes = []Scope{
+	ScopeTeamsRead, ScopeTeamsWrite,
+	ScopeUsersRead, ScopeUsersWrite,
+	ScopePRsRead, ScopePRsWrite,
+	ScopeStatsRead, ScopeJobsRead,
+	ScopeAdmin,
+}
+
+func (s Scope) Valid() bool {
+	for _, known := range Scopes {
+		if s == known {
+			return true
+		}
+	}
+	return false
+}
+
+type PrincipalType string
+
+const (
+	PrincipalAPIKey PrincipalType = "api_key"
+	// PrincipalUser - пользователь сервиса, аутентифицированный по JWT провайдера удостоверений
+	PrincipalUser PrincipalType = "user"
+	// PrincipalAnonymous используется, когда аутентификация отключена в конфигурации
+	PrincipalAnonymous PrincipalType = "anonymous"
+)
+
+// Principal - аутентифицированный вызывающий, от имени которого выполняется запрос
+type Principal struct {
+	Type   PrincipalType
+	ID     string
+	Name   string
+	Scopes []Scope
+	// UserID - пользователь сервиса, от имени которого действует вызывающий. nil для API-ключей
+	UserID *uuid.UUID
+	Role   Role
+	// TeamName - команда пользователя, по ней проверяются права руководителя команды
+	TeamName string
+}
+
+// IsUser сообщает, что вызывающий действует от имени пользователя id
+func (p Principal) IsUser(id uuid.UUID) bool {
+	return p.UserID != nil && *p.UserID == id
+}
+
+// IsAdmin сообщает, что вызывающему доступны действия администратора
+func (p Principal) IsAdmin() bool {
+	return p.Role == RoleAdmin
+}
+
+// ManagesTeam сообщает, что вызывающий может управлять командой teamName:
+// это администратор, интеграция или руководитель именно этой команды
+func (p Principal) ManagesTeam(teamName string) bool {
+	switch p.Role {
+	case RoleAdmin, RoleIntegration:
+		return true
+	case RoleTeamLead:
+		return p.TeamName == teamName
+	}
+	return false
+}
+
+// HasScope сообщает, есть ли у вызывающего право scope. Право admin включает все остальные
+func (p Principal) HasScope(scope Scope) bool {
+	for _, s := range p.Scopes {
+		if s == scope || s == ScopeAdmin {
+			return true
+		}
+	}
+	return false
+}
+
+// APIKey - ключ доступа к API. Сам ключ не хранится, только его SHA-256 хеш и префикс для опознания
+type APIKey struct {
+	ID         uuid.UUID
+	Name       string
+	Prefix     string
+	Hash       string
+	Scopes     []Scope
+	CreatedBy  string
+	CreatedAt  time.Time
+	ExpiresAt  *time.Time
+	RevokedAt  *time.Time
+	LastUsedAt *time.Time
+}
+
+// Active сообщает, что ключ не отозван и не истек на момент now
+func (k APIKey) Active(now time.Time) bool {
+	return k.RevokedAt == nil && (k.ExpiresAt == nil || now.Before(*k.ExpiresAt))
+}
+
+// AuditEvent - запись журнала аудита об изменяющем запросе
+type AuditEvent struct {
+	ID            uuid.UUID
+	OccurredAt    time.Time
+	PrincipalType PrincipalType
+	PrincipalID   string
+	PrincipalName string
+	Action        string
+	Status        int
+	RequestID     string
+	Details       map[string]any
+}
+
+// Verdict - решение ревьюера по PR
+type Verdict string
+
+const (
+	VerdictApproved         Verdict = "APPROVED"
+	VerdictChangesRequested Verdict = "CHANGES_REQUESTED"
+)
+
+func (v Verdict) Valid() bool {
+	return v == VerdictApproved || v == VerdictChangesRequested
+}
+
+// ReviewVerdict - вердикт назначенного ревьюера. Повторный вердикт заменяет предыдущий
+type ReviewVerdict struct {
+	PullRequestID string
+	ReviewerID    uuid.UUID
+	Verdict       Verdict
+	SubmittedAt   time.Time
+}
+
+// Review - назначение ревьюера на PR. Verdict и VerdictAt пусты, пока ревьюер не вынес решение
+type Review struct {
+	PullRequestID string
+	ReviewerID    uuid.UUID
+	AssignedAt    time.Time
+	Verdict       *Verdict
+	VerdictAt     *time.Time
+}
+
+// RateLimit - параметры корзины токенов: Rate токенов в секунду, не больше Burst в запасе
+type RateLimit struct {
+	Rate  float64
+	Burst int
+}
+
+// Valid сообщает, что корзина пополняется и вмещает хотя бы один запрос
+func (l RateLimit) Valid() bool {
+	return l.Rate > 0 && l.Burst >= 1
+}
+
+// RefillDuration - время, за которое пустая корзина наполняется полностью
+func (l RateLimit) RefillDuration() time.Duration {
+	return time.Duration(float64(l.Burst) / l.Rate * float64(time.Second))
+}
+
+// RateLimitDecision - результат попытки списать токен из корзины
+type RateLimitDecision struct {
+	Allowed bool
+	// Remaining - целое число токенов, оставшихся после запроса
+	Remaining int
+	// RetryAfter - через сколько появится следующий токен, если запрос отклонен
+	RetryAfter time.Duration
+}
+
+// IdempotencyRecord - сохраненный результат запроса с заголовком Idempotency-Key.
+// Ключи разных вызывающих не пересекаются. StatusCode 0 означает, что первый запрос еще выполняется
+type IdempotencyRecord struct {
+	PrincipalID string
+	Key         string
+	// RequestHash - SHA-256 метода, пути и тела запроса, по нему распознается повтор с другим телом
+	RequestHash string
+	StatusCode  int
+	ContentType string
+	// Headers - заголовки ответа, которые отдаются вместе с телом при повторе
+	Headers map[string]string
+	Body    []byte
+}
+
+// Completed сообщает, что ответ на первый запрос сохранен и его можно повторить
+func (r IdempotencyRecord) Completed() bool {
+	return r.StatusCode != 0
 }
@@ -0,0 +1,70 @@
+package events
+
+import (
+	"context"
+	"go.uber.org/zap"
+	"sync"
+
+	"avito/internal/domain"
+)
+
+// subscriberBuffer - размер буфера канала одного подписчика
+const subscriberBuffer = 64
+
+// Bus рассылает события ревью всем подписчикам внутри процесса.
+// Публикация не блокируется: если подписчик не успевает читать, событие для него отбрасывается.
+type Bus struct {
+	mu          sync.RWMutex
+	subscribers map[int]chan domain.ReviewEvent
+	nextID      int
+	log         *zap.Logger
+}
+
+func NewBus(log *zap.Logger) *Bus {
+	return &Bus{
+		subscribers: make(map[int]chan domain.ReviewEvent),
+		log:         log.Named("EventBus"),
+	}
+}
+
+// Publish отправляет событие всем подписчикам
+func (b *Bus) Publish(_ context.Context, event domain.ReviewEvent) {
+	log := b.log.With(
+		zap.String("event_type", string(event.Type)),
+		zap.String("pr_id", event.PullRequestID),
+		zap.String("reviewer_id", event.ReviewerID.String()),
+	)
+	log.Info("Review event published")
+
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	for id, ch := range b.subscribers {
+		select {
+		case ch <- event:
+		default:
+			log.Warn("Subscriber is too slow, event dropped", zap.Int("subscriber_id", id))
+		}
+	}
+}
+
+// Subscribe возвращает канал событий и функцию отписки, которая закрывает канал
+func (b *Bus) Subscribe() (<-chan domain.ReviewEvent, func()) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	id := b.nextID
+	b.nextID++
+	ch := make(chan domain.ReviewEvent, subscriberBuffer)
+	b.subscribers[id] = ch
+
+	var once sync.Once
+	unsubscribe := func() {
+		once.Do(func() {
+			b.mu.Lock()
+			defer b.mu.Unlock()
+			delete(b.subscribers, id)
+			close(ch)
+		})
+	}
+	return ch, unsubscribe
+}
@@ -0,0 +1,65 @@
+package metrics
+
+import (
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// PoolStatter отдает текущее состояние пула соединений
+type PoolStatter interface {
+	Stat() *pgxpool.Stat
+}
+
+// PoolCollector снимает состояние pgxpool при каждом запросе /metrics
+type PoolCollector struct {
+	pool PoolStatter
+
+	acquired     *prometheus.Desc
+	idle         *prometheus.Desc
+	constructing *prometheus.Desc
+	total        *prometheus.Desc
+	max          *prometheus.Desc
+	waited       *prometheus.Desc
+	waitDuration *prometheus.Desc
+	canceled     *prometheus.Desc
+}
+
+func NewPoolCollector(pool PoolStatter) *PoolCollector {
+	desc := func(name, help string) *prometheus.Desc {
+		return prometheus.NewDesc(prometheus.BuildFQName(namespace, "db_pool", name), help, nil, nil)
+	}
+	return &PoolCollector{
+		pool:         pool,
+		acquired:     desc("acquired_connections", "Number of connections currently acquired from the pool."),
+		idle:         desc("idle_connections", "Number of idle connections in the pool."),
+		constructing: desc("constructing_connections", "Number of connections being established."),
+		total:        desc("total_connections", "Total number of connections in the pool."),
+		max:          desc("max_connections", "Maximum size of the pool."),
+		waited:       desc("waited_acquires_total", "Number of acquires that had to wait for a connection."),
+		waitDuration: desc("acquire_wait_seconds_total", "Total time spent waiting for a connection when the pool was empty."),
+		canceled:     desc("canceled_acquires_total", "Number of acquires canceled while waiting."),
+	}
+}
+
+func (c *PoolCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.acquired
+	ch <- c.idle
+	ch <- c.constructing
+	ch <- c.total
+	ch <- c.max
+	ch <- c.waited
+	ch <- c.waitDuration
+	ch <- c.canceled
+}
+
+func (c *PoolCollector) Collect(ch chan<- prometheus.Metric) {
+	stat := c.pool.Stat()
+	ch <- prometheus.MustNewConstMetric(c.acquired, prometheus.GaugeValue, float64(stat.AcquiredConns()))
+	ch <- prometheus.MustNewConstMetric(c.idle, prometheus.GaugeValue, float64(stat.IdleConns()))
+	ch <- prometheus.MustNewConstMetric(c.constructing, prometheus.GaugeValue, float64(stat.ConstructingConns()))
+	ch <- prometheus.MustNewConstMetric(c.total, prometheus.GaugeValue, float64(stat.TotalConns()))
+	ch <- prometheus.MustNewConstMetric(c.max, prometheus.GaugeValue, float64(stat.MaxConns()))
+	ch <- prometheus.MustNewConstMetric(c.waited, prometheus.CounterValue, float64(stat.EmptyAcquireCount()))
+	ch <- prometheus.MustNewConstMetric(c.waitDuration, prometheus.CounterValue, stat.EmptyAcquireWaitTime().Seconds())
+	ch <- prometheus.MustNewConstMetric(c.canceled, prometheus.CounterValue, float64(stat.CanceledAcquireCount()))
+}
@@ -0,0 +1,85 @@
+// Package ratelimit хранит корзины токенов в памяти процесса.
+// Для нескольких экземпляров сервиса используется хранилище в PostgreSQL с тем же интерфейсом.
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+
+	"avito/internal/domain"
+)
+
+// sweepInterval определяет, как часто из памяти удаляются полностью наполнившиеся корзины
+const sweepInterval = time.Minute
+
+type bucket struct {
+	tokens    float64
+	updatedAt time.Time
+	limit     domain.RateLimit
+}
+
+// refill пополняет корзину за время, прошедшее с последнего обращения
+func (b *bucket) refill(now time.Time) {
+	elapsed := now.Sub(b.updatedAt).Seconds()
+	if elapsed > 0 {
+		b.tokens = math.Min(float64(b.limit.Burst), b.tokens+elapsed*b.limit.Rate)
+		b.updatedAt = now
+	}
+}
+
+// MemoryStore - корзины токенов одного экземпляра сервиса
+type MemoryStore struct {
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		buckets:   make(map[string]*bucket),
+		lastSweep: time.Now(),
+		now:       time.Now,
+	}
+}
+
+// TakeRateLimitToken списывает токен из корзины key. Новая корзина создается полной
+func (s *MemoryStore) TakeRateLimitToken(_ context.Context, key string, limit domain.RateLimit) (domain.RateLimitDecision, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	s.sweep(now)
+
+	b, ok := s.buckets[key]
+	if !ok {
+		b = &bucket{tokens: float64(limit.Burst), updatedAt: now}
+		s.buckets[key] = b
+	}
+	b.limit = limit
+	b.refill(now)
+
+	if b.tokens < 1 {
+		return domain.RateLimitDecision{
+			Allowed:    false,
+			RetryAfter: time.Duration((1 - b.tokens) / limit.Rate * float64(time.Second)),
+		}, nil
+	}
+	b.tokens--
+	return domain.RateLimitDecision{Allowed: true, Remaining: int(b.tokens)}, nil
+}
+
+// sweep удаляет корзины, которые успели наполниться: они ничем не отличаются от отсутствующих
+func (s *MemoryStore) sweep(now time.Time) {
+	if now.Sub(s.lastSweep) < sweepInterval {
+		return
+	}
+	s.lastSweep = now
+	for key, b := range s.buckets {
+		if now.Sub(b.updatedAt) >= b.limit.RefillDuration() {
+			delete(s.buckets, key)
+		}
+	}
+}
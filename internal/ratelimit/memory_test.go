@@ -0,0 +1,120 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"avito/internal/domain"
+)
+
+// step - одно обращение к корзине key через after после предыдущего
+type step struct {
+	after time.Duration
+	key   string
+	limit domain.RateLimit
+	want  domain.RateLimitDecision
+}
+
+func TestMemoryStoreTokenBucket(t *testing.T) {
+	limit := domain.RateLimit{Rate: 2, Burst: 3}
+	allowed := func(remaining int) domain.RateLimitDecision {
+		return domain.RateLimitDecision{Allowed: true, Remaining: remaining}
+	}
+	denied := func(retryAfter time.Duration) domain.RateLimitDecision {
+		return domain.RateLimitDecision{RetryAfter: retryAfter}
+	}
+
+	tests := []struct {
+		name  string
+		steps []step
+	}{
+		{
+			name: "new bucket is full and the burst is spent",
+			steps: []step{
+				{key: "a", limit: limit, want: allowed(2)},
+				{key: "a", limit: limit, want: allowed(1)},
+				{key: "a", limit: limit, want: allowed(0)},
+				{key: "a", limit: limit, want: denied(500 * time.Millisecond)},
+			},
+		},
+		{
+			name: "tokens refill at the rate",
+			steps: []step{
+				{key: "a", limit: limit, want: allowed(2)},
+				{key: "a", limit: limit, want: allowed(1)},
+				{key: "a", limit: limit, want: allowed(0)},
+				{after: 250 * time.Millisecond, key: "a", limit: limit, want: denied(250 * time.Millisecond)},
+				{after: 250 * time.Millisecond, key: "a", limit: limit, want: allowed(0)},
+			},
+		},
+		{
+			name: "refill is capped by the burst",
+			steps: []step{
+				{key: "a", limit: limit, want: allowed(2)},
+				{after: time.Hour, key: "a", limit: limit, want: allowed(2)},
+			},
+		},
+		{
+			name: "keys have separate buckets",
+			steps: []step{
+				{key: "a", limit: domain.RateLimit{Rate: 1, Burst: 1}, want: allowed(0)},
+				{key: "a", limit: domain.RateLimit{Rate: 1, Burst: 1}, want: denied(time.Second)},
+				{key: "b", limit: domain.RateLimit{Rate: 1, Burst: 1}, want: allowed(0)},
+			},
+		},
+		{
+			name: "lower burst applies to an existing bucket",
+			steps: []step{
+				{key: "a", limit: limit, want: allowed(2)},
+				{after: time.Second, key: "a", limit: domain.RateLimit{Rate: 2, Burst: 1}, want: allowed(0)},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := NewMemoryStore()
+			now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+			store.now = func() time.Time { return now }
+			store.lastSweep = now
+
+			for i, s := range tt.steps {
+				now = now.Add(s.after)
+				got, err := store.TakeRateLimitToken(context.Background(), s.key, s.limit)
+				if err != nil {
+					t.Fatalf("step %d: got error %v", i, err)
+				}
+				if got != s.want {
+					t.Fatalf("step %d: got %+v, want %+v", i, got, s.want)
+				}
+			}
+		})
+	}
+}
+
+func TestMemoryStoreSweepsFullBuckets(t *testing.T) {
+	store := NewMemoryStore()
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	store.now = func() time.Time { return now }
+	store.lastSweep = now
+
+	slow := domain.RateLimit{Rate: 0.001, Burst: 1}
+	if _, err := store.TakeRateLimitToken(context.Background(), "fast", domain.RateLimit{Rate: 10, Burst: 1}); err != nil {
+		t.Fatalf("got error %v", err)
+	}
+	if _, err := store.TakeRateLimitToken(context.Background(), "slow", slow); err != nil {
+		t.Fatalf("got error %v", err)
+	}
+
+	// Через sweepInterval корзина fast уже полна и удаляется, корзина slow еще пополняется
+	now = now.Add(sweepInterval)
+	if _, err := store.TakeRateLimitToken(context.Background(), "other", slow); err != nil {
+		t.Fatalf("got error %v", err)
+	}
+	if _, ok := store.buckets["fast"]; ok {
+		t.Fatalf("full bucket was not swept")
+	}
+	if _, ok := store.buckets["slow"]; !ok {
+		t.Fatalf("refilling bucket was swept")
+	}
+}
@@ -0,0 +1,139 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go.uber.org/zap"
+	"time"
+
+	"avito/internal/domain"
+	"avito/pkg/logger"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	apiKeyColumns = `id, name, prefix, key_hash, scopes, created_by, created_at, expires_at, revoked_at, last_used_at`
+
+	saveAPIKeyQuery = `INSERT INTO api_keys (id, name, prefix, key_hash, scopes, created_by, created_at, expires_at)
+					   VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`
+
+	getAPIKeyByHashQuery = `SELECT ` + apiKeyColumns + ` FROM api_keys WHERE key_hash = $1`
+
+	getAPIKeysQuery = `SELECT ` + apiKeyColumns + ` FROM api_keys ORDER BY created_at DESC`
+
+	revokeAPIKeyQuery = `UPDATE api_keys SET revoked_at = COALESCE(revoked_at, $2)
+						 WHERE id = $1
+						 RETURNING ` + apiKeyColumns
+
+	touchAPIKeyQuery = `UPDATE api_keys SET last_used_at = $2 WHERE id = $1`
+)
+
+// CreateAPIKey сохраняет новый ключ
+func (r *APIKeyRepository) CreateAPIKey(ctx context.Context, key domain.APIKey) error {
+	log := logger.FromContext(ctx, r.log).With(zap.String("api_key_id", key.ID.String()), zap.String("name", key.Name))
+	log.Debug("Saving API key")
+
+	_, err := r.pool.Exec(ctx, saveAPIKeyQuery,
+		key.ID, key.Name, key.Prefix, key.Hash, scopesToStrings(key.Scopes), key.CreatedBy, key.CreatedAt, key.ExpiresAt)
+	if err != nil {
+		log.Error("Failed to save API key", zap.Error(err))
+		return fmt.Errorf("failed to save API key: %w", err)
+	}
+	return nil
+}
+
+// GetAPIKeyByHash ищет ключ по хешу. Возвращает domain.ErrNotFound, если такого ключа нет
+func (r *APIKeyRepository) GetAPIKeyByHash(ctx context.Context, hash string) (*domain.APIKey, error) {
+	log := logger.FromContext(ctx, r.log)
+	log.Debug("Getting API key by hash")
+
+	key, err := scanAPIKey(r.pool.QueryRow(ctx, getAPIKeyByHashQuery, hash))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		log.Error("Failed to get API key", zap.Error(err))
+		return nil, fmt.Errorf("failed to get API key: %w", err)
+	}
+	return key, nil
+}
+
+// GetAPIKeys возвращает все ключи, начиная с новых
+func (r *APIKeyRepository) GetAPIKeys(ctx context.Context) ([]domain.APIKey, error) {
+	log := logger.FromContext(ctx, r.log)
+	log.Debug("Getting API keys")
+
+	rows, err := r.pool.Query(ctx, getAPIKeysQuery)
+	if err != nil {
+		log.Error("Failed to query API keys", zap.Error(err))
+		return nil, fmt.Errorf("failed to query API keys: %w", err)
+	}
+	defer rows.Close()
+
+	keys := make([]domain.APIKey, 0)
+	for rows.Next() {
+		key, err := scanAPIKey(rows)
+		if err != nil {
+			log.Error("Failed to scan API key", zap.Error(err))
+			return nil, fmt.Errorf("failed to scan API key: %w", err)
+		}
+		keys = append(keys, *key)
+	}
+	if err := rows.Err(); err != nil {
+		log.Error("Error after iterating over API keys", zap.Error(err))
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+	return keys, nil
+}
+
+// RevokeAPIKey отзывает ключ. Повторный отзыв сохраняет исходное время отзыва
+func (r *APIKeyRepository) RevokeAPIKey(ctx context.Context, id uuid.UUID, revokedAt time.Time) (*domain.APIKey, error) {
+	log := logger.FromContext(ctx, r.log).With(zap.String("api_key_id", id.String()))
+	log.Debug("Revoking API key")
+
+	key, err := scanAPIKey(r.pool.QueryRow(ctx, revokeAPIKeyQuery, id, revokedAt))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		log.Error("Failed to revoke API key", zap.Error(err))
+		return nil, fmt.Errorf("failed to revoke API key: %w", err)
+	}
+	return key, nil
+}
+
+// TouchAPIKey обновляет время последнего использования ключа
+func (r *APIKeyRepository) TouchAPIKey(ctx context.Context, id uuid.UUID, usedAt time.Time) error {
+	log := logger.FromContext(ctx, r.log).With(zap.String("api_key_id", id.String()))
+
+	if _, err := r.pool.Exec(ctx, touchAPIKeyQuery, id, usedAt); err != nil {
+		log.Error("Failed to update API key last use", zap.Error(err))
+		return fmt.Errorf("failed to update API key last use: %w", err)
+	}
+	return nil
+}
+
+func scanAPIKey(row pgx.Row) (*domain.APIKey, error) {
+	var key domain.APIKey
+	var scopes []string
+	if err := row.Scan(&key.ID, &key.Name, &key.Prefix, &key.Hash, &scopes, &key.CreatedBy,
+		&key.CreatedAt, &key.ExpiresAt, &key.RevokedAt, &key.LastUsedAt); err != nil {
+		return nil, err
+	}
+	key.Scopes = make([]domain.Scope, 0, len(scopes))
+	for _, scope := range scopes {
+		key.Scopes = append(key.Scopes, domain.Scope(scope))
+	}
+	return &key, nil
+}
+
+func scopesToStrings(scopes []domain.Scope) []string {
+	result := make([]string, 0, len(scopes))
+	for _, scope := range scopes {
+		result = append(result, string(scope))
+	}
+	return result
+}
@@ -0,0 +1,69 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"go.uber.org/zap"
+
+	"avito/internal/domain"
+	"avito/pkg/logger"
+)
+
+const (
+	saveAuditEventQuery = `INSERT INTO audit_events (id, occurred_at, principal_type, principal_id, principal_name, action, status, request_id, details)
+						   VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`
+
+	getAuditEventsQuery = `SELECT id, occurred_at, principal_type, principal_id, principal_name, action, status, request_id, details
+						   FROM audit_events
+						   WHERE $1 = '' OR principal_id = $1
+						   ORDER BY occurred_at DESC
+						   LIMIT $2`
+)
+
+// SaveAuditEvent сохраняет запись журнала аудита
+func (r *AuditRepository) SaveAuditEvent(ctx context.Context, event domain.AuditEvent) error {
+	log := logger.FromContext(ctx, r.log).With(zap.String("action", event.Action))
+	log.Debug("Saving audit event")
+
+	details := event.Details
+	if details == nil {
+		details = map[string]any{}
+	}
+	_, err := r.pool.Exec(ctx, saveAuditEventQuery,
+		event.ID, event.OccurredAt, event.PrincipalType, event.PrincipalID, event.PrincipalName,
+		event.Action, event.Status, event.RequestID, details)
+	if err != nil {
+		log.Error("Failed to save audit event", zap.Error(err))
+		return fmt.Errorf("failed to save audit event: %w", err)
+	}
+	return nil
+}
+
+// GetAuditEvents возвращает последние записи журнала. Пустой principalID означает всех вызывающих
+func (r *AuditRepository) GetAuditEvents(ctx context.Context, principalID string, limit int) ([]domain.AuditEvent, error) {
+	log := logger.FromContext(ctx, r.log).With(zap.String("principal_id", principalID), zap.Int("limit", limit))
+	log.Debug("Getting audit events")
+
+	rows, err := r.pool.Query(ctx, getAuditEventsQuery, principalID, limit)
+	if err != nil {
+		log.Error("Failed to query audit events", zap.Error(err))
+		return nil, fmt.Errorf("failed to query audit events: %w", err)
+	}
+	defer rows.Close()
+
+	events := make([]domain.AuditEvent, 0)
+	for rows.Next() {
+		var event domain.AuditEvent
+		if err := rows.Scan(&event.ID, &event.OccurredAt, &event.PrincipalType, &event.PrincipalID, &event.PrincipalName,
+			&event.Action, &event.Status, &event.RequestID, &event.Details); err != nil {
+			log.Error("Failed to scan audit event", zap.Error(err))
+			return nil, fmt.Errorf("failed to scan audit event: %w", err)
+		}
+		events = append(events, event)
+	}
+	if err := rows.Err(); err != nil {
+		log.Error("Error after iterating over audit events", zap.Error(err))
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+	return events, nil
+}
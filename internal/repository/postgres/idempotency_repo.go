@@ -0,0 +1,102 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go.uber.org/zap"
+	"time"
+
+	"avito/internal/domain"
+	"avito/pkg/logger"
+
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	// reserveIdempotencyKeyQuery занимает ключ для первого запроса. Занятый ключ переходит к новому запросу,
+	// только если запись истекла или первый запрос так и не завершился за $5 секунд
+	reserveIdempotencyKeyQuery = `INSERT INTO idempotency_keys AS k (principal_id, key, request_hash, created_at, expires_at)
+								  VALUES ($1, $2, $3, NOW(), NOW() + make_interval(secs => $4))
+								  ON CONFLICT (principal_id, key) DO UPDATE
+								  SET request_hash = EXCLUDED.request_hash, status_code = NULL, content_type = NULL,
+								      response_headers = NULL, response_body = NULL, created_at = NOW(), expires_at = EXCLUDED.expires_at
+								  WHERE k.expires_at <= NOW()
+								     OR (k.status_code IS NULL AND k.created_at <= NOW() - make_interval(secs => $5))
+								  RETURNING k.key`
+
+	getIdempotencyKeyQuery = `SELECT principal_id, key, request_hash, COALESCE(status_code, 0), COALESCE(content_type, ''),
+								 COALESCE(response_headers, '{}'::jsonb), response_body
+							  FROM idempotency_keys WHERE principal_id = $1 AND key = $2`
+
+	completeIdempotencyKeyQuery = `UPDATE idempotency_keys SET status_code = $3, content_type = $4, response_headers = $5, response_body = $6
+								   WHERE principal_id = $1 AND key = $2`
+
+	releaseIdempotencyKeyQuery = `DELETE FROM idempotency_keys WHERE principal_id = $1 AND key = $2 AND status_code IS NULL`
+
+	deleteExpiredIdempotencyKeysQuery = `DELETE FROM idempotency_keys WHERE expires_at <= NOW()`
+)
+
+// ReserveIdempotencyKey занимает ключ запроса на ttl. Если ключ уже занят, возвращает существующую запись,
+// иначе nil. lockTimeout - через сколько незавершенный первый запрос считается брошенным
+func (r *IdempotencyRepository) ReserveIdempotencyKey(ctx context.Context, record domain.IdempotencyRecord, ttl, lockTimeout time.Duration) (*domain.IdempotencyRecord, error) {
+	log := logger.FromContext(ctx, r.log).With(zap.String("idempotency_key", record.Key))
+
+	var key string
+	err := r.pool.QueryRow(ctx, reserveIdempotencyKeyQuery, record.PrincipalID, record.Key, record.RequestHash,
+		ttl.Seconds(), lockTimeout.Seconds()).Scan(&key)
+	if err == nil {
+		return nil, nil
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		log.Error("Failed to reserve idempotency key", zap.Error(err))
+		return nil, fmt.Errorf("failed to reserve idempotency key: %w", err)
+	}
+
+	var existing domain.IdempotencyRecord
+	err = r.pool.QueryRow(ctx, getIdempotencyKeyQuery, record.PrincipalID, record.Key).Scan(
+		&existing.PrincipalID, &existing.Key, &existing.RequestHash,
+		&existing.StatusCode, &existing.ContentType, &existing.Headers, &existing.Body,
+	)
+	if err != nil {
+		log.Error("Failed to get idempotency key", zap.Error(err))
+		return nil, fmt.Errorf("failed to get idempotency key: %w", err)
+	}
+	return &existing, nil
+}
+
+// CompleteIdempotencyKey сохраняет ответ на первый запрос для повторов
+func (r *IdempotencyRepository) CompleteIdempotencyKey(ctx context.Context, record domain.IdempotencyRecord) error {
+	log := logger.FromContext(ctx, r.log).With(zap.String("idempotency_key", record.Key))
+	commandTag, err := r.pool.Exec(ctx, completeIdempotencyKeyQuery, record.PrincipalID, record.Key,
+		record.StatusCode, record.ContentType, record.Headers, record.Body)
+	if err != nil {
+		log.Error("Failed to save idempotent response", zap.Error(err))
+		return fmt.Errorf("failed to save idempotent response: %w", err)
+	}
+	if commandTag.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+// ReleaseIdempotencyKey освобождает ключ незавершенного запроса, чтобы повтор выполнился заново
+func (r *IdempotencyRepository) ReleaseIdempotencyKey(ctx context.Context, principalID, key string) error {
+	log := logger.FromContext(ctx, r.log).With(zap.String("idempotency_key", key))
+	if _, err := r.pool.Exec(ctx, releaseIdempotencyKeyQuery, principalID, key); err != nil {
+		log.Error("Failed to release idempotency key", zap.Error(err))
+		return fmt.Errorf("failed to release idempotency key: %w", err)
+	}
+	return nil
+}
+
+// DeleteExpiredIdempotencyKeys удаляет истекшие ключи и возвращает их количество
+func (r *IdempotencyRepository) DeleteExpiredIdempotencyKeys(ctx context.Context) (int64, error) {
+	log := logger.FromContext(ctx, r.log)
+	commandTag, err := r.pool.Exec(ctx, deleteExpiredIdempotencyKeysQuery)
+	if err != nil {
+		log.Error("Failed to delete expired idempotency keys", zap.Error(err))
+		return 0, fmt.Errorf("failed to delete expired idempotency keys: %w", err)
+	}
+	return commandTag.RowsAffected(), nil
+}
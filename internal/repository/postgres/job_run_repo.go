@@ -0,0 +1,68 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"go.uber.org/zap"
+
+	"avito/internal/domain"
+	"avito/pkg/logger"
+)
+
+const (
+	saveJobRunQuery = `INSERT INTO job_runs (id, job_name, started_at, finished_at, status, details, error)
+					   VALUES ($1, $2, $3, $4, $5, $6, NULLIF($7, ''))`
+
+	getJobRunsQuery = `SELECT id, job_name, started_at, finished_at, status, details, COALESCE(error, '')
+					   FROM job_runs
+					   WHERE $1 = '' OR job_name = $1
+					   ORDER BY started_at DESC
+					   LIMIT $2`
+)
+
+// SaveJobRun сохраняет запись о запуске фоновой задачи
+func (r *JobRunRepository) SaveJobRun(ctx context.Context, run domain.JobRun) error {
+	log := logger.FromContext(ctx, r.log).With(zap.String("job", run.JobName), zap.String("run_id", run.ID.String()))
+	log.Debug("Saving job run")
+
+	details := run.Details
+	if details == nil {
+		details = map[string]any{}
+	}
+	_, err := r.pool.Exec(ctx, saveJobRunQuery,
+		run.ID, run.JobName, run.StartedAt, run.FinishedAt, run.Status, details, run.Error)
+	if err != nil {
+		log.Error("Failed to save job run", zap.Error(err))
+		return fmt.Errorf("failed to save job run: %w", err)
+	}
+	return nil
+}
+
+// GetJobRuns возвращает последние запуски задачи. Пустое имя означает все задачи
+func (r *JobRunRepository) GetJobRuns(ctx context.Context, jobName string, limit int) ([]domain.JobRun, error) {
+	log := logger.FromContext(ctx, r.log).With(zap.String("job", jobName), zap.Int("limit", limit))
+	log.Debug("Getting job runs")
+
+	rows, err := r.pool.Query(ctx, getJobRunsQuery, jobName, limit)
+	if err != nil {
+		log.Error("Failed to query job runs", zap.Error(err))
+		return nil, fmt.Errorf("failed to query job runs: %w", err)
+	}
+	defer rows.Close()
+
+	runs := make([]domain.JobRun, 0)
+	for rows.Next() {
+		var run domain.JobRun
+		if err := rows.Scan(&run.ID, &run.JobName, &run.StartedAt, &run.FinishedAt,
+			&run.Status, &run.Details, &run.Error); err != nil {
+			log.Error("Failed to scan job run", zap.Error(err))
+			return nil, fmt.Errorf("failed to scan job run: %w", err)
+		}
+		runs = append(runs, run)
+	}
+	if err := rows.Err(); err != nil {
+		log.Error("Error after iterating over job runs", zap.Error(err))
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+	return runs, nil
+}
@@ -5,20 +5,30 @@ import (
 	"errors"
 	"fmt"
 	"go.uber.org/zap"
+	"net"
+	"net/url"
 	"os"
 	"path/filepath"
-	"time"
+	"strconv"
 
+	"avito/internal/config"
 	"avito/internal/domain"
+	"avito/pkg/logger"
+	"avito/pkg/tracing"
 
 	"github.com/golang-migrate/migrate/v4"
 	_ "github.com/golang-migrate/migrate/v4/database/postgres"
+	"github.com/golang-migrate/migrate/v4/source"
 	_ "github.com/golang-migrate/migrate/v4/source/file"
+	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 	_ "github.com/lib/pq"
 )
 
+// getMigrationVersionQuery читает версию схемы из таблицы golang-migrate
+const getMigrationVersionQuery = `SELECT version, dirty FROM schema_migrations LIMIT 1`
+
 type StatsRepository struct {
 	pool *pgxpool.Pool
 	log  *zap.Logger
@@ -39,35 +49,93 @@ type PullRequestRepository struct {
 	log  *zap.Logger
 }
 
+type TagRepository struct {
+	pool *pgxpool.Pool
+	log  *zap.Logger
+}
+
+type UnavailabilityRepository struct {
+	pool *pgxpool.Pool
+	log  *zap.Logger
+}
+
+type JobRunRepository struct {
+	pool *pgxpool.Pool
+	log  *zap.Logger
+}
+
+type APIKeyRepository struct {
+	pool *pgxpool.Pool
+	log  *zap.Logger
+}
+
+type AuditRepository struct {
+	pool *pgxpool.Pool
+	log  *zap.Logger
+}
+
+type RateLimitRepository struct {
+	pool *pgxpool.Pool
+	log  *zap.Logger
+}
+
+type IdempotencyRepository struct {
+	pool *pgxpool.Pool
+	log  *zap.Logger
+}
+
 type Store struct {
 	pool *pgxpool.Pool
+	// migrationVersion - версия схемы, до которой приложение довело базу при запуске
+	migrationVersion uint
 	UserRepository
 	TeamRepository
 	PullRequestRepository
 	StatsRepository
+	TagRepository
+	UnavailabilityRepository
+	JobRunRepository
+	APIKeyRepository
+	AuditRepository
+	RateLimitRepository
+	IdempotencyRepository
 	log *zap.Logger
 }
 
-func NewStore(ctx context.Context, user string, password string, host string, port string, dbname string, sslmode string, log *zap.Logger) (*Store, error) {
-	connStr := fmt.Sprintf("postgres://%s:%s@%s:%s/%s?sslmode=%s", user, password, host, port, dbname, sslmode)
+// NewStore подключается к PostgreSQL с настройками пула из cfg и, если это разрешено, применяет миграции
+func NewStore(ctx context.Context, cfg config.DatabaseConfig, log *zap.Logger) (*Store, error) {
+	connURL := url.URL{
+		Scheme:   "postgres",
+		User:     url.UserPassword(cfg.User, cfg.Password),
+		Host:     net.JoinHostPort(cfg.Host, strconv.Itoa(cfg.Port)),
+		Path:     cfg.Name,
+		RawQuery: url.Values{"sslmode": {cfg.SSLMode}}.Encode(),
+	}
+	connStr := connURL.String()
 
-	log = log.With(zap.String("dbname", dbname),
-		zap.String("host:port", fmt.Sprintf("%s:%s", host, port)),
-		zap.String("user", user),
+	log = log.With(zap.String("dbname", cfg.Name),
+		zap.String("host:port", connURL.Host),
+		zap.String("user", cfg.User),
 	)
 
 	log.Info("Connecting to PostgreSQL")
 
-	config, err := pgxpool.ParseConfig(connStr)
+	poolConfig, err := pgxpool.ParseConfig(connStr)
 	if err != nil {
 		log.Error("Error parsing connection string", zap.Error(err))
 		return nil, fmt.Errorf("error parsing connection string: %w", err)
 	}
-	config.MaxConns = 50
-	config.HealthCheckPeriod = 30 * time.Second
-	config.MinConns = 2
+	poolConfig.MaxConns = cfg.MaxConns
+	poolConfig.MinConns = cfg.MinConns
+	poolConfig.MaxConnLifetime = cfg.MaxConnLifetime
+	poolConfig.MaxConnIdleTime = cfg.MaxConnIdleTime
+	poolConfig.HealthCheckPeriod = cfg.HealthCheckPeriod
+	poolConfig.ConnConfig.ConnectTimeout = cfg.ConnectTimeout
+	if cfg.QueryTracing {
+		poolConfig.ConnConfig.Tracer = tracing.NewQueryTracer()
+	}
 
-	db, err := pgxpool.NewWithConfig(ctx, config)
+	db, err := pgxpool.NewWithConfig(ctx, poolConfig)
 	if err != nil {
 		log.Error("Failed connecting to PostgreSQL", zap.Error(err))
 		return nil, fmt.Errorf("error connecting to PostgreSQL: %w", err)
@@ -81,28 +149,48 @@ func NewStore(ctx context.Context, user string, password string, host string, po
 
 	log.Info("Successfully connected to PostgreSQL")
 
-	log.Info("Starting database migrations")
+	var migrationVersion uint
+	if cfg.RunMigrations {
+		log.Info("Starting database migrations")
 
-	if err := runMigrations(connStr); err != nil {
-		log.Error("Failed to run migrations", zap.Error(err))
-		return nil, fmt.Errorf("failed to run migration: %w", err)
-	}
+		migrationVersion, err = runMigrations(connStr, cfg.MigrationsPath)
+		if err != nil {
+			log.Error("Failed to run migrations", zap.Error(err))
+			return nil, fmt.Errorf("failed to run migration: %w", err)
+		}
 
-	log.Info("Successfully migrated database")
+		log.Info("Successfully migrated database", zap.Uint("version", migrationVersion))
+	} else {
+		// Миграции применяются снаружи: ожидаемой считаем последнюю версию из каталога миграций
+		migrationVersion, err = latestMigrationVersion(cfg.MigrationsPath)
+		if err != nil {
+			log.Error("Failed to read migrations directory", zap.Error(err))
+			return nil, fmt.Errorf("failed to read migrations: %w", err)
+		}
+		log.Info("Skipping database migrations", zap.Uint("expected_version", migrationVersion))
+	}
 
 	return &Store{
-		pool:                  db,
-		UserRepository:        UserRepository{pool: db, log: log},
-		TeamRepository:        TeamRepository{pool: db, log: log},
-		PullRequestRepository: PullRequestRepository{pool: db, log: log},
-		StatsRepository:       StatsRepository{pool: db, log: log},
-		log:                   log.Named("Repository"),
+		pool:                     db,
+		migrationVersion:         migrationVersion,
+		UserRepository:           UserRepository{pool: db, log: log},
+		TeamRepository:           TeamRepository{pool: db, log: log},
+		PullRequestRepository:    PullRequestRepository{pool: db, log: log},
+		StatsRepository:          StatsRepository{pool: db, log: log},
+		TagRepository:            TagRepository{pool: db, log: log},
+		UnavailabilityRepository: UnavailabilityRepository{pool: db, log: log},
+		JobRunRepository:         JobRunRepository{pool: db, log: log},
+		APIKeyRepository:         APIKeyRepository{pool: db, log: log},
+		AuditRepository:          AuditRepository{pool: db, log: log},
+		RateLimitRepository:      RateLimitRepository{pool: db, log: log},
+		IdempotencyRepository:    IdempotencyRepository{pool: db, log: log},
+		log:                      log.Named("Repository"),
 	}, nil
 }
 
 // CreateTeamWithMembersTx создает команду и ее участников в одной атомарной транзакции
 func (s *Store) CreateTeamWithMembersTx(ctx context.Context, team domain.Team) error {
-	log := s.log.With(zap.String("team_name", team.Name))
+	log := logger.FromContext(ctx, s.log).With(zap.String("team_name", team.Name))
 	log.Debug("Creating team with members in a transaction")
 
 	tx, err := s.pool.BeginTx(ctx, pgx.TxOptions{})
@@ -117,14 +205,23 @@ func (s *Store) CreateTeamWithMembersTx(ctx context.Context, team domain.Team) e
 		}
 	}()
 
-	if _, err := tx.Exec(ctx, saveTeamQuery, team.Name); err != nil {
+	if _, err := tx.Exec(ctx, saveTeamQuery, team.Name, team.DefaultReviewCapacity, team.ReviewSLAMinutes, team.EscalationMinutes); err != nil {
 		log.Error("Failed to save team within transaction", zap.Error(err))
 		return fmt.Errorf("failed to save team: %w", err)
 	}
 
+	memberIDs := make([]uuid.UUID, 0, len(team.Members))
+	for _, member := range team.Members {
+		memberIDs = append(memberIDs, member.ID)
+	}
+	if _, err := tx.Exec(ctx, bumpMovedMembersTeamsQuery, memberIDs, team.Name); err != nil {
+		log.Error("Failed to bump versions of previous member teams", zap.Error(err))
+		return fmt.Errorf("failed to bump team versions: %w", err)
+	}
+
 	for _, member := range team.Members {
 		member.TeamName = team.Name
-		_, err := tx.Exec(ctx, saveUserQuery, member.ID, member.Username, member.IsActive, member.TeamName)
+		_, err := tx.Exec(ctx, saveUserQuery, member.ID, member.Username, member.IsActive, member.TeamName, member.ReviewCapacity, roleOrNil(member.Role))
 		if err != nil {
 			log.Error("Failed to save user within transaction", zap.String("user_id", member.ID.String()), zap.Error(err))
 			return fmt.Errorf("failed to save user: %w", err)
@@ -140,26 +237,84 @@ func (r *Store) Close() {
 	r.pool.Close()
 }
 
-func runMigrations(connStr string) error {
-	migratePath := os.Getenv("MIGRATE_PATH")
-	if migratePath == "" {
-		migratePath = "./migrations"
-	}
-	absPath, err := filepath.Abs(migratePath)
+// runMigrations применяет миграции из migratePath и возвращает версию схемы, до которой они доведены
+func runMigrations(connStr, migratePath string) (uint, error) {
+	migrateUrl, err := migrationsURL(migratePath)
 	if err != nil {
-		return fmt.Errorf("failed to get absolute path: %w", err)
+		return 0, err
 	}
-	absPath = filepath.ToSlash(absPath)
-	migrateUrl := fmt.Sprintf("file://%s", absPath)
 	m, err := migrate.New(migrateUrl, connStr)
 	if err != nil {
-		return fmt.Errorf("start migrations error %v", err)
+		return 0, fmt.Errorf("start migrations error %v", err)
+	}
+	if err := m.Up(); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+		return 0, fmt.Errorf("migration up error: %v", err)
+	}
+	version, _, err := m.Version()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get migration version: %w", err)
+	}
+	return version, nil
+}
+
+// latestMigrationVersion возвращает версию последней миграции в каталоге, не подключаясь к базе
+func latestMigrationVersion(migratePath string) (uint, error) {
+	migrateUrl, err := migrationsURL(migratePath)
+	if err != nil {
+		return 0, err
 	}
-	if err := m.Up(); err != nil {
-		if errors.Is(err, migrate.ErrNoChange) {
-			return nil
+	src, err := source.Open(migrateUrl)
+	if err != nil {
+		return 0, fmt.Errorf("failed to open migrations source: %w", err)
+	}
+	defer src.Close()
+
+	version, err := src.First()
+	if err != nil {
+		return 0, fmt.Errorf("failed to read first migration: %w", err)
+	}
+	for {
+		next, err := src.Next(version)
+		if errors.Is(err, os.ErrNotExist) {
+			return version, nil
+		}
+		if err != nil {
+			return 0, fmt.Errorf("failed to read migrations: %w", err)
+		}
+		version = next
+	}
+}
+
+func migrationsURL(migratePath string) (string, error) {
+	absPath, err := filepath.Abs(migratePath)
+	if err != nil {
+		return "", fmt.Errorf("failed to get absolute path: %w", err)
+	}
+	return fmt.Sprintf("file://%s", filepath.ToSlash(absPath)), nil
+}
+
+// Stat возвращает текущее состояние пула соединений
+func (s *Store) Stat() *pgxpool.Stat {
+	return s.pool.Stat()
+}
+
+// Ping проверяет, что база доступна
+func (s *Store) Ping(ctx context.Context) error {
+	return s.pool.Ping(ctx)
+}
+
+// MigrationState возвращает текущую версию схемы в базе и версию, которую ожидает приложение
+func (s *Store) MigrationState(ctx context.Context) (domain.MigrationState, error) {
+	state := domain.MigrationState{Expected: s.migrationVersion}
+	var version int64
+	err := s.pool.QueryRow(ctx, getMigrationVersionQuery).Scan(&version, &state.Dirty)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return state, nil
 		}
-		return fmt.Errorf("migration up error: %v", err)
+		logger.FromContext(ctx, s.log).Error("Failed to get migration version", zap.Error(err))
+		return state, fmt.Errorf("failed to get migration version: %w", err)
 	}
-	return nil
+	state.Current = uint(version)
+	return state, nil
 }
@@ -5,8 +5,10 @@ import (
 	"errors"
 	"fmt"
 	"go.uber.org/zap"
+	"time"
 
 	"avito/internal/domain"
+	"avito/pkg/logger"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
@@ -16,28 +18,90 @@ const (
 	createPullRequestQuery = `INSERT INTO pull_requests (id, name, status, author_id, created_at) 
 							  VALUES ($1, $2, $3, $4, $5)`
 
-	getPullRequestByIDQuery = `SELECT id, name, status, author_id, created_at, merged_at 
+	getPullRequestByIDQuery = `SELECT id, name, status, author_id, created_at, merged_at, version 
 							   FROM pull_requests WHERE id = $1`
 
 	getReviewersForPRQuery = `SELECT reviewer_id FROM pull_request_reviewers WHERE pull_request_id = $1`
 
+	getLabelsForPRQuery = `SELECT label FROM pull_request_labels WHERE pull_request_id = $1 ORDER BY label`
+
+	getReviewLoadsQuery = `SELECT u.id, COALESCE(u.review_capacity, t.default_review_capacity), COUNT(p.id)
+						   FROM users u
+						   JOIN teams t ON t.name = u.team_name
+						   LEFT JOIN pull_request_reviewers prr ON prr.reviewer_id = u.id
+						   LEFT JOIN pull_requests p ON p.id = prr.pull_request_id AND p.status = 'OPEN'
+						   WHERE u.id = ANY($1::uuid[])
+						   GROUP BY u.id, u.review_capacity, t.default_review_capacity`
+
+	// Ревьюер, уже вынесший вердикт, ревью провел: ему не напоминают и его не переназначают
+	getStaleReviewsQuery = `SELECT prr.pull_request_id, prr.reviewer_id, t.name, prr.assigned_at, prr.reminded_at,
+							prr.assigned_at + COALESCE(t.escalation_minutes * INTERVAL '1 minute', $2 * INTERVAL '1 second') <= NOW()
+							FROM pull_request_reviewers prr
+							JOIN pull_requests p ON p.id = prr.pull_request_id
+							JOIN users a ON a.id = p.author_id
+							JOIN teams t ON t.name = a.team_name
+							WHERE p.status = 'OPEN' AND prr.verdict IS NULL
+							  AND prr.assigned_at + COALESCE(t.review_sla_minutes * INTERVAL '1 minute', $1 * INTERVAL '1 second') <= NOW()
+							ORDER BY prr.assigned_at`
+
+	markReviewRemindedQuery = `UPDATE pull_request_reviewers SET reminded_at = NOW() WHERE pull_request_id = $1 AND reviewer_id = $2`
+
+	setVerdictQuery = `UPDATE pull_request_reviewers SET verdict = $1, verdict_at = $2
+					   WHERE pull_request_id = $3 AND reviewer_id = $4`
+
 	deleteSpecificReviewerQuery = `DELETE FROM pull_request_reviewers WHERE pull_request_id = $1 AND reviewer_id = $2`
 
 	insertSpecificReviewerQuery = `INSERT INTO pull_request_reviewers (pull_request_id, reviewer_id) VALUES ($1, $2)`
 
-	getPRsByReviewerIDQuery = `SELECT p.id, p.name, p.status, p.author_id, p.created_at, p.merged_at
+	getPRsByReviewerIDQuery = `SELECT p.id, p.name, p.status, p.author_id, p.created_at, p.merged_at, p.version
 							   FROM pull_requests p
 							   JOIN pull_request_reviewers prr ON p.id = prr.pull_request_id
 							   WHERE prr.reviewer_id = $1`
 
+	// listPullRequestsQuery выбирает PR вместе с ревьюерами и метками одной строкой на PR
+	listPullRequestsQuery = `SELECT p.id, p.name, p.status, p.author_id, p.created_at, p.merged_at, p.version,
+								ARRAY(SELECT prr.reviewer_id FROM pull_request_reviewers prr
+									  WHERE prr.pull_request_id = p.id ORDER BY prr.assigned_at, prr.reviewer_id),
+								ARRAY(SELECT l.label FROM pull_request_labels l
+									  WHERE l.pull_request_id = p.id ORDER BY l.label)
+							 FROM pull_requests p
+							 JOIN users a ON a.id = p.author_id
+							 WHERE ($1::timestamptz IS NULL OR p.created_at >= $1)
+							   AND ($2::timestamptz IS NULL OR p.created_at < $2)
+							   AND ($3::text IS NULL OR a.team_name = $3)
+							   AND ($4::uuid IS NULL OR p.author_id = $4)
+							   AND ($5::text IS NULL OR p.status = $5)
+							 ORDER BY p.created_at, p.id`
+
+	getPRsByIDsQuery = `SELECT p.id, p.name, p.status, p.author_id, p.created_at, p.merged_at, p.version,
+							ARRAY(SELECT prr.reviewer_id FROM pull_request_reviewers prr
+								  WHERE prr.pull_request_id = p.id ORDER BY prr.assigned_at, prr.reviewer_id),
+							ARRAY(SELECT l.label FROM pull_request_labels l
+								  WHERE l.pull_request_id = p.id ORDER BY l.label)
+						FROM pull_requests p
+						WHERE p.id = ANY($1::text[])`
+
+	getReviewsByReviewerIDsQuery = `SELECT pull_request_id, reviewer_id, assigned_at, verdict, verdict_at
+									FROM pull_request_reviewers
+									WHERE reviewer_id = ANY($1::uuid[])
+									ORDER BY assigned_at, pull_request_id`
+
 	existsPullRequestQuery = `SELECT EXISTS(SELECT 1 FROM pull_requests WHERE id = $1)`
 
-	updatePullRequestStatusQuery = `UPDATE pull_requests SET status = $1, merged_at = NOW() WHERE id = $2`
+	getPullRequestStatusQuery = `SELECT status FROM pull_requests WHERE id = $1`
+
+	// Меняется только открытый PR: у смерженного не сдвигаются merged_at и версия.
+	// Пустая ожидаемая версия ($3) отключает проверку
+	updatePullRequestStatusQuery = `UPDATE pull_requests SET status = $1, merged_at = NOW(), version = version + 1
+									WHERE id = $2 AND status = 'OPEN' AND ($3::bigint IS NULL OR version = $3)`
+
+	bumpPullRequestVersionQuery = `UPDATE pull_requests SET version = version + 1
+								   WHERE id = $1 AND ($2::bigint IS NULL OR version = $2)`
 )
 
 // Create создает новый PR и его ревьюеров в одной транзакции
 func (r *PullRequestRepository) Create(ctx context.Context, pr *domain.PullRequest) error {
-	log := r.log.With(zap.String("pr_id", pr.ID))
+	log := logger.FromContext(ctx, r.log).With(zap.String("pr_id", pr.ID))
 	log.Debug("Creating pull request in a transaction")
 
 	tx, err := r.pool.BeginTx(ctx, pgx.TxOptions{})
@@ -77,19 +141,39 @@ func (r *PullRequestRepository) Create(ctx context.Context, pr *domain.PullReque
 		}
 	}
 
+	if len(pr.Labels) > 0 {
+		log.Debug("Bulk inserting labels", zap.Int("count", len(pr.Labels)))
+
+		rows := make([][]interface{}, len(pr.Labels))
+		for i, label := range pr.Labels {
+			rows[i] = []interface{}{pr.ID, label}
+		}
+
+		_, err = tx.CopyFrom(
+			ctx,
+			pgx.Identifier{"pull_request_labels"},
+			[]string{"pull_request_id", "label"},
+			pgx.CopyFromRows(rows),
+		)
+		if err != nil {
+			log.Error("Failed to bulk insert labels", zap.Error(err))
+			return fmt.Errorf("failed to copy labels: %w", err)
+		}
+	}
+
 	log.Debug("Committing transaction")
 	return tx.Commit(ctx)
 }
 
 // GetPRByID находит PR по ID и загружает его ревьюеров
 func (r *PullRequestRepository) GetPRByID(ctx context.Context, id string) (*domain.PullRequest, error) {
-	log := r.log.With(zap.String("pr_id", id))
+	log := logger.FromContext(ctx, r.log).With(zap.String("pr_id", id))
 	log.Debug("Getting pull request by ID")
 
 	pr := &domain.PullRequest{}
 
 	err := r.pool.QueryRow(ctx, getPullRequestByIDQuery, id).Scan(
-		&pr.ID, &pr.Name, &pr.Status, &pr.AuthorID, &pr.CreatedAt, &pr.MergedAt,
+		&pr.ID, &pr.Name, &pr.Status, &pr.AuthorID, &pr.CreatedAt, &pr.MergedAt, &pr.Version,
 	)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -118,13 +202,129 @@ func (r *PullRequestRepository) GetPRByID(ctx context.Context, id string) (*doma
 	}
 
 	pr.AssignedReviewers = reviewers
+
+	labels, err := r.getLabels(ctx, id)
+	if err != nil {
+		log.Error("Failed to get labels for PR", zap.Error(err))
+		return nil, fmt.Errorf("failed to get labels for PR: %w", err)
+	}
+	pr.Labels = labels
+
 	log.Debug("Pull request retrieved successfully", zap.Int("reviewers_count", len(reviewers)))
 	return pr, nil
 }
 
+// getLabels загружает метки PR
+func (r *PullRequestRepository) getLabels(ctx context.Context, id string) ([]string, error) {
+	rows, err := r.pool.Query(ctx, getLabelsForPRQuery, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query labels: %w", err)
+	}
+	defer rows.Close()
+
+	var labels []string
+	for rows.Next() {
+		var label string
+		if err := rows.Scan(&label); err != nil {
+			return nil, fmt.Errorf("failed to scan label: %w", err)
+		}
+		labels = append(labels, label)
+	}
+	return labels, rows.Err()
+}
+
+// GetReviewLoads возвращает для каждого пользователя количество открытых ревью и действующий лимит:
+// личный, если задан, иначе лимит команды по умолчанию.
+func (r *PullRequestRepository) GetReviewLoads(ctx context.Context, userIDs []uuid.UUID) (map[uuid.UUID]domain.ReviewLoad, error) {
+	log := logger.FromContext(ctx, r.log).With(zap.Int("users_count", len(userIDs)))
+	log.Debug("Getting review loads for users")
+
+	rows, err := r.pool.Query(ctx, getReviewLoadsQuery, userIDs)
+	if err != nil {
+		log.Error("Failed to query review loads", zap.Error(err))
+		return nil, fmt.Errorf("failed to query review loads: %w", err)
+	}
+	defer rows.Close()
+
+	loads := make(map[uuid.UUID]domain.ReviewLoad, len(userIDs))
+	for rows.Next() {
+		var userID uuid.UUID
+		var load domain.ReviewLoad
+		if err := rows.Scan(&userID, &load.Capacity, &load.OpenReviews); err != nil {
+			log.Error("Failed to scan review load", zap.Error(err))
+			return nil, fmt.Errorf("failed to scan review load: %w", err)
+		}
+		loads[userID] = load
+	}
+	if err := rows.Err(); err != nil {
+		log.Error("Error after iterating over review loads", zap.Error(err))
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+	return loads, nil
+}
+
+// GetStaleReviews возвращает назначения на открытые PR, по которым истек SLA команды автора.
+// defaultSLA и defaultEscalation используются для команд, у которых пороги не заданы.
+func (r *PullRequestRepository) GetStaleReviews(ctx context.Context, defaultSLA, defaultEscalation time.Duration) ([]domain.StaleReview, error) {
+	log := logger.FromContext(ctx, r.log).With(zap.Duration("default_sla", defaultSLA), zap.Duration("default_escalation", defaultEscalation))
+	log.Debug("Getting stale reviews")
+
+	rows, err := r.pool.Query(ctx, getStaleReviewsQuery, int64(defaultSLA.Seconds()), int64(defaultEscalation.Seconds()))
+	if err != nil {
+		log.Error("Failed to query stale reviews", zap.Error(err))
+		return nil, fmt.Errorf("failed to query stale reviews: %w", err)
+	}
+	defer rows.Close()
+
+	var reviews []domain.StaleReview
+	for rows.Next() {
+		var review domain.StaleReview
+		if err := rows.Scan(&review.PullRequestID, &review.ReviewerID, &review.TeamName,
+			&review.AssignedAt, &review.RemindedAt, &review.Escalate); err != nil {
+			log.Error("Failed to scan stale review", zap.Error(err))
+			return nil, fmt.Errorf("failed to scan stale review: %w", err)
+		}
+		reviews = append(reviews, review)
+	}
+	if err := rows.Err(); err != nil {
+		log.Error("Error after iterating over stale reviews", zap.Error(err))
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+	log.Debug("Stale reviews retrieved", zap.Int("count", len(reviews)))
+	return reviews, nil
+}
+
+// MarkReviewReminded запоминает, что ревьюеру отправлено напоминание по PR
+func (r *PullRequestRepository) MarkReviewReminded(ctx context.Context, prID string, reviewerID uuid.UUID) error {
+	log := logger.FromContext(ctx, r.log)
+	commandTag, err := r.pool.Exec(ctx, markReviewRemindedQuery, prID, reviewerID)
+	if err != nil {
+		log.Error("Failed to mark review reminded", zap.String("pr_id", prID), zap.Error(err))
+		return fmt.Errorf("failed to mark review reminded: %w", err)
+	}
+	if commandTag.RowsAffected() == 0 {
+		return domain.ErrUserNotAssigned
+	}
+	return nil
+}
+
+// SetVerdict сохраняет вердикт назначенного ревьюера, заменяя предыдущий
+func (r *PullRequestRepository) SetVerdict(ctx context.Context, verdict domain.ReviewVerdict) error {
+	log := logger.FromContext(ctx, r.log).With(zap.String("pr_id", verdict.PullRequestID))
+	commandTag, err := r.pool.Exec(ctx, setVerdictQuery, verdict.Verdict, verdict.SubmittedAt, verdict.PullRequestID, verdict.ReviewerID)
+	if err != nil {
+		log.Error("Failed to set review verdict", zap.Error(err))
+		return fmt.Errorf("failed to set review verdict: %w", err)
+	}
+	if commandTag.RowsAffected() == 0 {
+		return domain.ErrUserNotAssigned
+	}
+	return nil
+}
+
 // ReassignReviewer атомарно заменяет одного ревьюера на другого в рамках одной транзакции.
 func (r *PullRequestRepository) ReassignReviewer(ctx context.Context, reasReviewer domain.Reassignment) error {
-	log := r.log.With(zap.String("pr_id", reasReviewer.PullRequestID))
+	log := logger.FromContext(ctx, r.log).With(zap.String("pr_id", reasReviewer.PullRequestID))
 	log.Debug("Reassigning reviewer in a manual transaction")
 
 	tx, err := r.pool.BeginTx(ctx, pgx.TxOptions{})
@@ -139,7 +339,19 @@ func (r *PullRequestRepository) ReassignReviewer(ctx context.Context, reasReview
 		}
 	}()
 
-	cmdTag, err := tx.Exec(ctx, deleteSpecificReviewerQuery, reasReviewer.PullRequestID, reasReviewer.OldUserID)
+	// Версия увеличивается первой: строка PR блокируется до конца транзакции, и параллельное изменение
+	// по той же версии получит конфликт
+	cmdTag, err := tx.Exec(ctx, bumpPullRequestVersionQuery, reasReviewer.PullRequestID, reasReviewer.ExpectedVersion)
+	if err != nil {
+		log.Error("Failed to bump pull request version", zap.Error(err))
+		return fmt.Errorf("failed to bump pull request version: %w", err)
+	}
+	if cmdTag.RowsAffected() == 0 {
+		log.Warn("Pull request version does not match", zap.Int64p("expected_version", reasReviewer.ExpectedVersion))
+		return domain.ErrVersionConflict
+	}
+
+	cmdTag, err = tx.Exec(ctx, deleteSpecificReviewerQuery, reasReviewer.PullRequestID, reasReviewer.OldUserID)
 	if err != nil {
 		log.Error("Failed to delete old reviewer", zap.Stringer("old_reviewer", reasReviewer.OldUserID), zap.Error(err))
 		return fmt.Errorf("failed to delete old reviewer: %w", err)
@@ -162,7 +374,7 @@ func (r *PullRequestRepository) ReassignReviewer(ctx context.Context, reasReview
 
 // GetByReviewerID находит все PR, где пользователь является ревьюером.
 func (r *PullRequestRepository) GetByReviewerID(ctx context.Context, reviewerID uuid.UUID) ([]*domain.PullRequest, error) {
-	log := r.log.With(zap.String("reviewer_id", reviewerID.String()))
+	log := logger.FromContext(ctx, r.log).With(zap.String("reviewer_id", reviewerID.String()))
 	log.Debug("Getting PRs by reviewer ID")
 
 	rows, err := r.pool.Query(ctx, getPRsByReviewerIDQuery, reviewerID)
@@ -175,7 +387,7 @@ func (r *PullRequestRepository) GetByReviewerID(ctx context.Context, reviewerID
 	var prs []*domain.PullRequest
 	for rows.Next() {
 		var pr domain.PullRequest
-		if err := rows.Scan(&pr.ID, &pr.Name, &pr.Status, &pr.AuthorID, &pr.CreatedAt, &pr.MergedAt); err != nil {
+		if err := rows.Scan(&pr.ID, &pr.Name, &pr.Status, &pr.AuthorID, &pr.CreatedAt, &pr.MergedAt, &pr.Version); err != nil {
 			log.Error("Failed to scan PR row", zap.Error(err))
 			return nil, fmt.Errorf("failed to scan PR: %w", err)
 		}
@@ -185,32 +397,146 @@ func (r *PullRequestRepository) GetByReviewerID(ctx context.Context, reviewerID
 	return prs, nil
 }
 
+// GetPRsByIDs находит PR из набора ID вместе с ревьюерами и метками одним запросом.
+// Отсутствующие PR в результат не попадают
+func (r *PullRequestRepository) GetPRsByIDs(ctx context.Context, ids []string) ([]*domain.PullRequest, error) {
+	log := logger.FromContext(ctx, r.log).With(zap.Int("prs_count", len(ids)))
+	log.Debug("Getting PRs by IDs")
+
+	rows, err := r.pool.Query(ctx, getPRsByIDsQuery, ids)
+	if err != nil {
+		log.Error("Failed to query PRs by IDs", zap.Error(err))
+		return nil, fmt.Errorf("failed to query PRs by IDs: %w", err)
+	}
+	defer rows.Close()
+
+	prs := make([]*domain.PullRequest, 0, len(ids))
+	for rows.Next() {
+		var pr domain.PullRequest
+		if err := rows.Scan(&pr.ID, &pr.Name, &pr.Status, &pr.AuthorID, &pr.CreatedAt, &pr.MergedAt, &pr.Version, &pr.AssignedReviewers, &pr.Labels); err != nil {
+			log.Error("Failed to scan PR row", zap.Error(err))
+			return nil, fmt.Errorf("failed to scan PR: %w", err)
+		}
+		prs = append(prs, &pr)
+	}
+	if err := rows.Err(); err != nil {
+		log.Error("Error after iterating over PR rows", zap.Error(err))
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+	log.Debug("PRs retrieved successfully", zap.Int("found", len(prs)))
+	return prs, nil
+}
+
+// GetReviewsByReviewerIDs находит назначения сразу для набора ревьюеров, от старых к новым
+func (r *PullRequestRepository) GetReviewsByReviewerIDs(ctx context.Context, reviewerIDs []uuid.UUID) ([]domain.Review, error) {
+	log := logger.FromContext(ctx, r.log).With(zap.Int("reviewers_count", len(reviewerIDs)))
+	log.Debug("Getting reviews by reviewer IDs")
+
+	rows, err := r.pool.Query(ctx, getReviewsByReviewerIDsQuery, reviewerIDs)
+	if err != nil {
+		log.Error("Failed to query reviews by reviewer IDs", zap.Error(err))
+		return nil, fmt.Errorf("failed to query reviews by reviewer IDs: %w", err)
+	}
+	defer rows.Close()
+
+	var reviews []domain.Review
+	for rows.Next() {
+		var review domain.Review
+		if err := rows.Scan(&review.PullRequestID, &review.ReviewerID, &review.AssignedAt, &review.Verdict, &review.VerdictAt); err != nil {
+			log.Error("Failed to scan review row", zap.Error(err))
+			return nil, fmt.Errorf("failed to scan review: %w", err)
+		}
+		reviews = append(reviews, review)
+	}
+	if err := rows.Err(); err != nil {
+		log.Error("Error after iterating over review rows", zap.Error(err))
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+	log.Debug("Reviews retrieved successfully", zap.Int("count", len(reviews)))
+	return reviews, nil
+}
+
+// StreamPullRequests построчно читает PR, подходящие под фильтр, и передает каждый в fn.
+// Результат не накапливается в памяти; ошибка fn прерывает чтение и возвращается как есть.
+func (r *PullRequestRepository) StreamPullRequests(ctx context.Context, filter domain.PullRequestFilter, fn func(*domain.PullRequest) error) error {
+	log := logger.FromContext(ctx, r.log).With(zap.String("repo_method", "StreamPullRequests"))
+	log.Debug("Streaming pull requests")
+
+	var status *string
+	if filter.Status != nil {
+		s := string(*filter.Status)
+		status = &s
+	}
+	rows, err := r.pool.Query(ctx, listPullRequestsQuery, filter.From, filter.To, optionalText(filter.TeamName), filter.AuthorID, status)
+	if err != nil {
+		log.Error("Failed to query pull requests", zap.Error(err))
+		return fmt.Errorf("failed to query pull requests: %w", err)
+	}
+	defer rows.Close()
+
+	count := 0
+	for rows.Next() {
+		var pr domain.PullRequest
+		if err := rows.Scan(&pr.ID, &pr.Name, &pr.Status, &pr.AuthorID, &pr.CreatedAt, &pr.MergedAt, &pr.Version, &pr.AssignedReviewers, &pr.Labels); err != nil {
+			log.Error("Failed to scan PR row", zap.Error(err))
+			return fmt.Errorf("failed to scan PR: %w", err)
+		}
+		if err := fn(&pr); err != nil {
+			return err
+		}
+		count++
+	}
+	if err := rows.Err(); err != nil {
+		log.Error("Error after iterating over pull request rows", zap.Error(err))
+		return fmt.Errorf("rows iteration error: %w", err)
+	}
+	log.Debug("Pull requests streamed", zap.Int("count", count))
+	return nil
+}
+
 // Exists проверяет существование PR.
 func (r *PullRequestRepository) Exists(ctx context.Context, id string) (bool, error) {
+	log := logger.FromContext(ctx, r.log)
 	var exists bool
 	err := r.pool.QueryRow(ctx, existsPullRequestQuery, id).Scan(&exists)
 	if err != nil {
-		r.log.Error("Failed to check if PR exists", zap.String("id", id), zap.Error(err))
+		log.Error("Failed to check if PR exists", zap.String("id", id), zap.Error(err))
 		return false, fmt.Errorf("failed to check existence: %w", err)
 	}
 	return exists, nil
 }
 
-func (r *PullRequestRepository) SetMerge(ctx context.Context, id string) error {
-	log := r.log.With(zap.String("pr_id", id))
+// SetMerge переводит открытый PR в статус MERGED. expectedVersion nil отключает проверку версии.
+// Возвращает true, если PR был смержен этим вызовом. Уже смерженный PR остается без изменений, и возвращается false
+func (r *PullRequestRepository) SetMerge(ctx context.Context, id string, expectedVersion *int64) (bool, error) {
+	log := logger.FromContext(ctx, r.log).With(zap.String("pr_id", id))
 	log.Debug("Setting pull request status to MERGED")
 
-	commandTag, err := r.pool.Exec(ctx, updatePullRequestStatusQuery, domain.StatusMerged, id)
+	commandTag, err := r.pool.Exec(ctx, updatePullRequestStatusQuery, domain.StatusMerged, id, expectedVersion)
 	if err != nil {
 		log.Error("Failed to execute update status query", zap.Error(err))
-		return fmt.Errorf("failed to set merge status for PR %s: %w", id, err)
+		return false, fmt.Errorf("failed to set merge status for PR %s: %w", id, err)
 	}
 
 	if commandTag.RowsAffected() == 0 {
-		log.Warn("Pull request not found for setting merge status")
-		return domain.ErrNotFound
+		var status domain.StatusPR
+		err := r.pool.QueryRow(ctx, getPullRequestStatusQuery, id).Scan(&status)
+		if errors.Is(err, pgx.ErrNoRows) {
+			log.Warn("Pull request not found for setting merge status")
+			return false, domain.ErrNotFound
+		}
+		if err != nil {
+			log.Error("Failed to get pull request status", zap.Error(err))
+			return false, fmt.Errorf("failed to get status of PR %s: %w", id, err)
+		}
+		if status == domain.StatusMerged {
+			log.Info("Pull request is already merged")
+			return false, nil
+		}
+		log.Warn("Pull request version does not match", zap.Int64p("expected_version", expectedVersion))
+		return false, domain.ErrVersionConflict
 	}
 
 	log.Info("Successfully set pull request status to MERGED")
-	return nil
+	return true, nil
 }
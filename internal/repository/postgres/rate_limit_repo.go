@@ -0,0 +1,71 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go.uber.org/zap"
+	"time"
+
+	"avito/internal/domain"
+	"avito/pkg/logger"
+
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	// takeRateLimitTokenQuery пополняет корзину за прошедшее время и списывает токен одним запросом,
+	// поэтому экземпляры сервиса не обгоняют друг друга. Если токена нет, строка не меняется и запрос ничего не возвращает.
+	// $2 - токенов в секунду, $3 - емкость корзины. Время берется из базы, чтобы не зависеть от часов экземпляров
+	takeRateLimitTokenQuery = `INSERT INTO rate_limit_buckets AS b (key, tokens, updated_at, full_at)
+							   VALUES ($1, $3::float8 - 1, NOW(), NOW() + make_interval(secs => 1 / $2::float8))
+							   ON CONFLICT (key) DO UPDATE
+							   SET tokens = LEAST($3::float8, b.tokens + GREATEST(EXTRACT(EPOCH FROM NOW() - b.updated_at)::float8, 0) * $2::float8) - 1,
+							       updated_at = NOW(),
+							       full_at = NOW() + make_interval(secs => ($3::float8 + 1
+							           - LEAST($3::float8, b.tokens + GREATEST(EXTRACT(EPOCH FROM NOW() - b.updated_at)::float8, 0) * $2::float8)) / $2::float8)
+							   WHERE LEAST($3::float8, b.tokens + GREATEST(EXTRACT(EPOCH FROM NOW() - b.updated_at)::float8, 0) * $2::float8) >= 1
+							   RETURNING tokens`
+
+	getRateLimitTokensQuery = `SELECT LEAST($3::float8, tokens + GREATEST(EXTRACT(EPOCH FROM NOW() - updated_at)::float8, 0) * $2::float8)
+							   FROM rate_limit_buckets WHERE key = $1`
+
+	deleteFullRateLimitBucketsQuery = `DELETE FROM rate_limit_buckets WHERE full_at <= NOW()`
+)
+
+// TakeRateLimitToken списывает токен из общей для всех экземпляров корзины key
+func (r *RateLimitRepository) TakeRateLimitToken(ctx context.Context, key string, limit domain.RateLimit) (domain.RateLimitDecision, error) {
+	log := logger.FromContext(ctx, r.log).With(zap.String("bucket", key))
+
+	var tokens float64
+	err := r.pool.QueryRow(ctx, takeRateLimitTokenQuery, key, limit.Rate, limit.Burst).Scan(&tokens)
+	if err == nil {
+		return domain.RateLimitDecision{Allowed: true, Remaining: int(tokens)}, nil
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		log.Error("Failed to take rate limit token", zap.Error(err))
+		return domain.RateLimitDecision{}, fmt.Errorf("failed to take rate limit token: %w", err)
+	}
+
+	// Токена нет: остаток нужен только для Retry-After, поэтому читается отдельным запросом
+	err = r.pool.QueryRow(ctx, getRateLimitTokensQuery, key, limit.Rate, limit.Burst).Scan(&tokens)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		log.Error("Failed to get rate limit tokens", zap.Error(err))
+		return domain.RateLimitDecision{}, fmt.Errorf("failed to get rate limit tokens: %w", err)
+	}
+	return domain.RateLimitDecision{
+		Allowed:    false,
+		RetryAfter: time.Duration((1 - tokens) / limit.Rate * float64(time.Second)),
+	}, nil
+}
+
+// DeleteFullRateLimitBuckets удаляет наполнившиеся корзины и возвращает их количество
+func (r *RateLimitRepository) DeleteFullRateLimitBuckets(ctx context.Context) (int64, error) {
+	log := logger.FromContext(ctx, r.log)
+	commandTag, err := r.pool.Exec(ctx, deleteFullRateLimitBucketsQuery)
+	if err != nil {
+		log.Error("Failed to delete full rate limit buckets", zap.Error(err))
+		return 0, fmt.Errorf("failed to delete full rate limit buckets: %w", err)
+	}
+	return commandTag.RowsAffected(), nil
+}
@@ -4,59 +4,438 @@ import (
 	"context"
 	"fmt"
 	"go.uber.org/zap"
+	"time"
 
 	"avito/internal/domain"
+	"avito/pkg/logger"
 )
 
 // GetUserReviewStatsQuery - SQL-запрос для сбора статистики.
+// $1, $2 - границы периода по времени назначения ревьюера, $3 - команда, $4 - статус PR.
+// NULL в любом из параметров отключает соответствующий фильтр.
 const GetUserReviewStatsQuery = `
 	SELECT
 		u.id,
 		u.username,
 		u.is_active,
-		COALESCE(pr_counts.review_count, 0) as review_count
+		COALESCE(pr_counts.review_count, 0) as review_count,
+		COALESCE(pr_counts.open_review_count, 0) as open_review_count,
+		COALESCE(u.review_capacity, t.default_review_capacity) as review_capacity
 	FROM
 		users u
+	JOIN teams t ON t.name = u.team_name
 	LEFT JOIN (
 		SELECT
-			reviewer_id,
-			COUNT(*) as review_count
+			prr.reviewer_id,
+			COUNT(*) FILTER (
+				WHERE ($1::timestamptz IS NULL OR prr.assigned_at >= $1)
+				  AND ($2::timestamptz IS NULL OR prr.assigned_at < $2)
+				  AND ($4::text IS NULL OR p.status = $4)
+			) as review_count,
+			COUNT(*) FILTER (WHERE p.status = 'OPEN') as open_review_count
 		FROM
-			pull_request_reviewers
+			pull_request_reviewers prr
+		JOIN pull_requests p ON p.id = prr.pull_request_id
 		GROUP BY
-			reviewer_id
+			prr.reviewer_id
 	) as pr_counts ON u.id = pr_counts.reviewer_id
+	WHERE
+		($3::text IS NULL OR u.team_name = $3)
 	ORDER BY
 		review_count DESC;
 `
 
+// GetTeamReviewStatsQuery - SQL-запрос для агрегатов по командам.
+// PR относится к команде своего автора. Открытые PR считаются по created_at, смерженные - по merged_at.
+const GetTeamReviewStatsQuery = `
+	SELECT
+		t.name,
+		COUNT(p.id) FILTER (WHERE p.opened_in_window) as prs_opened,
+		COUNT(p.id) FILTER (
+			WHERE p.merged_at IS NOT NULL
+			  AND ($1::timestamptz IS NULL OR p.merged_at >= $1)
+			  AND ($2::timestamptz IS NULL OR p.merged_at < $2)
+		) as prs_merged,
+		COALESCE(AVG(p.reviewers_count) FILTER (WHERE p.opened_in_window), 0)::float8 as avg_reviewers,
+		COUNT(p.id) FILTER (WHERE p.opened_in_window AND p.reviewers_count = 0) as prs_without_reviewers
+	FROM
+		teams t
+	LEFT JOIN (
+		SELECT
+			pr.id,
+			a.team_name,
+			pr.merged_at,
+			($1::timestamptz IS NULL OR pr.created_at >= $1)
+				AND ($2::timestamptz IS NULL OR pr.created_at < $2) as opened_in_window,
+			(SELECT COUNT(*) FROM pull_request_reviewers prr WHERE prr.pull_request_id = pr.id) as reviewers_count
+		FROM
+			pull_requests pr
+		JOIN users a ON a.id = pr.author_id
+		WHERE
+			($4::text IS NULL OR pr.status = $4)
+	) as p ON p.team_name = t.name
+	WHERE
+		($3::text IS NULL OR t.name = $3)
+	GROUP BY
+		t.name
+	ORDER BY
+		t.name;
+`
+
+// timeToMergeQueryTemplate - SQL-запрос перцентилей времени от создания до мержа PR.
+// Первый %s - выражения ключа и имени группы, второй - дополнительная колонка интервала.
+// $1, $2 - границы периода по merged_at, $3 - команда автора, $4 - размер интервала для date_trunc (только при разбивке).
+const timeToMergeQueryTemplate = `
+	SELECT
+		%s,
+		%s
+		COUNT(*) as merged_count,
+		percentile_cont(0.5) WITHIN GROUP (ORDER BY EXTRACT(EPOCH FROM pr.merged_at - pr.created_at)) as p50,
+		percentile_cont(0.9) WITHIN GROUP (ORDER BY EXTRACT(EPOCH FROM pr.merged_at - pr.created_at)) as p90,
+		percentile_cont(0.99) WITHIN GROUP (ORDER BY EXTRACT(EPOCH FROM pr.merged_at - pr.created_at)) as p99
+	FROM
+		pull_requests pr
+	JOIN users a ON a.id = pr.author_id
+	WHERE
+		pr.merged_at IS NOT NULL
+		AND ($1::timestamptz IS NULL OR pr.merged_at >= $1)
+		AND ($2::timestamptz IS NULL OR pr.merged_at < $2)
+		AND ($3::text IS NULL OR a.team_name = $3)
+	GROUP BY
+		group_key, group_name%s
+	ORDER BY
+		group_key%s;
+`
+
+// timeToReviewQueryTemplate - SQL-запрос перцентилей времени от назначения ревьюера до его вердикта.
+// Плейсхолдеры те же, что у timeToMergeQueryTemplate. $1, $2 - границы периода по verdict_at, $3 - команда ревьюера.
+const timeToReviewQueryTemplate = `
+	SELECT
+		%s,
+		%s
+		COUNT(*) as review_count,
+		percentile_cont(0.5) WITHIN GROUP (ORDER BY EXTRACT(EPOCH FROM prr.verdict_at - prr.assigned_at)) as p50,
+		percentile_cont(0.9) WITHIN GROUP (ORDER BY EXTRACT(EPOCH FROM prr.verdict_at - prr.assigned_at)) as p90,
+		percentile_cont(0.99) WITHIN GROUP (ORDER BY EXTRACT(EPOCH FROM prr.verdict_at - prr.assigned_at)) as p99
+	FROM
+		pull_request_reviewers prr
+	JOIN users rv ON rv.id = prr.reviewer_id
+	WHERE
+		prr.verdict_at IS NOT NULL
+		AND ($1::timestamptz IS NULL OR prr.verdict_at >= $1)
+		AND ($2::timestamptz IS NULL OR prr.verdict_at < $2)
+		AND ($3::text IS NULL OR rv.team_name = $3)
+	GROUP BY
+		group_key, group_name%s
+	ORDER BY
+		group_key%s;
+`
+
+// latencyQuery описывает запрос задержек для одного способа группировки:
+// шаблон, выражения ключа и имени группы и колонку начала интервала
+type latencyQuery struct {
+	template     string
+	groupColumns string
+	bucketColumn string
+}
+
+// mergeBucketColumn и verdictBucketColumn приводят время мержа или вердикта к началу интервала в UTC
+const (
+	mergeBucketColumn   = "(date_trunc($4::text, pr.merged_at AT TIME ZONE 'UTC') AT TIME ZONE 'UTC') as bucket_start,"
+	verdictBucketColumn = "(date_trunc($4::text, prr.verdict_at AT TIME ZONE 'UTC') AT TIME ZONE 'UTC') as bucket_start,"
+)
+
+var latencyQueries = map[domain.LatencyGroupBy]latencyQuery{
+	domain.LatencyByTeam: {
+		template:     timeToMergeQueryTemplate,
+		groupColumns: "a.team_name as group_key, a.team_name as group_name",
+		bucketColumn: mergeBucketColumn,
+	},
+	domain.LatencyByAuthor: {
+		template:     timeToMergeQueryTemplate,
+		groupColumns: "a.id::text as group_key, a.username as group_name",
+		bucketColumn: mergeBucketColumn,
+	},
+	domain.LatencyByReviewer: {
+		template:     timeToReviewQueryTemplate,
+		groupColumns: "rv.id::text as group_key, rv.username as group_name",
+		bucketColumn: verdictBucketColumn,
+	},
+}
+
+// GetLatency возвращает перцентили задержек по группам, а при заданном Bucket - еще и по интервалам.
+// Для команд и авторов это время до мержа, для ревьюеров - время от назначения до вердикта.
+func (r *StatsRepository) GetLatency(ctx context.Context, filter domain.LatencyFilter) ([]*domain.LatencySeries, error) {
+	log := logger.FromContext(ctx, r.log).With(zap.String("repo_method", "GetLatency"), zap.String("group_by", string(filter.GroupBy)))
+	log.Debug("Fetching latency percentiles from database")
+
+	q, ok := latencyQueries[filter.GroupBy]
+	if !ok {
+		return nil, domain.ErrInvalidGrouping
+	}
+	args := latencyFilterArgs(filter)
+
+	overallQuery := fmt.Sprintf(q.template, q.groupColumns, "", "", "")
+	rows, err := r.pool.Query(ctx, overallQuery, args...)
+	if err != nil {
+		log.Error("Failed to query latency", zap.Error(err))
+		return nil, fmt.Errorf("failed to query latency: %w", err)
+	}
+	defer rows.Close()
+
+	var series []*domain.LatencySeries
+	byKey := make(map[string]*domain.LatencySeries)
+	for rows.Next() {
+		var s domain.LatencySeries
+		var p50, p90, p99 float64
+		if err := rows.Scan(&s.Key, &s.Name, &s.Overall.Count, &p50, &p90, &p99); err != nil {
+			log.Error("Failed to scan latency row", zap.Error(err))
+			return nil, fmt.Errorf("failed to scan latency: %w", err)
+		}
+		s.Overall.P50, s.Overall.P90, s.Overall.P99 = seconds(p50), seconds(p90), seconds(p99)
+		series = append(series, &s)
+		byKey[s.Key] = &s
+	}
+	if err := rows.Err(); err != nil {
+		log.Error("Error after iterating over latency rows", zap.Error(err))
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	if filter.Bucket != "" {
+		bucketArgs := append(args, string(filter.Bucket))
+		if err := r.fillLatencyBuckets(ctx, q, bucketArgs, byKey); err != nil {
+			log.Error("Failed to fetch latency buckets", zap.Error(err))
+			return nil, err
+		}
+	}
+
+	log.Info("Successfully fetched latency percentiles", zap.Int("group_count", len(series)))
+	return series, nil
+}
+
+// fillLatencyBuckets добавляет в серии перцентили по интервалам
+func (r *StatsRepository) fillLatencyBuckets(ctx context.Context, q latencyQuery, args []any, byKey map[string]*domain.LatencySeries) error {
+	query := fmt.Sprintf(q.template, q.groupColumns, q.bucketColumn, ", bucket_start", ", bucket_start")
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to query latency buckets: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var key, name string
+		var bucket domain.LatencyBucketStat
+		var p50, p90, p99 float64
+		if err := rows.Scan(&key, &name, &bucket.Start, &bucket.Count, &p50, &p90, &p99); err != nil {
+			return fmt.Errorf("failed to scan latency bucket: %w", err)
+		}
+		bucket.P50, bucket.P90, bucket.P99 = seconds(p50), seconds(p90), seconds(p99)
+		bucket.Start = bucket.Start.UTC()
+		if s, ok := byKey[key]; ok {
+			s.Buckets = append(s.Buckets, bucket)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("rows iteration error: %w", err)
+	}
+	return nil
+}
+
+// getMemberAssignmentsQuery - SQL-запрос числа назначений каждого участника команды за период [$1, $2).
+// $3 - команда, NULL означает все команды.
+const getMemberAssignmentsQuery = `
+	SELECT
+		u.team_name,
+		u.id,
+		u.username,
+		u.is_active,
+		COUNT(prr.pull_request_id) as assignments
+	FROM
+		users u
+	LEFT JOIN pull_request_reviewers prr
+		ON prr.reviewer_id = u.id AND prr.assigned_at >= $1 AND prr.assigned_at < $2
+	WHERE
+		($3::text IS NULL OR u.team_name = $3)
+	GROUP BY
+		u.team_name, u.id, u.username, u.is_active
+	ORDER BY
+		u.team_name, u.username;
+`
+
+// getTeamUnavailabilityQuery - периоды недоступности участников, пересекающиеся с периодом [$1, $2), обрезанные по его границам
+const getTeamUnavailabilityQuery = `
+	SELECT
+		ua.user_id,
+		GREATEST(ua.starts_at, $1) as starts_at,
+		LEAST(ua.ends_at, $2) as ends_at
+	FROM
+		user_unavailability ua
+	JOIN users u ON u.id = ua.user_id
+	WHERE
+		ua.starts_at < $2 AND ua.ends_at > $1
+		AND ($3::text IS NULL OR u.team_name = $3)
+	ORDER BY
+		ua.user_id, ua.starts_at;
+`
+
+// GetMemberAssignments получает число назначений на ревью за период для каждого участника команды.
+func (r *StatsRepository) GetMemberAssignments(ctx context.Context, filter domain.FairnessFilter) ([]*domain.MemberAssignments, error) {
+	log := logger.FromContext(ctx, r.log).With(zap.String("repo_method", "GetMemberAssignments"))
+	log.Debug("Fetching member assignments from database")
+
+	rows, err := r.pool.Query(ctx, getMemberAssignmentsQuery, filter.From, filter.To, optionalText(filter.TeamName))
+	if err != nil {
+		log.Error("Failed to query member assignments", zap.Error(err))
+		return nil, fmt.Errorf("failed to query member assignments: %w", err)
+	}
+	defer rows.Close()
+
+	var members []*domain.MemberAssignments
+	for rows.Next() {
+		var m domain.MemberAssignments
+		if err := rows.Scan(&m.TeamName, &m.UserID, &m.Username, &m.IsActive, &m.Assignments); err != nil {
+			log.Error("Failed to scan member assignments row", zap.Error(err))
+			return nil, fmt.Errorf("failed to scan member assignments: %w", err)
+		}
+		members = append(members, &m)
+	}
+	if err := rows.Err(); err != nil {
+		log.Error("Error after iterating over member assignments rows", zap.Error(err))
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+	return members, nil
+}
+
+// GetTeamUnavailability получает периоды недоступности участников команд, обрезанные по границам периода отчета.
+// В результате заполнены только UserID, StartsAt и EndsAt.
+func (r *StatsRepository) GetTeamUnavailability(ctx context.Context, filter domain.FairnessFilter) ([]domain.Unavailability, error) {
+	log := logger.FromContext(ctx, r.log).With(zap.String("repo_method", "GetTeamUnavailability"))
+
+	rows, err := r.pool.Query(ctx, getTeamUnavailabilityQuery, filter.From, filter.To, optionalText(filter.TeamName))
+	if err != nil {
+		log.Error("Failed to query team unavailability", zap.Error(err))
+		return nil, fmt.Errorf("failed to query team unavailability: %w", err)
+	}
+	defer rows.Close()
+
+	var periods []domain.Unavailability
+	for rows.Next() {
+		var period domain.Unavailability
+		if err := rows.Scan(&period.UserID, &period.StartsAt, &period.EndsAt); err != nil {
+			log.Error("Failed to scan team unavailability row", zap.Error(err))
+			return nil, fmt.Errorf("failed to scan team unavailability: %w", err)
+		}
+		periods = append(periods, period)
+	}
+	if err := rows.Err(); err != nil {
+		log.Error("Error after iterating over team unavailability rows", zap.Error(err))
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+	return periods, nil
+}
+
 // GetUserReviewStats получает статистику по количеству назначенных ревью для каждого пользователя.
-func (r *StatsRepository) GetUserReviewStats(ctx context.Context) ([]*domain.UserReviewStat, error) {
-	log := r.log.With(zap.String("repo_method", "GetUserReviewStats"))
+func (r *StatsRepository) GetUserReviewStats(ctx context.Context, filter domain.StatsFilter) ([]*domain.UserReviewStat, error) {
+	var stats []*domain.UserReviewStat
+	err := r.StreamUserReviewStats(ctx, filter, func(stat *domain.UserReviewStat) error {
+		stats = append(stats, stat)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
+
+// StreamUserReviewStats построчно читает статистику пользователей и передает каждую строку в fn,
+// не накапливая результат в памяти. Ошибка fn прерывает чтение и возвращается как есть.
+func (r *StatsRepository) StreamUserReviewStats(ctx context.Context, filter domain.StatsFilter, fn func(*domain.UserReviewStat) error) error {
+	log := logger.FromContext(ctx, r.log).With(zap.String("repo_method", "StreamUserReviewStats"))
 	log.Debug("Fetching user review statistics from database")
 
-	rows, err := r.pool.Query(ctx, GetUserReviewStatsQuery)
+	rows, err := r.pool.Query(ctx, GetUserReviewStatsQuery, statsFilterArgs(filter)...)
 	if err != nil {
 		log.Error("Failed to query user review stats", zap.Error(err))
-		return nil, fmt.Errorf("failed to query user review stats: %w", err)
+		return fmt.Errorf("failed to query user review stats: %w", err)
 	}
 	defer rows.Close()
 
-	var stats []*domain.UserReviewStat
+	count := 0
 	for rows.Next() {
 		var stat domain.UserReviewStat
-		if err := rows.Scan(&stat.UserID, &stat.Username, &stat.IsActive, &stat.ReviewCount); err != nil {
+		if err := rows.Scan(&stat.UserID, &stat.Username, &stat.IsActive, &stat.ReviewCount, &stat.OpenReviewCount, &stat.ReviewCapacity); err != nil {
 			log.Error("Failed to scan user stat row", zap.Error(err))
-			return nil, fmt.Errorf("failed to scan user stat: %w", err)
+			return fmt.Errorf("failed to scan user stat: %w", err)
 		}
-		stats = append(stats, &stat)
+		if err := fn(&stat); err != nil {
+			return err
+		}
+		count++
 	}
 
 	if err := rows.Err(); err != nil {
 		log.Error("Error after iterating over stats rows", zap.Error(err))
+		return fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	log.Info("Successfully fetched user review statistics", zap.Int("user_count", count))
+	return nil
+}
+
+// GetTeamReviewStats получает агрегаты по PR для каждой команды.
+func (r *StatsRepository) GetTeamReviewStats(ctx context.Context, filter domain.StatsFilter) ([]*domain.TeamReviewStat, error) {
+	log := logger.FromContext(ctx, r.log).With(zap.String("repo_method", "GetTeamReviewStats"))
+	log.Debug("Fetching team review statistics from database")
+
+	rows, err := r.pool.Query(ctx, GetTeamReviewStatsQuery, statsFilterArgs(filter)...)
+	if err != nil {
+		log.Error("Failed to query team review stats", zap.Error(err))
+		return nil, fmt.Errorf("failed to query team review stats: %w", err)
+	}
+	defer rows.Close()
+
+	var stats []*domain.TeamReviewStat
+	for rows.Next() {
+		var stat domain.TeamReviewStat
+		if err := rows.Scan(&stat.TeamName, &stat.PRsOpened, &stat.PRsMerged, &stat.AvgReviewersPerPR, &stat.PRsWithoutReviewers); err != nil {
+			log.Error("Failed to scan team stat row", zap.Error(err))
+			return nil, fmt.Errorf("failed to scan team stat: %w", err)
+		}
+		stats = append(stats, &stat)
+	}
+
+	if err := rows.Err(); err != nil {
+		log.Error("Error after iterating over team stats rows", zap.Error(err))
 		return nil, fmt.Errorf("rows iteration error: %w", err)
 	}
 
-	log.Info("Successfully fetched user review statistics", zap.Int("user_count", len(stats)))
+	log.Info("Successfully fetched team review statistics", zap.Int("team_count", len(stats)))
 	return stats, nil
 }
+
+// statsFilterArgs преобразует фильтр в параметры запроса ($1 - from, $2 - to, $3 - команда, $4 - статус)
+func statsFilterArgs(filter domain.StatsFilter) []any {
+	var status *string
+	if filter.Status != nil {
+		s := string(*filter.Status)
+		status = &s
+	}
+	return []any{filter.From, filter.To, optionalText(filter.TeamName), status}
+}
+
+// optionalText превращает пустую строку в NULL для необязательных фильтров
+func optionalText(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+// latencyFilterArgs преобразует фильтр в параметры запроса ($1 - from, $2 - to, $3 - команда)
+func latencyFilterArgs(filter domain.LatencyFilter) []any {
+	return []any{filter.From, filter.To, optionalText(filter.TeamName)}
+}
+
+func seconds(s float64) time.Duration {
+	return time.Duration(s * float64(time.Second))
+}
@@ -0,0 +1,147 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go.uber.org/zap"
+
+	"avito/internal/domain"
+	"avito/pkg/logger"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	getUserTagsQuery = `SELECT tag FROM user_tags WHERE user_id = $1 ORDER BY tag`
+
+	addUserTagsQuery = `INSERT INTO user_tags (user_id, tag)
+						SELECT $1, UNNEST($2::text[])
+						ON CONFLICT (user_id, tag) DO NOTHING`
+
+	deleteUserTagsQuery = `DELETE FROM user_tags WHERE user_id = $1`
+
+	deleteUserTagQuery = `DELETE FROM user_tags WHERE user_id = $1 AND tag = $2`
+
+	getTagsByUserIDsQuery = `SELECT user_id, tag FROM user_tags WHERE user_id = ANY($1::uuid[])`
+)
+
+// GetUserTags возвращает теги экспертизы пользователя
+func (r *TagRepository) GetUserTags(ctx context.Context, userID uuid.UUID) ([]string, error) {
+	log := logger.FromContext(ctx, r.log).With(zap.String("user_id", userID.String()))
+	log.Debug("Getting user tags")
+
+	rows, err := r.pool.Query(ctx, getUserTagsQuery, userID)
+	if err != nil {
+		log.Error("Failed to query user tags", zap.Error(err))
+		return nil, fmt.Errorf("failed to query user tags: %w", err)
+	}
+	defer rows.Close()
+
+	tags := make([]string, 0)
+	for rows.Next() {
+		var tag string
+		if err := rows.Scan(&tag); err != nil {
+			log.Error("Failed to scan user tag", zap.Error(err))
+			return nil, fmt.Errorf("failed to scan user tag: %w", err)
+		}
+		tags = append(tags, tag)
+	}
+	if err := rows.Err(); err != nil {
+		log.Error("Error after iterating over user tags", zap.Error(err))
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	log.Debug("User tags retrieved", zap.Int("count", len(tags)))
+	return tags, nil
+}
+
+// AddUserTags добавляет пользователю теги, уже существующие теги пропускаются
+func (r *TagRepository) AddUserTags(ctx context.Context, userID uuid.UUID, tags []string) error {
+	log := logger.FromContext(ctx, r.log).With(zap.String("user_id", userID.String()))
+	log.Debug("Adding user tags", zap.Strings("tags", tags))
+
+	if _, err := r.pool.Exec(ctx, addUserTagsQuery, userID, tags); err != nil {
+		log.Error("Failed to add user tags", zap.Error(err))
+		return fmt.Errorf("failed to add user tags: %w", err)
+	}
+	return nil
+}
+
+// ReplaceUserTags заменяет все теги пользователя на переданные в одной транзакции
+func (r *TagRepository) ReplaceUserTags(ctx context.Context, userID uuid.UUID, tags []string) error {
+	log := logger.FromContext(ctx, r.log).With(zap.String("user_id", userID.String()))
+	log.Debug("Replacing user tags in a transaction", zap.Strings("tags", tags))
+
+	tx, err := r.pool.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		log.Error("Failed to begin transaction", zap.Error(err))
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(ctx); err != nil && !errors.Is(err, pgx.ErrTxClosed) {
+			log.Error("Failed to rollback transaction", zap.Error(err))
+		}
+	}()
+
+	if _, err := tx.Exec(ctx, deleteUserTagsQuery, userID); err != nil {
+		log.Error("Failed to delete user tags", zap.Error(err))
+		return fmt.Errorf("failed to delete user tags: %w", err)
+	}
+	if len(tags) > 0 {
+		if _, err := tx.Exec(ctx, addUserTagsQuery, userID, tags); err != nil {
+			log.Error("Failed to insert user tags", zap.Error(err))
+			return fmt.Errorf("failed to insert user tags: %w", err)
+		}
+	}
+
+	log.Debug("Committing transaction")
+	return tx.Commit(ctx)
+}
+
+// DeleteUserTag удаляет один тег пользователя
+func (r *TagRepository) DeleteUserTag(ctx context.Context, userID uuid.UUID, tag string) error {
+	log := logger.FromContext(ctx, r.log).With(zap.String("user_id", userID.String()), zap.String("tag", tag))
+	log.Debug("Deleting user tag")
+
+	commandTag, err := r.pool.Exec(ctx, deleteUserTagQuery, userID, tag)
+	if err != nil {
+		log.Error("Failed to delete user tag", zap.Error(err))
+		return fmt.Errorf("failed to delete user tag: %w", err)
+	}
+	if commandTag.RowsAffected() == 0 {
+		log.Warn("User tag not found")
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+// GetTagsByUserIDs возвращает теги сразу для набора пользователей
+func (r *TagRepository) GetTagsByUserIDs(ctx context.Context, userIDs []uuid.UUID) (map[uuid.UUID][]string, error) {
+	log := logger.FromContext(ctx, r.log).With(zap.Int("users_count", len(userIDs)))
+	log.Debug("Getting tags for users")
+
+	rows, err := r.pool.Query(ctx, getTagsByUserIDsQuery, userIDs)
+	if err != nil {
+		log.Error("Failed to query tags for users", zap.Error(err))
+		return nil, fmt.Errorf("failed to query tags for users: %w", err)
+	}
+	defer rows.Close()
+
+	tags := make(map[uuid.UUID][]string, len(userIDs))
+	for rows.Next() {
+		var userID uuid.UUID
+		var tag string
+		if err := rows.Scan(&userID, &tag); err != nil {
+			log.Error("Failed to scan user tag row", zap.Error(err))
+			return nil, fmt.Errorf("failed to scan user tag: %w", err)
+		}
+		tags[userID] = append(tags[userID], tag)
+	}
+	if err := rows.Err(); err != nil {
+		log.Error("Error after iterating over user tags", zap.Error(err))
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+	return tags, nil
+}
@@ -6,38 +6,58 @@ import (
 	"go.uber.org/zap"
 
 	"avito/internal/domain"
+	"avito/pkg/logger"
 
 	"github.com/google/uuid"
 )
 
 const (
-	saveTeamQuery      = `INSERT INTO teams (name) VALUES ($1)`
-	getTeamByNameQuery = `SELECT t.name, u.id, u.username, u.is_active, u.team_name
+	saveTeamQuery      = `INSERT INTO teams (name, default_review_capacity, review_sla_minutes, escalation_minutes) VALUES ($1, $2, $3, $4)`
+	getTeamByNameQuery = `SELECT t.name, t.default_review_capacity, t.review_sla_minutes, t.escalation_minutes, t.version, u.id, u.username, u.is_active, u.team_name, u.review_capacity, COALESCE(u.role, 'member')
                             FROM teams t
                             LEFT JOIN users u ON t.name = u.team_name
                             WHERE t.name = $1`
 
+	getTeamsByNamesQuery = `SELECT t.name, t.default_review_capacity, t.review_sla_minutes, t.escalation_minutes, t.version, u.id, u.username, u.is_active, u.team_name, u.review_capacity, COALESCE(u.role, 'member')
+                            FROM teams t
+                            LEFT JOIN users u ON t.name = u.team_name
+                            WHERE t.name = ANY($1::text[])
+                            ORDER BY t.name`
+
 	existsTeamQuery = `SELECT EXISTS(SELECT name FROM teams WHERE name = $1)`
+
+	// Пустая ожидаемая версия отключает проверку
+	setDefaultReviewCapacityQuery = `UPDATE teams SET default_review_capacity = $1, version = version + 1
+									 WHERE name = $2 AND ($3::bigint IS NULL OR version = $3)`
+
+	setReviewSLAQuery = `UPDATE teams SET review_sla_minutes = $1, escalation_minutes = $2, version = version + 1
+						 WHERE name = $3 AND ($4::bigint IS NULL OR version = $4)`
+
+	// bumpMovedMembersTeamsQuery увеличивает версии команд, из которых участники переходят в новую команду
+	bumpMovedMembersTeamsQuery = `UPDATE teams SET version = version + 1
+								  WHERE name IN (SELECT team_name FROM users WHERE id = ANY($1::uuid[]) AND team_name <> $2)`
 )
 
 // SaveTeam Сохраняет новую команду.
 func (r *TeamRepository) SaveTeam(ctx context.Context, team domain.Team) error {
-	r.log.Debug("Saving team", zap.Any("team", team))
-	_, err := r.pool.Exec(ctx, saveTeamQuery, team.Name)
+	log := logger.FromContext(ctx, r.log)
+	log.Debug("Saving team", zap.Any("team", team))
+	_, err := r.pool.Exec(ctx, saveTeamQuery, team.Name, team.DefaultReviewCapacity, team.ReviewSLAMinutes, team.EscalationMinutes)
 	if err != nil {
-		r.log.Error("Failed to save team", zap.Any("team", team), zap.Error(err))
+		log.Error("Failed to save team", zap.Any("team", team), zap.Error(err))
 		return fmt.Errorf("failed to save team: %w", err)
 	}
-	r.log.Debug("Saved team", zap.Any("team", team))
+	log.Debug("Saved team", zap.Any("team", team))
 	return nil
 }
 
 func (r *TeamRepository) GetTeamByName(ctx context.Context, name string) (*domain.Team, error) {
-	r.log.Debug("Getting team by name", zap.String("name", name))
+	log := logger.FromContext(ctx, r.log)
+	log.Debug("Getting team by name", zap.String("name", name))
 
 	rows, err := r.pool.Query(ctx, getTeamByNameQuery, name)
 	if err != nil {
-		r.log.Error("Failed to query team by name", zap.String("name", name), zap.Error(err))
+		log.Error("Failed to query team by name", zap.String("name", name), zap.Error(err))
 		return nil, fmt.Errorf("failed to query team by name: %w", err)
 	}
 	defer rows.Close()
@@ -48,14 +68,22 @@ func (r *TeamRepository) GetTeamByName(ctx context.Context, name string) (*domai
 	for rows.Next() {
 		var user domain.User
 		var teamName string
-		err = rows.Scan(&teamName, &user.ID, &user.Username, &user.IsActive, &user.TeamName)
+		var defaultCapacity, slaMinutes, escalationMinutes *int
+		var version int64
+		err = rows.Scan(&teamName, &defaultCapacity, &slaMinutes, &escalationMinutes, &version, &user.ID, &user.Username, &user.IsActive, &user.TeamName, &user.ReviewCapacity, &user.Role)
 		if err != nil {
-			r.log.Error("Failed to scan team member row", zap.String("name", name), zap.Error(err))
+			log.Error("Failed to scan team member row", zap.String("name", name), zap.Error(err))
 			return nil, fmt.Errorf("failed to scan row: %w", err)
 		}
 
 		if team == nil {
-			team = &domain.Team{Name: teamName}
+			team = &domain.Team{
+				Name:                  teamName,
+				DefaultReviewCapacity: defaultCapacity,
+				ReviewSLAMinutes:      slaMinutes,
+				EscalationMinutes:     escalationMinutes,
+				Version:               version,
+			}
 		}
 
 		if user.ID != uuid.Nil {
@@ -64,26 +92,128 @@ func (r *TeamRepository) GetTeamByName(ctx context.Context, name string) (*domai
 	}
 
 	if err := rows.Err(); err != nil {
-		r.log.Error("Error after iterating over team members", zap.String("name", name), zap.Error(err))
+		log.Error("Error after iterating over team members", zap.String("name", name), zap.Error(err))
 		return nil, fmt.Errorf("rows iteration error: %w", err)
 	}
 
 	if team == nil {
-		r.log.Warn("Team not found", zap.String("name", name))
+		log.Warn("Team not found", zap.String("name", name))
 		return nil, domain.ErrNotFound
 	}
 
 	team.Members = members
-	r.log.Debug("Team found", zap.Any("team", team))
+	log.Debug("Team found", zap.Any("team", team))
 	return team, nil
 }
 
+// GetTeamsByNames находит команды с участниками по набору имен одним запросом. Отсутствующие команды в результат не попадают
+func (r *TeamRepository) GetTeamsByNames(ctx context.Context, names []string) ([]domain.Team, error) {
+	log := logger.FromContext(ctx, r.log).With(zap.Int("teams_count", len(names)))
+	log.Debug("Getting teams by names")
+
+	rows, err := r.pool.Query(ctx, getTeamsByNamesQuery, names)
+	if err != nil {
+		log.Error("Failed to query teams by names", zap.Error(err))
+		return nil, fmt.Errorf("failed to query teams by names: %w", err)
+	}
+	defer rows.Close()
+
+	teams := make([]domain.Team, 0, len(names))
+	for rows.Next() {
+		var user domain.User
+		var teamName string
+		var defaultCapacity, slaMinutes, escalationMinutes *int
+		var version int64
+		var userID *uuid.UUID
+		var username, userTeam *string
+		var isActive *bool
+		err = rows.Scan(&teamName, &defaultCapacity, &slaMinutes, &escalationMinutes, &version, &userID, &username, &isActive, &userTeam, &user.ReviewCapacity, &user.Role)
+		if err != nil {
+			log.Error("Failed to scan team member row", zap.Error(err))
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+
+		// Строки одной команды идут подряд благодаря сортировке по имени
+		if len(teams) == 0 || teams[len(teams)-1].Name != teamName {
+			teams = append(teams, domain.Team{
+				Name:                  teamName,
+				Members:               make([]domain.User, 0),
+				DefaultReviewCapacity: defaultCapacity,
+				ReviewSLAMinutes:      slaMinutes,
+				EscalationMinutes:     escalationMinutes,
+				Version:               version,
+			})
+		}
+
+		if userID != nil {
+			user.ID, user.Username, user.IsActive, user.TeamName = *userID, *username, *isActive, *userTeam
+			team := &teams[len(teams)-1]
+			team.Members = append(team.Members, user)
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		log.Error("Error after iterating over teams", zap.Error(err))
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	log.Debug("Teams found", zap.Int("found", len(teams)))
+	return teams, nil
+}
+
 func (r *TeamRepository) ExistsTeam(ctx context.Context, name string) (bool, error) {
+	log := logger.FromContext(ctx, r.log)
 	var exists bool
 	err := r.pool.QueryRow(ctx, existsTeamQuery, name).Scan(&exists)
 	if err != nil {
-		r.log.Error("Failed to check if team exists", zap.String("name", name), zap.Error(err))
+		log.Error("Failed to check if team exists", zap.String("name", name), zap.Error(err))
 		return false, fmt.Errorf("failed to check existence: %w", err)
 	}
 	return exists, nil
 }
+
+// SetDefaultReviewCapacity устанавливает лимит открытых ревью по умолчанию для участников команды.
+// expectedVersion nil отключает проверку версии
+func (r *TeamRepository) SetDefaultReviewCapacity(ctx context.Context, name string, capacity *int, expectedVersion *int64) error {
+	log := logger.FromContext(ctx, r.log)
+	log.Debug("Setting team default review capacity", zap.String("name", name), zap.Intp("default_review_capacity", capacity))
+	commandTag, err := r.pool.Exec(ctx, setDefaultReviewCapacityQuery, capacity, name, expectedVersion)
+	if err != nil {
+		log.Error("Failed to set team default review capacity", zap.String("name", name), zap.Error(err))
+		return fmt.Errorf("failed to set team default review capacity: %w", err)
+	}
+	if commandTag.RowsAffected() == 0 {
+		return r.missingTeamError(ctx, log, name, expectedVersion)
+	}
+	return nil
+}
+
+// SetReviewSLA устанавливает пороги напоминания и переназначения ревью для команды.
+// expectedVersion nil отключает проверку версии
+func (r *TeamRepository) SetReviewSLA(ctx context.Context, name string, slaMinutes, escalationMinutes *int, expectedVersion *int64) error {
+	log := logger.FromContext(ctx, r.log)
+	log.Debug("Setting team review SLA", zap.String("name", name),
+		zap.Intp("review_sla_minutes", slaMinutes), zap.Intp("escalation_minutes", escalationMinutes))
+	commandTag, err := r.pool.Exec(ctx, setReviewSLAQuery, slaMinutes, escalationMinutes, name, expectedVersion)
+	if err != nil {
+		log.Error("Failed to set team review SLA", zap.String("name", name), zap.Error(err))
+		return fmt.Errorf("failed to set team review SLA: %w", err)
+	}
+	if commandTag.RowsAffected() == 0 {
+		return r.missingTeamError(ctx, log, name, expectedVersion)
+	}
+	return nil
+}
+
+// missingTeamError определяет, почему обновление команды не затронуло ни одной строки:
+// команда существует, но ее версия изменилась, или команды нет
+func (r *TeamRepository) missingTeamError(ctx context.Context, log *zap.Logger, name string, expectedVersion *int64) error {
+	if expectedVersion != nil {
+		if exists, err := r.ExistsTeam(ctx, name); err == nil && exists {
+			log.Warn("Team version does not match", zap.String("name", name), zap.Int64p("expected_version", expectedVersion))
+			return domain.ErrVersionConflict
+		}
+	}
+	log.Warn("Team not found", zap.String("name", name))
+	return domain.ErrNotFound
+}
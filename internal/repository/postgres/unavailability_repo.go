@@ -0,0 +1,211 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go.uber.org/zap"
+
+	"avito/internal/domain"
+	"avito/pkg/logger"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	unavailabilityColumns = `id, user_id, starts_at, ends_at, reason, COALESCE(uid, ''), reviews_reassigned_at, created_at`
+
+	createUnavailabilityQuery = `INSERT INTO user_unavailability (id, user_id, starts_at, ends_at, reason, created_at)
+								 VALUES ($1, $2, $3, $4, $5, $6)`
+
+	// Повторный импорт события с тем же UID обновляет период. Если сдвинулись даты, ревью переназначаются заново
+	upsertImportedUnavailabilityQuery = `INSERT INTO user_unavailability (id, user_id, starts_at, ends_at, reason, uid, created_at)
+										 VALUES ($1, $2, $3, $4, $5, NULLIF($6, ''), $7)
+										 ON CONFLICT (user_id, uid) DO UPDATE
+										 SET starts_at = EXCLUDED.starts_at,
+											 ends_at = EXCLUDED.ends_at,
+											 reason = EXCLUDED.reason,
+											 reviews_reassigned_at = CASE
+												 WHEN user_unavailability.starts_at = EXCLUDED.starts_at
+													 AND user_unavailability.ends_at = EXCLUDED.ends_at
+												 THEN user_unavailability.reviews_reassigned_at
+											 END
+										 RETURNING ` + unavailabilityColumns
+
+	getUnavailabilityByUserIDQuery = `SELECT ` + unavailabilityColumns + `
+									  FROM user_unavailability
+									  WHERE user_id = $1
+									  ORDER BY starts_at`
+
+	getUnavailabilityByIDQuery = `SELECT ` + unavailabilityColumns + ` FROM user_unavailability WHERE id = $1`
+
+	deleteUnavailabilityQuery = `DELETE FROM user_unavailability WHERE id = $1
+								 RETURNING ` + unavailabilityColumns
+
+	getStartedUnavailabilityQuery = `SELECT ` + unavailabilityColumns + `
+									 FROM user_unavailability
+									 WHERE starts_at <= NOW() AND ends_at > NOW() AND reviews_reassigned_at IS NULL
+									 ORDER BY starts_at`
+
+	markReviewsReassignedQuery = `UPDATE user_unavailability SET reviews_reassigned_at = NOW() WHERE id = $1`
+)
+
+// CreateUnavailability сохраняет один период недоступности
+func (r *UnavailabilityRepository) CreateUnavailability(ctx context.Context, period domain.Unavailability) error {
+	log := logger.FromContext(ctx, r.log).With(zap.String("user_id", period.UserID.String()))
+	log.Debug("Creating unavailability period", zap.Time("starts_at", period.StartsAt), zap.Time("ends_at", period.EndsAt))
+
+	_, err := r.pool.Exec(ctx, createUnavailabilityQuery,
+		period.ID, period.UserID, period.StartsAt, period.EndsAt, period.Reason, period.CreatedAt)
+	if err != nil {
+		log.Error("Failed to create unavailability period", zap.Error(err))
+		return fmt.Errorf("failed to create unavailability period: %w", err)
+	}
+	return nil
+}
+
+// CreateUnavailabilities сохраняет несколько периодов недоступности в одной транзакции.
+// Период с UID, который у пользователя уже есть, обновляет существующую запись. Возвращает сохраненные записи
+func (r *UnavailabilityRepository) CreateUnavailabilities(ctx context.Context, periods []domain.Unavailability) ([]domain.Unavailability, error) {
+	log := logger.FromContext(ctx, r.log).With(zap.Int("count", len(periods)))
+	log.Debug("Creating unavailability periods in a transaction")
+
+	tx, err := r.pool.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		log.Error("Failed to begin transaction", zap.Error(err))
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(ctx); err != nil && !errors.Is(err, pgx.ErrTxClosed) {
+			log.Error("Failed to rollback transaction", zap.Error(err))
+		}
+	}()
+
+	saved := make([]domain.Unavailability, 0, len(periods))
+	for _, period := range periods {
+		var stored domain.Unavailability
+		err := tx.QueryRow(ctx, upsertImportedUnavailabilityQuery,
+			period.ID, period.UserID, period.StartsAt, period.EndsAt, period.Reason, period.UID, period.CreatedAt,
+		).Scan(
+			&stored.ID, &stored.UserID, &stored.StartsAt, &stored.EndsAt,
+			&stored.Reason, &stored.UID, &stored.ReviewsReassignedAt, &stored.CreatedAt,
+		)
+		if err != nil {
+			log.Error("Failed to create unavailability period within transaction", zap.String("uid", period.UID), zap.Error(err))
+			return nil, fmt.Errorf("failed to create unavailability period: %w", err)
+		}
+		saved = append(saved, stored)
+	}
+
+	log.Debug("Committing transaction")
+	if err := tx.Commit(ctx); err != nil {
+		log.Error("Failed to commit transaction", zap.Error(err))
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return saved, nil
+}
+
+// GetUnavailabilityByUserID возвращает все периоды недоступности пользователя
+func (r *UnavailabilityRepository) GetUnavailabilityByUserID(ctx context.Context, userID uuid.UUID) ([]domain.Unavailability, error) {
+	log := logger.FromContext(ctx, r.log).With(zap.String("user_id", userID.String()))
+	log.Debug("Getting unavailability periods for user")
+
+	rows, err := r.pool.Query(ctx, getUnavailabilityByUserIDQuery, userID)
+	if err != nil {
+		log.Error("Failed to query unavailability periods", zap.Error(err))
+		return nil, fmt.Errorf("failed to query unavailability periods: %w", err)
+	}
+	return r.collectUnavailability(rows, log)
+}
+
+// DeleteUnavailability удаляет период недоступности и возвращает удаленную запись
+func (r *UnavailabilityRepository) DeleteUnavailability(ctx context.Context, id uuid.UUID) (*domain.Unavailability, error) {
+	log := logger.FromContext(ctx, r.log).With(zap.String("unavailability_id", id.String()))
+	log.Debug("Deleting unavailability period")
+
+	var period domain.Unavailability
+	err := r.pool.QueryRow(ctx, deleteUnavailabilityQuery, id).Scan(
+		&period.ID, &period.UserID, &period.StartsAt, &period.EndsAt,
+		&period.Reason, &period.UID, &period.ReviewsReassignedAt, &period.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			log.Warn("Unavailability period not found")
+			return nil, domain.ErrNotFound
+		}
+		log.Error("Failed to delete unavailability period", zap.Error(err))
+		return nil, fmt.Errorf("failed to delete unavailability period: %w", err)
+	}
+	return &period, nil
+}
+
+// GetUnavailabilityByID возвращает период недоступности по ID
+func (r *UnavailabilityRepository) GetUnavailabilityByID(ctx context.Context, id uuid.UUID) (*domain.Unavailability, error) {
+	log := logger.FromContext(ctx, r.log).With(zap.String("unavailability_id", id.String()))
+	log.Debug("Getting unavailability period")
+
+	var period domain.Unavailability
+	err := r.pool.QueryRow(ctx, getUnavailabilityByIDQuery, id).Scan(
+		&period.ID, &period.UserID, &period.StartsAt, &period.EndsAt,
+		&period.Reason, &period.UID, &period.ReviewsReassignedAt, &period.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			log.Warn("Unavailability period not found")
+			return nil, domain.ErrNotFound
+		}
+		log.Error("Failed to get unavailability period", zap.Error(err))
+		return nil, fmt.Errorf("failed to get unavailability period: %w", err)
+	}
+	return &period, nil
+}
+
+// GetStartedUnavailability возвращает начавшиеся периоды, для которых ревью еще не переназначены
+func (r *UnavailabilityRepository) GetStartedUnavailability(ctx context.Context) ([]domain.Unavailability, error) {
+	log := logger.FromContext(ctx, r.log).With(zap.String("repo_method", "GetStartedUnavailability"))
+	log.Debug("Getting started unavailability periods")
+
+	rows, err := r.pool.Query(ctx, getStartedUnavailabilityQuery)
+	if err != nil {
+		log.Error("Failed to query started unavailability periods", zap.Error(err))
+		return nil, fmt.Errorf("failed to query started unavailability periods: %w", err)
+	}
+	return r.collectUnavailability(rows, log)
+}
+
+// MarkReviewsReassigned отмечает, что ревью пользователя за период уже переназначены
+func (r *UnavailabilityRepository) MarkReviewsReassigned(ctx context.Context, id uuid.UUID) error {
+	log := logger.FromContext(ctx, r.log)
+	commandTag, err := r.pool.Exec(ctx, markReviewsReassignedQuery, id)
+	if err != nil {
+		log.Error("Failed to mark reviews reassigned", zap.String("unavailability_id", id.String()), zap.Error(err))
+		return fmt.Errorf("failed to mark reviews reassigned: %w", err)
+	}
+	if commandTag.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+func (r *UnavailabilityRepository) collectUnavailability(rows pgx.Rows, log *zap.Logger) ([]domain.Unavailability, error) {
+	defer rows.Close()
+
+	periods := make([]domain.Unavailability, 0)
+	for rows.Next() {
+		var period domain.Unavailability
+		if err := rows.Scan(
+			&period.ID, &period.UserID, &period.StartsAt, &period.EndsAt,
+			&period.Reason, &period.UID, &period.ReviewsReassignedAt, &period.CreatedAt,
+		); err != nil {
+			log.Error("Failed to scan unavailability period", zap.Error(err))
+			return nil, fmt.Errorf("failed to scan unavailability period: %w", err)
+		}
+		periods = append(periods, period)
+	}
+	if err := rows.Err(); err != nil {
+		log.Error("Error after iterating over unavailability periods", zap.Error(err))
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+	return periods, nil
+}
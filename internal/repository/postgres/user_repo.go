@@ -8,94 +8,213 @@ import (
 	"strconv"
 
 	"avito/internal/domain"
+	"avito/pkg/logger"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
 )
 
 const (
-	saveUserQuery = `INSERT INTO users (id, username, is_active, team_name) 
-					 VALUES ($1, $2, $3, $4)
+	// Пустая роль не меняет роль существующего пользователя, новому назначается member
+	saveUserQuery = `INSERT INTO users (id, username, is_active, team_name, review_capacity, role) 
+					 VALUES ($1, $2, $3, $4, $5, COALESCE($6, 'member'))
 					 ON CONFLICT (id) DO UPDATE 
-					 SET username = EXCLUDED.username, is_active = EXCLUDED.is_active, team_name = EXCLUDED.team_name`
+					 SET username = EXCLUDED.username, is_active = EXCLUDED.is_active, team_name = EXCLUDED.team_name,
+					     review_capacity = COALESCE(EXCLUDED.review_capacity, users.review_capacity),
+					     role = COALESCE($6, users.role)`
 
-	getByIDQuery = `SELECT id, username, is_active, team_name FROM users WHERE id = $1`
+	getByIDQuery = `SELECT id, username, is_active, team_name, review_capacity, role FROM users WHERE id = $1`
 
-	getActiveTeamMembersQuery = `SELECT id, username, is_active, team_name 
-							     FROM users 
-							     WHERE team_name = $1 AND is_active = true AND id != ALL($2::uuid[])`
+	getUsersByIDsQuery = `SELECT id, username, is_active, team_name, review_capacity, role FROM users WHERE id = ANY($1::uuid[])`
 
-	setIsActiveQuery = `UPDATE users SET is_active = $1 WHERE id = $2`
+	getActiveTeamMembersQuery = `SELECT u.id, u.username, u.is_active, u.team_name, u.review_capacity, u.role
+							     FROM users u
+							     WHERE u.team_name = $1 AND u.is_active = true AND u.id != ALL($2::uuid[])
+							       AND NOT EXISTS (
+							           SELECT 1 FROM user_unavailability uu
+							           WHERE uu.user_id = u.id AND uu.starts_at <= NOW() AND uu.ends_at > NOW()
+							       )`
+
+	// Активность меняет состав команды, поэтому вместе с ней увеличивается версия команды.
+	// Строка команды обновляется первой, и параллельное изменение по той же версии получит конфликт.
+	// Пустая ожидаемая версия ($3) отключает проверку
+	setIsActiveQuery = `WITH t AS (
+							UPDATE teams SET version = version + 1
+							WHERE name = (SELECT team_name FROM users WHERE id = $2) AND ($3::bigint IS NULL OR version = $3)
+							RETURNING name
+						), u AS (
+							UPDATE users SET is_active = $1 WHERE id = $2 AND team_name IN (SELECT name FROM t)
+							RETURNING id
+						)
+						SELECT EXISTS(SELECT 1 FROM u), EXISTS(SELECT 1 FROM users WHERE id = $2)`
+
+	// Лимит и роль входят в представление команды, поэтому их изменение тоже увеличивает версию команды
+	setReviewCapacityQuery = `WITH u AS (
+								  UPDATE users SET review_capacity = $1 WHERE id = $2 RETURNING team_name
+							  ), t AS (
+								  UPDATE teams SET version = version + 1 WHERE name IN (SELECT team_name FROM u)
+							  )
+							  SELECT COUNT(*) FROM u`
+
+	setRoleQuery = `WITH u AS (
+						UPDATE users SET role = $1 WHERE id = $2 RETURNING team_name
+					), t AS (
+						UPDATE teams SET version = version + 1 WHERE name IN (SELECT team_name FROM u)
+					)
+					SELECT COUNT(*) FROM u`
 )
 
 // SaveUser Сохраняет нового или обновляет существующего пользователя
 func (r *UserRepository) SaveUser(ctx context.Context, user domain.User) error {
-	r.log.Debug("Saving user", zap.Any("user", user))
-	_, err := r.pool.Exec(ctx, saveUserQuery, user.ID, user.Username, user.IsActive, user.TeamName)
+	log := logger.FromContext(ctx, r.log)
+	log.Debug("Saving user", zap.Any("user", user))
+	_, err := r.pool.Exec(ctx, saveUserQuery, user.ID, user.Username, user.IsActive, user.TeamName, user.ReviewCapacity, roleOrNil(user.Role))
 	if err != nil {
-		r.log.Error("Error saving user", zap.Error(err))
+		log.Error("Error saving user", zap.Error(err))
 		return fmt.Errorf("error saving user: %w", err)
 	}
-	r.log.Debug("Saved user", zap.Any("user", user))
+	log.Debug("Saved user", zap.Any("user", user))
 	return nil
 }
 
 // GetUserByID Находит пользователя по ID
 func (r *UserRepository) GetUserByID(ctx context.Context, id uuid.UUID) (*domain.User, error) {
-	r.log.Debug("Getting user by id", zap.String("id", id.String()))
+	log := logger.FromContext(ctx, r.log)
+	log.Debug("Getting user by id", zap.String("id", id.String()))
 	var user domain.User
 	err := r.pool.QueryRow(ctx, getByIDQuery, id).Scan(
 		&user.ID,
 		&user.Username,
 		&user.IsActive,
 		&user.TeamName,
+		&user.ReviewCapacity,
+		&user.Role,
 	)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
-			r.log.Warn("User not found", zap.String("id", id.String()))
+			log.Warn("User not found", zap.String("id", id.String()))
 			return nil, domain.ErrNotFound
 		}
-		r.log.Error("Error getting user by id", zap.String("id", id.String()))
+		log.Error("Error getting user by id", zap.String("id", id.String()))
 		return nil, fmt.Errorf("error getting user by id: %w", err)
 	}
-	r.log.Debug("User found", zap.String("id", id.String()))
+	log.Debug("User found", zap.String("id", id.String()))
 	return &user, nil
 }
 
-// GetActiveTeamMembers Находит всех активных пользователей в команде, кроме автора
+// GetUsersByIDs Находит пользователей из набора ID одним запросом. Отсутствующие ID в результат не попадают
+func (r *UserRepository) GetUsersByIDs(ctx context.Context, ids []uuid.UUID) ([]domain.User, error) {
+	log := logger.FromContext(ctx, r.log).With(zap.Int("users_count", len(ids)))
+	log.Debug("Getting users by ids")
+	rows, err := r.pool.Query(ctx, getUsersByIDsQuery, ids)
+	if err != nil {
+		log.Error("Error getting users by ids", zap.Error(err))
+		return nil, fmt.Errorf("error getting users by ids: %w", err)
+	}
+	defer rows.Close()
+	users := make([]domain.User, 0, len(ids))
+	for rows.Next() {
+		var user domain.User
+		if err := rows.Scan(&user.ID, &user.Username, &user.IsActive, &user.TeamName, &user.ReviewCapacity, &user.Role); err != nil {
+			log.Error("Error scanning users by ids", zap.Error(err))
+			return nil, fmt.Errorf("error scanning users by ids: %w", err)
+		}
+		users = append(users, user)
+	}
+	if err := rows.Err(); err != nil {
+		log.Error("Error iterating users by ids", zap.Error(err))
+		return nil, fmt.Errorf("error iterating users by ids: %w", err)
+	}
+	log.Debug("Users found", zap.Int("found", len(users)))
+	return users, nil
+}
+
+// GetActiveTeamMembers Находит всех активных пользователей в команде, кроме автора.
+// Пользователи, недоступные в текущий момент, в результат не попадают
 func (r *UserRepository) GetActiveTeamMembers(ctx context.Context, teamName string, excludeIDs []uuid.UUID) ([]domain.User, error) {
-	r.log.Debug("Getting active team members", zap.String("team_name", teamName))
+	log := logger.FromContext(ctx, r.log)
+	log.Debug("Getting active team members", zap.String("team_name", teamName))
 	rows, err := r.pool.Query(ctx, getActiveTeamMembersQuery, teamName, excludeIDs)
 	if err != nil {
-		r.log.Error("Error getting active team members", zap.Error(err))
+		log.Error("Error getting active team members", zap.Error(err))
 		return nil, fmt.Errorf("error getting active team members: %w", err)
 	}
 	defer rows.Close()
 	var users []domain.User
 	for rows.Next() {
 		var user domain.User
-		err := rows.Scan(&user.ID, &user.Username, &user.IsActive, &user.TeamName)
+		err := rows.Scan(&user.ID, &user.Username, &user.IsActive, &user.TeamName, &user.ReviewCapacity, &user.Role)
 		if err != nil {
-			r.log.Error("Error scanning active team members", zap.Error(err))
+			log.Error("Error scanning active team members", zap.Error(err))
 			return nil, fmt.Errorf("error scanning active team members: %w", err)
 		}
 		users = append(users, user)
 	}
-	r.log.Debug("Users found", zap.Int("count", len(users)))
+	log.Debug("Users found", zap.Int("count", len(users)))
 	return users, nil
 }
 
-func (r *UserRepository) SetIsActive(ctx context.Context, id uuid.UUID, isActive bool) error {
-	r.log.Debug("Setting is_active", zap.String("id", id.String()), zap.String("is_active", strconv.FormatBool(isActive)))
-	commandTag, err := r.pool.Exec(ctx, setIsActiveQuery, isActive, id)
+// SetIsActive меняет активность пользователя. expectedTeamVersion nil отключает проверку версии команды
+func (r *UserRepository) SetIsActive(ctx context.Context, id uuid.UUID, isActive bool, expectedTeamVersion *int64) error {
+	log := logger.FromContext(ctx, r.log)
+	log.Debug("Setting is_active", zap.String("id", id.String()), zap.String("is_active", strconv.FormatBool(isActive)))
+	var updated, exists bool
+	err := r.pool.QueryRow(ctx, setIsActiveQuery, isActive, id, expectedTeamVersion).Scan(&updated, &exists)
 	if err != nil {
-		r.log.Error("Error setting IsActive", zap.Error(err))
+		log.Error("Error setting IsActive", zap.Error(err))
 		return fmt.Errorf("error saving IsActive: %w", err)
 	}
-	if commandTag.RowsAffected() == 0 {
-		r.log.Warn("User not found for SetIsActive", zap.String("id", id.String()))
+	if !exists {
+		log.Warn("User not found for SetIsActive", zap.String("id", id.String()))
 		return domain.ErrNotFound
 	}
-	r.log.Debug("is_active set successful", zap.String("id", id.String()), zap.String("is_active", strconv.FormatBool(isActive)))
+	if !updated {
+		log.Warn("Team version does not match for SetIsActive", zap.String("id", id.String()), zap.Int64p("expected_team_version", expectedTeamVersion))
+		return domain.ErrVersionConflict
+	}
+	log.Debug("is_active set successful", zap.String("id", id.String()), zap.String("is_active", strconv.FormatBool(isActive)))
 	return nil
 }
+
+// SetReviewCapacity устанавливает личный лимит открытых ревью. nil сбрасывает лимит до командного
+func (r *UserRepository) SetReviewCapacity(ctx context.Context, id uuid.UUID, capacity *int) error {
+	log := logger.FromContext(ctx, r.log)
+	log.Debug("Setting review capacity", zap.String("id", id.String()), zap.Intp("review_capacity", capacity))
+	var updated int64
+	err := r.pool.QueryRow(ctx, setReviewCapacityQuery, capacity, id).Scan(&updated)
+	if err != nil {
+		log.Error("Error setting review capacity", zap.Error(err))
+		return fmt.Errorf("error saving review capacity: %w", err)
+	}
+	if updated == 0 {
+		log.Warn("User not found for SetReviewCapacity", zap.String("id", id.String()))
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+// SetRole назначает пользователю роль
+func (r *UserRepository) SetRole(ctx context.Context, id uuid.UUID, role domain.Role) error {
+	log := logger.FromContext(ctx, r.log)
+	log.Debug("Setting role", zap.String("id", id.String()), zap.String("role", string(role)))
+	var updated int64
+	err := r.pool.QueryRow(ctx, setRoleQuery, role, id).Scan(&updated)
+	if err != nil {
+		log.Error("Error setting role", zap.Error(err))
+		return fmt.Errorf("error saving role: %w", err)
+	}
+	if updated == 0 {
+		log.Warn("User not found for SetRole", zap.String("id", id.String()))
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+// roleOrNil возвращает nil для пустой роли, чтобы запрос сохранения не менял роль пользователя
+func roleOrNil(role domain.Role) *string {
+	if role == "" {
+		return nil
+	}
+	value := string(role)
+	return &value
+}
@@ -0,0 +1,216 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"go.uber.org/zap"
+	"strings"
+	"time"
+
+	"avito/internal/auth"
+	"avito/internal/domain"
+	"avito/pkg/logger"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// apiKeyTokenPrefix отличает ключи сервиса от прочих секретов, например при поиске утечек в логах
+	apiKeyTokenPrefix = "avk_"
+	// apiKeySecretBytes - размер случайной части ключа
+	apiKeySecretBytes = 32
+	// apiKeyDisplayLength - сколько первых символов ключа хранится открыто для опознания
+	apiKeyDisplayLength = 12
+	// apiKeyTouchInterval ограничивает частоту записи времени последнего использования ключа
+	apiKeyTouchInterval = time.Minute
+	// bootstrapAdminKeyName - имя ключа администратора, заданного в конфигурации
+	bootstrapAdminKeyName = "bootstrap-admin"
+)
+
+type APIKeyRepository interface {
+	CreateAPIKey(ctx context.Context, key domain.APIKey) error
+	GetAPIKeyByHash(ctx context.Context, hash string) (*domain.APIKey, error)
+	GetAPIKeys(ctx context.Context) ([]domain.APIKey, error)
+	RevokeAPIKey(ctx context.Context, id uuid.UUID, revokedAt time.Time) (*domain.APIKey, error)
+	TouchAPIKey(ctx context.Context, id uuid.UUID, usedAt time.Time) error
+}
+
+type APIKeyService struct {
+	repo APIKeyRepository
+	log  *zap.Logger
+}
+
+func NewAPIKeyService(repo APIKeyRepository, log *zap.Logger) *APIKeyService {
+	return &APIKeyService{
+		repo: repo,
+		log:  log.Named("APIKeyService"),
+	}
+}
+
+// CreateAPIKey выпускает новый ключ. Открытый ключ возвращается только здесь, в базе остается его хеш
+func (s *APIKeyService) CreateAPIKey(ctx context.Context, name string, scopes []domain.Scope, expiresAt *time.Time) (*domain.APIKey, string, error) {
+	log := logger.FromContext(ctx, s.log).With(zap.String("name", name), zap.String("method", "CreateAPIKey"))
+	name = strings.TrimSpace(name)
+	if name == "" || len(scopes) == 0 {
+		log.Warn("API key name or scopes are empty")
+		return nil, "", domain.ErrOneOfParametersNil
+	}
+	for _, scope := range scopes {
+		if !scope.Valid() {
+			log.Warn("Unknown scope requested", zap.String("scope", string(scope)))
+			return nil, "", fmt.Errorf("%w: %s", domain.ErrInvalidScope, scope)
+		}
+	}
+	now := time.Now().UTC()
+	if expiresAt != nil && !expiresAt.After(now) {
+		log.Warn("API key expiration is in the past", zap.Time("expires_at", *expiresAt))
+		return nil, "", domain.ErrInvalidPeriod
+	}
+
+	plaintext, err := generateAPIKey()
+	if err != nil {
+		log.Error("Failed to generate API key", zap.Error(err))
+		return nil, "", fmt.Errorf("failed to generate API key: %w", err)
+	}
+
+	createdBy := ""
+	if principal, ok := auth.PrincipalFromContext(ctx); ok {
+		createdBy = principal.ID
+	}
+	key := domain.APIKey{
+		ID:        uuid.New(),
+		Name:      name,
+		Prefix:    plaintext[:apiKeyDisplayLength],
+		Hash:      HashAPIKey(plaintext),
+		Scopes:    scopes,
+		CreatedBy: createdBy,
+		CreatedAt: now,
+		ExpiresAt: expiresAt,
+	}
+	if err := s.repo.CreateAPIKey(ctx, key); err != nil {
+		log.Error("Failed to create API key", zap.Error(err))
+		return nil, "", fmt.Errorf("failed to create API key: %w", err)
+	}
+	log.Info("API key created", zap.String("api_key_id", key.ID.String()))
+	return &key, plaintext, nil
+}
+
+func (s *APIKeyService) GetAPIKeys(ctx context.Context) ([]domain.APIKey, error) {
+	log := logger.FromContext(ctx, s.log).With(zap.String("method", "GetAPIKeys"))
+	keys, err := s.repo.GetAPIKeys(ctx)
+	if err != nil {
+		log.Error("Failed to get API keys", zap.Error(err))
+		return nil, fmt.Errorf("failed to get API keys: %w", err)
+	}
+	return keys, nil
+}
+
+// RevokeAPIKey отзывает ключ, после чего он перестает проходить аутентификацию
+func (s *APIKeyService) RevokeAPIKey(ctx context.Context, id uuid.UUID) (*domain.APIKey, error) {
+	log := logger.FromContext(ctx, s.log).With(zap.String("api_key_id", id.String()), zap.String("method", "RevokeAPIKey"))
+	key, err := s.repo.RevokeAPIKey(ctx, id, time.Now().UTC())
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			log.Warn("API key not found")
+			return nil, err
+		}
+		log.Error("Failed to revoke API key", zap.Error(err))
+		return nil, fmt.Errorf("failed to revoke API key: %w", err)
+	}
+	log.Info("API key revoked")
+	return key, nil
+}
+
+// Authenticate проверяет открытый ключ и возвращает вызывающего.
+// Неизвестный, отозванный или истекший ключ дает domain.ErrUnauthorized
+func (s *APIKeyService) Authenticate(ctx context.Context, plaintext string) (*domain.Principal, error) {
+	log := logger.FromContext(ctx, s.log).With(zap.String("method", "Authenticate"))
+	key, err := s.repo.GetAPIKeyByHash(ctx, HashAPIKey(plaintext))
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			log.Debug("Unknown API key")
+			return nil, domain.ErrUnauthorized
+		}
+		log.Error("Failed to get API key", zap.Error(err))
+		return nil, fmt.Errorf("failed to get API key: %w", err)
+	}
+
+	now := time.Now().UTC()
+	if !key.Active(now) {
+		log.Debug("API key is revoked or expired", zap.String("api_key_id", key.ID.String()))
+		return nil, domain.ErrUnauthorized
+	}
+
+	// Время последнего использования пишется не чаще раза в минуту, чтобы не обновлять строку на каждый запрос
+	if key.LastUsedAt == nil || now.Sub(*key.LastUsedAt) >= apiKeyTouchInterval {
+		if err := s.repo.TouchAPIKey(ctx, key.ID, now); err != nil {
+			log.Warn("Failed to record API key use", zap.Error(err))
+		}
+	}
+
+	principal := &domain.Principal{
+		Type:   domain.PrincipalAPIKey,
+		ID:     key.ID.String(),
+		Name:   key.Name,
+		Scopes: key.Scopes,
+		Role:   domain.RoleIntegration,
+	}
+	if principal.HasScope(domain.ScopeAdmin) {
+		principal.Role = domain.RoleAdmin
+	}
+	return principal, nil
+}
+
+// EnsureBootstrapKey регистрирует ключ администратора из конфигурации, если его еще нет.
+// Так в пустой базе можно выпустить первые ключи через API
+func (s *APIKeyService) EnsureBootstrapKey(ctx context.Context, plaintext string) error {
+	log := logger.FromContext(ctx, s.log).With(zap.String("method", "EnsureBootstrapKey"))
+	if plaintext == "" {
+		return nil
+	}
+
+	hash := HashAPIKey(plaintext)
+	_, err := s.repo.GetAPIKeyByHash(ctx, hash)
+	if err == nil {
+		return nil
+	}
+	if !errors.Is(err, domain.ErrNotFound) {
+		log.Error("Failed to look up bootstrap API key", zap.Error(err))
+		return fmt.Errorf("failed to look up bootstrap API key: %w", err)
+	}
+
+	key := domain.APIKey{
+		ID:        uuid.New(),
+		Name:      bootstrapAdminKeyName,
+		Prefix:    plaintext[:min(apiKeyDisplayLength, len(plaintext))],
+		Hash:      hash,
+		Scopes:    []domain.Scope{domain.ScopeAdmin},
+		CreatedBy: "config",
+		CreatedAt: time.Now().UTC(),
+	}
+	if err := s.repo.CreateAPIKey(ctx, key); err != nil {
+		log.Error("Failed to create bootstrap API key", zap.Error(err))
+		return fmt.Errorf("failed to create bootstrap API key: %w", err)
+	}
+	log.Info("Bootstrap admin API key registered", zap.String("api_key_id", key.ID.String()))
+	return nil
+}
+
+// HashAPIKey возвращает SHA-256 ключа в hex. Ключи случайные и длинные, поэтому медленный KDF не нужен
+func HashAPIKey(plaintext string) string {
+	sum := sha256.Sum256([]byte(plaintext))
+	return hex.EncodeToString(sum[:])
+}
+
+func generateAPIKey() (string, error) {
+	secret := make([]byte, apiKeySecretBytes)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+	return apiKeyTokenPrefix + base64.RawURLEncoding.EncodeToString(secret), nil
+}
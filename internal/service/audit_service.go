@@ -0,0 +1,71 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"go.uber.org/zap"
+	"time"
+
+	"avito/internal/domain"
+	"avito/pkg/logger"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// defaultAuditEventsLimit - количество записей журнала, возвращаемых по умолчанию.
+	defaultAuditEventsLimit = 50
+	// maxAuditEventsLimit - максимальное количество записей журнала в одном ответе.
+	maxAuditEventsLimit = 500
+)
+
+type AuditRepository interface {
+	SaveAuditEvent(ctx context.Context, event domain.AuditEvent) error
+	GetAuditEvents(ctx context.Context, principalID string, limit int) ([]domain.AuditEvent, error)
+}
+
+type AuditService struct {
+	repo AuditRepository
+	log  *zap.Logger
+}
+
+func NewAuditService(repo AuditRepository, log *zap.Logger) *AuditService {
+	return &AuditService{
+		repo: repo,
+		log:  log.Named("AuditService"),
+	}
+}
+
+// Record сохраняет запись журнала аудита, заполняя идентификатор и время, если они не заданы
+func (s *AuditService) Record(ctx context.Context, event domain.AuditEvent) error {
+	log := logger.FromContext(ctx, s.log).With(zap.String("action", event.Action), zap.String("method", "Record"))
+	if event.ID == uuid.Nil {
+		event.ID = uuid.New()
+	}
+	if event.OccurredAt.IsZero() {
+		event.OccurredAt = time.Now().UTC()
+	}
+	if err := s.repo.SaveAuditEvent(ctx, event); err != nil {
+		log.Error("Failed to record audit event", zap.Error(err))
+		return fmt.Errorf("failed to record audit event: %w", err)
+	}
+	return nil
+}
+
+// GetAuditEvents возвращает последние записи журнала, при пустом principalID - всех вызывающих
+func (s *AuditService) GetAuditEvents(ctx context.Context, principalID string, limit int) ([]domain.AuditEvent, error) {
+	log := logger.FromContext(ctx, s.log).With(zap.String("principal_id", principalID), zap.String("method", "GetAuditEvents"))
+	if limit == 0 {
+		limit = defaultAuditEventsLimit
+	}
+	if limit < 0 || limit > maxAuditEventsLimit {
+		log.Warn("Invalid audit events limit", zap.Int("limit", limit))
+		return nil, domain.ErrOneOfParametersNil
+	}
+	events, err := s.repo.GetAuditEvents(ctx, principalID, limit)
+	if err != nil {
+		log.Error("Failed to get audit events", zap.Error(err))
+		return nil, fmt.Errorf("failed to get audit events: %w", err)
+	}
+	return events, nil
+}
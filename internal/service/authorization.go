@@ -0,0 +1,63 @@
+package service
+
+import (
+	"context"
+
+	"avito/internal/auth"
+	"avito/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+// Проверки ролей выполняются в сервисах, а не в транспорте, чтобы правила были одинаковыми
+// для любого способа вызова. Вызовы без вызывающего (фоновые задачи) разрешены всегда.
+
+// authorizeAdmin разрешает действие только администратору
+func authorizeAdmin(ctx context.Context) error {
+	principal, ok := auth.PrincipalFromContext(ctx)
+	if !ok || principal.IsAdmin() {
+		return nil
+	}
+	return domain.ErrForbidden
+}
+
+// authorizeTeamLead разрешает действие над командой teamName ее руководителю, администратору и интеграциям
+func authorizeTeamLead(ctx context.Context, teamName string) error {
+	principal, ok := auth.PrincipalFromContext(ctx)
+	if !ok || principal.ManagesTeam(teamName) {
+		return nil
+	}
+	return domain.ErrForbidden
+}
+
+// authorizeSelf разрешает действие от имени пользователя userID самому пользователю,
+// администратору и интеграциям
+func authorizeSelf(ctx context.Context, userID uuid.UUID) error {
+	principal, ok := auth.PrincipalFromContext(ctx)
+	if !ok || principal.IsUser(userID) {
+		return nil
+	}
+	if principal.Role == domain.RoleAdmin || principal.Role == domain.RoleIntegration {
+		return nil
+	}
+	return domain.ErrForbidden
+}
+
+// authorizeSelfOrTeamLead разрешает действие над пользователем ему самому, а над другими - руководителю
+// его команды, администратору и интеграциям с правом users:write. Маршруты таких действий право не требуют,
+// чтобы пользователь мог менять свои данные, поэтому для чужих данных оно проверяется здесь
+func authorizeSelfOrTeamLead(ctx context.Context, user *domain.User) error {
+	principal, ok := auth.PrincipalFromContext(ctx)
+	if !ok || principal.IsUser(user.ID) {
+		return nil
+	}
+	if principal.ManagesTeam(user.TeamName) && principal.HasScope(domain.ScopeUsersWrite) {
+		return nil
+	}
+	return domain.ErrForbidden
+}
+
+// authorizeTeamLeadOf разрешает действие над пользователем руководителю его команды
+func authorizeTeamLeadOf(ctx context.Context, user *domain.User) error {
+	return authorizeTeamLead(ctx, user.TeamName)
+}
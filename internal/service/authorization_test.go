@@ -0,0 +1,217 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"avito/internal/auth"
+	"avito/internal/domain"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// fakeTagRepo принимает любые изменения тегов и ничего не хранит
+type fakeTagRepo struct{}
+
+func (fakeTagRepo) GetUserTags(context.Context, uuid.UUID) ([]string, error) {
+	return nil, nil
+}
+
+func (fakeTagRepo) AddUserTags(context.Context, uuid.UUID, []string) error {
+	return nil
+}
+
+func (fakeTagRepo) ReplaceUserTags(context.Context, uuid.UUID, []string) error {
+	return nil
+}
+
+func (fakeTagRepo) DeleteUserTag(context.Context, uuid.UUID, string) error {
+	return nil
+}
+
+// TestRoleAuthorization проверяет каждое действие, ограниченное ролью, от имени каждой роли.
+// Разрешенный вызов доходит до проверок после авторизации и возвращает allowedErr
+func TestRoleAuthorization(t *testing.T) {
+	memberID, colleagueID, leadID, otherLeadID, adminID := uuid.New(), uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	users := map[uuid.UUID]domain.User{
+		memberID:    {ID: memberID, Username: "member", IsActive: true, TeamName: "backend", Role: domain.RoleMember},
+		colleagueID: {ID: colleagueID, Username: "colleague", IsActive: true, TeamName: "backend", Role: domain.RoleMember},
+		leadID:      {ID: leadID, Username: "lead", IsActive: true, TeamName: "backend", Role: domain.RoleTeamLead},
+		otherLeadID: {ID: otherLeadID, Username: "other-lead", IsActive: true, TeamName: "frontend", Role: domain.RoleTeamLead},
+		adminID:     {ID: adminID, Username: "admin", IsActive: true, TeamName: "platform", Role: domain.RoleAdmin},
+	}
+	// Права маршрутов выданы всем, чтобы проверялись только роли
+	scopes := []domain.Scope{domain.ScopeUsersWrite, domain.ScopeTeamsWrite, domain.ScopePRsWrite}
+	principals := map[string]domain.Principal{
+		"admin":       userPrincipal(adminID, domain.RoleAdmin, "platform", scopes...),
+		"integration": {Type: domain.PrincipalAPIKey, ID: "ci", Role: domain.RoleIntegration, Scopes: scopes},
+		"lead":        userPrincipal(leadID, domain.RoleTeamLead, "backend", scopes...),
+		"other lead":  userPrincipal(otherLeadID, domain.RoleTeamLead, "frontend", scopes...),
+		"member":      userPrincipal(memberID, domain.RoleMember, "backend", scopes...),
+		"colleague":   userPrincipal(colleagueID, domain.RoleMember, "backend", scopes...),
+	}
+
+	newUserService := func() *UserService {
+		repo := &fakeUserRepo{users: make(map[uuid.UUID]domain.User, len(users))}
+		for id, user := range users {
+			repo.users[id] = user
+		}
+		return NewUserService(repo, nil, fakeTagRepo{}, zap.NewNop())
+	}
+	newPRService := func() *PullRequestService {
+		prs := &fakePRRepo{prs: map[string]*domain.PullRequest{
+			"pr-1": {ID: "pr-1", AuthorID: colleagueID, Status: domain.StatusOpen, AssignedReviewers: []uuid.UUID{memberID}},
+		}}
+		return NewPullRequestService(prs, &fakeUserRepo{users: users}, nil, nil, nil, zap.NewNop())
+	}
+	negative := -1
+	start := time.Now()
+
+	tests := []struct {
+		name       string
+		call       func(ctx context.Context) error
+		allowed    []string
+		allowedErr error
+	}{
+		{
+			name: "create team is admin only",
+			call: func(ctx context.Context) error {
+				_, err := NewTeamService(nil, nil, zap.NewNop()).CreateTeamWithMembers(ctx, domain.Team{})
+				return err
+			},
+			allowed:    []string{"admin"},
+			allowedErr: domain.ErrOneOfParametersNil,
+		},
+		{
+			name: "set role is admin only",
+			call: func(ctx context.Context) error {
+				_, err := newUserService().SetRole(ctx, memberID, domain.RoleTeamLead)
+				return err
+			},
+			allowed: []string{"admin"},
+		},
+		{
+			name: "team review capacity is changed by its lead",
+			call: func(ctx context.Context) error {
+				_, err := NewTeamService(nil, nil, zap.NewNop()).SetDefaultReviewCapacity(ctx, "backend", &negative, nil)
+				return err
+			},
+			allowed:    []string{"admin", "integration", "lead"},
+			allowedErr: domain.ErrInvalidCapacity,
+		},
+		{
+			name: "team review SLA is changed by its lead",
+			call: func(ctx context.Context) error {
+				_, err := NewTeamService(nil, nil, zap.NewNop()).SetReviewSLA(ctx, "backend", &negative, nil, nil)
+				return err
+			},
+			allowed:    []string{"admin", "integration", "lead"},
+			allowedErr: domain.ErrInvalidSLA,
+		},
+		{
+			name: "user review capacity is changed by the team lead",
+			call: func(ctx context.Context) error {
+				capacity := 3
+				_, err := newUserService().SetReviewCapacity(ctx, memberID, &capacity)
+				return err
+			},
+			allowed: []string{"admin", "integration", "lead"},
+		},
+		{
+			name: "user tags are added by the team lead",
+			call: func(ctx context.Context) error {
+				_, err := newUserService().AddUserTags(ctx, memberID, []string{"go"})
+				return err
+			},
+			allowed: []string{"admin", "integration", "lead"},
+		},
+		{
+			name: "user tags are replaced by the team lead",
+			call: func(ctx context.Context) error {
+				_, err := newUserService().SetUserTags(ctx, memberID, []string{"go"})
+				return err
+			},
+			allowed: []string{"admin", "integration", "lead"},
+		},
+		{
+			name: "user tag is removed by the team lead",
+			call: func(ctx context.Context) error {
+				_, err := newUserService().RemoveUserTag(ctx, memberID, "go")
+				return err
+			},
+			allowed: []string{"admin", "integration", "lead"},
+		},
+		{
+			name: "user activity is changed by the user or the team lead",
+			call: func(ctx context.Context) error {
+				_, err := newUserService().SetIsActive(ctx, memberID, false, nil)
+				return err
+			},
+			allowed: []string{"admin", "integration", "lead", "member"},
+		},
+		{
+			name: "unavailability is added by the user or the team lead",
+			call: func(ctx context.Context) error {
+				srv := NewUnavailabilityService(&fakeUnavailabilityRepo{}, &fakeUserRepo{users: users}, nil, nil, zap.NewNop())
+				_, err := srv.AddUnavailability(ctx, memberID, start, start.Add(time.Hour), "vacation")
+				return err
+			},
+			allowed: []string{"admin", "integration", "lead", "member"},
+		},
+		{
+			name: "pull request is created by its author",
+			call: func(ctx context.Context) error {
+				_, _, err := newPRService().CreatePR(ctx, "pr-1", "feature", memberID, nil)
+				return err
+			},
+			allowed:    []string{"admin", "integration", "member"},
+			allowedErr: domain.ErrPRExists,
+		},
+		{
+			name: "reviewers are reassigned by the lead of the author's team",
+			call: func(ctx context.Context) error {
+				_, _, err := newPRService().ReassignmentReviewers(ctx, "pr-1", colleagueID, nil)
+				return err
+			},
+			allowed:    []string{"admin", "integration", "lead"},
+			allowedErr: domain.ErrAuthorCannotDelete,
+		},
+		{
+			name: "verdict is submitted by the reviewer",
+			call: func(ctx context.Context) error {
+				_, err := newPRService().SubmitVerdict(ctx, "pr-1", memberID, domain.VerdictApproved)
+				return err
+			},
+			allowed: []string{"admin", "integration", "member"},
+		},
+		{
+			name: "merge is limited by the route scope only",
+			call: func(ctx context.Context) error {
+				_, err := newPRService().SetMerge(ctx, "pr-1", nil)
+				return err
+			},
+			allowed: []string{"admin", "integration", "lead", "other lead", "member", "colleague"},
+		},
+	}
+	for _, tt := range tests {
+		allowed := make(map[string]bool, len(tt.allowed))
+		for _, name := range tt.allowed {
+			allowed[name] = true
+		}
+		for name, principal := range principals {
+			t.Run(tt.name+"/"+name, func(t *testing.T) {
+				err := tt.call(auth.WithPrincipal(context.Background(), principal))
+				var want error = domain.ErrForbidden
+				if allowed[name] {
+					want = tt.allowedErr
+				}
+				if !errors.Is(err, want) {
+					t.Fatalf("got error %v, want %v", err, want)
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,137 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"go.uber.org/zap"
+	"sync"
+	"time"
+
+	"avito/internal/domain"
+	"avito/pkg/logger"
+)
+
+// healthCheckTimeout ограничивает время одной проверки готовности
+const healthCheckTimeout = 2 * time.Second
+
+type DatabaseHealthProvider interface {
+	Ping(ctx context.Context) error
+	MigrationState(ctx context.Context) (domain.MigrationState, error)
+}
+
+type SchedulerStateProvider interface {
+	State() domain.SchedulerState
+}
+
+type HealthService struct {
+	db        DatabaseHealthProvider
+	scheduler SchedulerStateProvider
+	log       *zap.Logger
+}
+
+func NewHealthService(db DatabaseHealthProvider, scheduler SchedulerStateProvider, log *zap.Logger) *HealthService {
+	return &HealthService{
+		db:        db,
+		scheduler: scheduler,
+		log:       log.Named("HealthService"),
+	}
+}
+
+type healthCheck struct {
+	name string
+	run  func(ctx context.Context) (map[string]any, error)
+}
+
+// Readiness параллельно выполняет проверки базы, версии миграций и фоновых задач.
+// Каждая проверка ограничена своим таймаутом, для каждой измеряется длительность
+func (s *HealthService) Readiness(ctx context.Context) domain.ReadinessReport {
+	log := logger.FromContext(ctx, s.log)
+	checks := []healthCheck{
+		{name: "database", run: s.checkDatabase},
+		{name: "migrations", run: s.checkMigrations},
+		{name: "workers", run: s.checkWorkers},
+	}
+
+	results := make([]domain.HealthCheckResult, len(checks))
+	var wg sync.WaitGroup
+	for i, check := range checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			checkCtx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+			defer cancel()
+
+			start := time.Now()
+			details, err := check.run(checkCtx)
+			result := domain.HealthCheckResult{
+				Name:    check.name,
+				Status:  domain.HealthOK,
+				Latency: time.Since(start),
+				Details: details,
+			}
+			if err != nil {
+				result.Status = domain.HealthFail
+				result.Error = err.Error()
+			}
+			results[i] = result
+		}()
+	}
+	wg.Wait()
+
+	report := domain.ReadinessReport{Status: domain.HealthOK, Checks: results}
+	for _, result := range results {
+		if result.Status != domain.HealthOK {
+			report.Status = domain.HealthFail
+			log.Warn("Readiness check failed", zap.String("check", result.Name), zap.String("error", result.Error))
+		}
+	}
+	return report
+}
+
+func (s *HealthService) checkDatabase(ctx context.Context) (map[string]any, error) {
+	if err := s.db.Ping(ctx); err != nil {
+		return nil, fmt.Errorf("ping failed: %w", err)
+	}
+	return nil, nil
+}
+
+func (s *HealthService) checkMigrations(ctx context.Context) (map[string]any, error) {
+	state, err := s.db.MigrationState(ctx)
+	details := map[string]any{
+		"current":  state.Current,
+		"expected": state.Expected,
+		"dirty":    state.Dirty,
+	}
+	if err != nil {
+		return details, err
+	}
+	if !state.UpToDate() {
+		return details, fmt.Errorf("schema version %d (dirty: %t) does not match expected %d", state.Current, state.Dirty, state.Expected)
+	}
+	return details, nil
+}
+
+func (s *HealthService) checkWorkers(_ context.Context) (map[string]any, error) {
+	state := s.scheduler.State()
+	jobs := make([]map[string]any, 0, len(state.Jobs))
+	for _, job := range state.Jobs {
+		jobDetails := map[string]any{
+			"name":     job.Name,
+			"interval": job.Interval.String(),
+			"running":  job.Running,
+		}
+		if job.LastFinishedAt != nil {
+			jobDetails["last_finished_at"] = *job.LastFinishedAt
+			jobDetails["last_status"] = job.LastStatus
+		}
+		if job.LastError != "" {
+			jobDetails["last_error"] = job.LastError
+		}
+		jobs = append(jobs, jobDetails)
+	}
+	details := map[string]any{"running": state.Running, "jobs": jobs}
+	if !state.Running {
+		return details, fmt.Errorf("scheduler is not running")
+	}
+	return details, nil
+}
@@ -0,0 +1,51 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"go.uber.org/zap"
+
+	"avito/internal/domain"
+	"avito/pkg/logger"
+)
+
+const (
+	// defaultJobRunsLimit - количество запусков, возвращаемых по умолчанию.
+	defaultJobRunsLimit = 20
+	// maxJobRunsLimit - максимальное количество запусков в одном ответе.
+	maxJobRunsLimit = 200
+)
+
+type JobRunRepository interface {
+	GetJobRuns(ctx context.Context, jobName string, limit int) ([]domain.JobRun, error)
+}
+
+type JobRunService struct {
+	repo JobRunRepository
+	log  *zap.Logger
+}
+
+func NewJobRunService(repo JobRunRepository, log *zap.Logger) *JobRunService {
+	return &JobRunService{
+		repo: repo,
+		log:  log.Named("JobRunService"),
+	}
+}
+
+// GetJobRuns возвращает последние запуски фоновых задач. limit = 0 означает значение по умолчанию
+func (s *JobRunService) GetJobRuns(ctx context.Context, jobName string, limit int) ([]domain.JobRun, error) {
+	log := logger.FromContext(ctx, s.log)
+	if limit < 0 || limit > maxJobRunsLimit {
+		log.Warn("invalid job runs limit", zap.Int("limit", limit))
+		return nil, domain.ErrOneOfParametersNil
+	}
+	if limit == 0 {
+		limit = defaultJobRunsLimit
+	}
+	runs, err := s.repo.GetJobRuns(ctx, jobName, limit)
+	if err != nil {
+		log.Error("Failed to get job runs", zap.String("job", jobName), zap.Error(err))
+		return nil, fmt.Errorf("failed to get job runs: %w", err)
+	}
+	return runs, nil
+}
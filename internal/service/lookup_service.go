@@ -0,0 +1,118 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"go.uber.org/zap"
+
+	"avito/internal/domain"
+	"avito/pkg/logger"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// LookupRepository - чтения сразу по набору ключей, каждое одним запросом к базе
+type LookupRepository interface {
+	GetUsersByIDs(ctx context.Context, ids []uuid.UUID) ([]domain.User, error)
+	GetTeamsByNames(ctx context.Context, names []string) ([]domain.Team, error)
+	GetPRsByIDs(ctx context.Context, ids []string) ([]*domain.PullRequest, error)
+	GetReviewsByReviewerIDs(ctx context.Context, reviewerIDs []uuid.UUID) ([]domain.Review, error)
+	GetTagsByUserIDs(ctx context.Context, userIDs []uuid.UUID) (map[uuid.UUID][]string, error)
+}
+
+// LookupService читает связанные сущности пачками. Им пользуются клиенты, которые собирают
+// граф данных (команда, ее участники, их ревью и авторы PR), чтобы не делать запрос на каждый узел.
+// Результаты возвращаются картами по ключу; ключей, которых нет в базе, в карте нет
+type LookupService struct {
+	repo LookupRepository
+	log  *zap.Logger
+}
+
+func NewLookupService(repo LookupRepository, log *zap.Logger) *LookupService {
+	return &LookupService{
+		repo: repo,
+		log:  log.Named("LookupService"),
+	}
+}
+
+// UsersByIDs возвращает пользователей из набора ID. Теги не заполняются, их отдает TagsByUserIDs
+func (s *LookupService) UsersByIDs(ctx context.Context, ids []uuid.UUID) (_ map[uuid.UUID]*domain.User, err error) {
+	ctx, span := startSpan(ctx, "LookupService.UsersByIDs", attribute.Int("keys", len(ids)))
+	defer func() { endSpan(span, err) }()
+
+	users, err := s.repo.GetUsersByIDs(ctx, ids)
+	if err != nil {
+		logger.FromContext(ctx, s.log).Error("failed to get users by ids", zap.Error(err))
+		return nil, fmt.Errorf("failed to get users by ids: %w", err)
+	}
+	byID := make(map[uuid.UUID]*domain.User, len(users))
+	for i := range users {
+		byID[users[i].ID] = &users[i]
+	}
+	return byID, nil
+}
+
+// TeamsByNames возвращает команды с участниками из набора имен
+func (s *LookupService) TeamsByNames(ctx context.Context, names []string) (_ map[string]*domain.Team, err error) {
+	ctx, span := startSpan(ctx, "LookupService.TeamsByNames", attribute.Int("keys", len(names)))
+	defer func() { endSpan(span, err) }()
+
+	teams, err := s.repo.GetTeamsByNames(ctx, names)
+	if err != nil {
+		logger.FromContext(ctx, s.log).Error("failed to get teams by names", zap.Error(err))
+		return nil, fmt.Errorf("failed to get teams by names: %w", err)
+	}
+	byName := make(map[string]*domain.Team, len(teams))
+	for i := range teams {
+		byName[teams[i].Name] = &teams[i]
+	}
+	return byName, nil
+}
+
+// PullRequestsByIDs возвращает PR с ревьюерами и метками из набора ID
+func (s *LookupService) PullRequestsByIDs(ctx context.Context, ids []string) (_ map[string]*domain.PullRequest, err error) {
+	ctx, span := startSpan(ctx, "LookupService.PullRequestsByIDs", attribute.Int("keys", len(ids)))
+	defer func() { endSpan(span, err) }()
+
+	prs, err := s.repo.GetPRsByIDs(ctx, ids)
+	if err != nil {
+		logger.FromContext(ctx, s.log).Error("failed to get pull requests by ids", zap.Error(err))
+		return nil, fmt.Errorf("failed to get pull requests by ids: %w", err)
+	}
+	byID := make(map[string]*domain.PullRequest, len(prs))
+	for _, pr := range prs {
+		byID[pr.ID] = pr
+	}
+	return byID, nil
+}
+
+// ReviewsByReviewerIDs возвращает назначения каждого ревьюера из набора, от старых к новым
+func (s *LookupService) ReviewsByReviewerIDs(ctx context.Context, reviewerIDs []uuid.UUID) (_ map[uuid.UUID][]domain.Review, err error) {
+	ctx, span := startSpan(ctx, "LookupService.ReviewsByReviewerIDs", attribute.Int("keys", len(reviewerIDs)))
+	defer func() { endSpan(span, err) }()
+
+	reviews, err := s.repo.GetReviewsByReviewerIDs(ctx, reviewerIDs)
+	if err != nil {
+		logger.FromContext(ctx, s.log).Error("failed to get reviews by reviewer ids", zap.Error(err))
+		return nil, fmt.Errorf("failed to get reviews by reviewer ids: %w", err)
+	}
+	byReviewer := make(map[uuid.UUID][]domain.Review, len(reviewerIDs))
+	for _, review := range reviews {
+		byReviewer[review.ReviewerID] = append(byReviewer[review.ReviewerID], review)
+	}
+	return byReviewer, nil
+}
+
+// TagsByUserIDs возвращает теги экспертизы пользователей из набора
+func (s *LookupService) TagsByUserIDs(ctx context.Context, userIDs []uuid.UUID) (_ map[uuid.UUID][]string, err error) {
+	ctx, span := startSpan(ctx, "LookupService.TagsByUserIDs", attribute.Int("keys", len(userIDs)))
+	defer func() { endSpan(span, err) }()
+
+	tags, err := s.repo.GetTagsByUserIDs(ctx, userIDs)
+	if err != nil {
+		logger.FromContext(ctx, s.log).Error("failed to get tags by user ids", zap.Error(err))
+		return nil, fmt.Errorf("failed to get tags by user ids: %w", err)
+	}
+	return tags, nil
+}
@@ -5,12 +5,14 @@ import (
 	"errors"
 	"fmt"
 	"go.uber.org/zap"
-	"math/rand"
 	"time"
 
+	"avito/internal/auth"
 	"avito/internal/domain"
+	"avito/pkg/logger"
 
 	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 const (
@@ -25,80 +27,145 @@ type PullRequestRepo interface {
 	GetPRByID(ctx context.Context, id string) (*domain.PullRequest, error)
 	ReassignReviewer(ctx context.Context, reasReviewer domain.Reassignment) error
 	Exists(ctx context.Context, id string) (bool, error)
-	SetMerge(ctx context.Context, id string) error
+	// SetMerge возвращает true, если PR перешел из OPEN в MERGED именно этим вызовом
+	SetMerge(ctx context.Context, id string, expectedVersion *int64) (bool, error)
+	SetVerdict(ctx context.Context, verdict domain.ReviewVerdict) error
+	StreamPullRequests(ctx context.Context, filter domain.PullRequestFilter, fn func(*domain.PullRequest) error) error
 }
 
 type UserProviderForPR interface {
 	GetUserByID(ctx context.Context, id uuid.UUID) (*domain.User, error)
 	GetActiveTeamMembers(ctx context.Context, teamName string, excludeIDs []uuid.UUID) ([]domain.User, error)
 }
+
+// PullRequestMetrics учитывает бизнес-события жизненного цикла PR
+type PullRequestMetrics interface {
+	PRCreated()
+	PRMerged()
+	ReviewerReassigned()
+	NoCandidate()
+	PRUnderAssigned()
+}
+
 type PullRequestService struct {
-	prRepo  PullRequestRepo
-	userSvc UserProviderForPR
-	log     *zap.Logger
+	prRepo    PullRequestRepo
+	userSvc   UserProviderForPR
+	selector  ReviewerSelector
+	metrics   PullRequestMetrics
+	publisher EventPublisher
+	log       *zap.Logger
 }
 
-func NewPullRequestService(prRepo PullRequestRepo, userSvc UserProviderForPR, log *zap.Logger) *PullRequestService {
+// NewPullRequestService создает сервис PR. В publisher отправляются события назначения и переназначения ревьюеров
+func NewPullRequestService(prRepo PullRequestRepo, userSvc UserProviderForPR, selector ReviewerSelector, metrics PullRequestMetrics, publisher EventPublisher, log *zap.Logger) *PullRequestService {
 	return &PullRequestService{
-		prRepo:  prRepo,
-		userSvc: userSvc,
-		log:     log.Named("PullRequestService"),
+		prRepo:    prRepo,
+		userSvc:   userSvc,
+		selector:  selector,
+		metrics:   metrics,
+		publisher: publisher,
+		log:       log.Named("PullRequestService"),
 	}
 }
 
 // CreatePR обрабатывает создание нового Pull Request и назначение ревьюеров
-func (pr *PullRequestService) CreatePR(ctx context.Context, prID string, prName string, authorID uuid.UUID) (*domain.PullRequest, error) {
-	log := pr.log.With(zap.String("pr_id", prID), zap.String("method", "CreatePR"))
+// Вместе с PR возвращается отчет о назначении: сколько ревьюеров требовалось и сколько удалось назначить.
+func (pr *PullRequestService) CreatePR(ctx context.Context, prID string, prName string, authorID uuid.UUID, labels []string) (_ *domain.PullRequest, _ *domain.AssignmentReport, err error) {
+	ctx, span := startSpan(ctx, "PullRequestService.CreatePR", attribute.String("pr.id", prID))
+	defer func() { endSpan(span, err) }()
+	log := logger.FromContext(ctx, pr.log).With(zap.String("pr_id", prID), zap.String("method", "CreatePR"))
+	normalizedLabels, err := normalizeTags(labels)
+	if err != nil {
+		log.Warn("Invalid pull request labels", zap.Strings("labels", labels))
+		return nil, nil, err
+	}
+	// Участник создает PR только от своего имени
+	if err := authorizeSelf(ctx, authorID); err != nil {
+		log.Warn("Caller is not allowed to create pull request for another author", zap.String("author_id", authorID.String()))
+		return nil, nil, err
+	}
 	exists, err := pr.prRepo.Exists(ctx, prID)
 	if err != nil {
 		log.Error("Failed to check pr existence", zap.Error(err))
-		return nil, fmt.Errorf("failed to check pr existence: %w", err)
+		return nil, nil, fmt.Errorf("failed to check pr existence: %w", err)
 	}
 	if exists {
 		log.Error("Pull request already exists")
-		return nil, domain.ErrPRExists
+		return nil, nil, domain.ErrPRExists
 	}
 
 	author, err := pr.userSvc.GetUserByID(ctx, authorID)
 	if err != nil {
 		if errors.Is(err, domain.ErrNotFound) {
 			log.Warn("author not found", zap.String("authorID", authorID.String()))
-			return nil, domain.ErrNotFound
+			return nil, nil, domain.ErrNotFound
 		}
 		log.Error("Failed to get author by author id", zap.Error(err))
-		return nil, fmt.Errorf("failed to get author by author id: %w", err)
+		return nil, nil, fmt.Errorf("failed to get author by author id: %w", err)
 	}
 
 	if !author.IsActive {
 		log.Warn("Attempted to create PR with an inactive author", zap.String("author_id", author.ID.String()))
-		return nil, domain.ErrAuthorIsInactive
+		return nil, nil, domain.ErrAuthorIsInactive
 	}
 
 	excludeID := []uuid.UUID{author.ID}
 	activeMembers, err := pr.userSvc.GetActiveTeamMembers(ctx, author.TeamName, excludeID)
 	if err != nil {
 		log.Error("Failed to get active team members", zap.Error(err))
-		return nil, fmt.Errorf("failed to get active team members: %w", err)
+		return nil, nil, fmt.Errorf("failed to get active team members: %w", err)
+	}
+	selection, err := pr.selector.SelectReviewers(ctx, activeMembers, normalizedLabels, countReviewers)
+	if err != nil {
+		log.Error("Failed to select reviewers", zap.Error(err))
+		return nil, nil, fmt.Errorf("failed to select reviewers: %w", err)
 	}
 	pullRequest := domain.PullRequest{
 		ID:                prID,
 		Name:              prName,
 		Status:            domain.StatusOpen,
 		AuthorID:          authorID,
-		AssignedReviewers: pr.getRandomReviewers(activeMembers, countReviewers),
+		AssignedReviewers: selection.Reviewers,
+		Labels:            normalizedLabels,
 		CreatedAt:         time.Now().UTC(),
+		Version:           domain.InitialVersion,
 	}
 	if err := pr.prRepo.Create(ctx, &pullRequest); err != nil {
 		log.Error("Failed to create pull request", zap.Error(err))
-		return nil, fmt.Errorf("failed to create pull request: %w", err)
+		return nil, nil, fmt.Errorf("failed to create pull request: %w", err)
+	}
+
+	report := &domain.AssignmentReport{
+		Required:          countReviewers,
+		Assigned:          len(selection.Reviewers),
+		SkippedAtCapacity: selection.SkippedAtCapacity,
+	}
+	pr.metrics.PRCreated()
+	for _, reviewerID := range selection.Reviewers {
+		pr.publisher.Publish(ctx, domain.ReviewEvent{
+			Type:          domain.EventReviewerAssigned,
+			PullRequestID: prID,
+			ReviewerID:    reviewerID,
+			OccurredAt:    pullRequest.CreatedAt,
+		})
+	}
+	if report.Assigned < report.Required {
+		pr.metrics.PRUnderAssigned()
+	}
+	if report.LimitedByCapacity() {
+		log.Warn("Not all reviewer slots filled because of review capacity",
+			zap.Int("required", report.Required), zap.Int("assigned", report.Assigned), zap.Int("skipped_at_capacity", report.SkippedAtCapacity))
 	}
-	return &pullRequest, nil
+	return &pullRequest, report, nil
 
 }
 
-// ReassignmentReviewers обрабатывает логику замены одного ревьюера на другого
-func (pr *PullRequestService) ReassignmentReviewers(ctx context.Context, prID string, oldUserID uuid.UUID) (*domain.PullRequest, string, error) {
-	log := pr.log.With(zap.String("pr_id", prID), zap.String("method", "ReassignmentReviewers"))
+// ReassignmentReviewers обрабатывает логику замены одного ревьюера на другого.
+// Если expectedVersion задан, замена выполняется только для PR этой версии
+func (pr *PullRequestService) ReassignmentReviewers(ctx context.Context, prID string, oldUserID uuid.UUID, expectedVersion *int64) (_ *domain.PullRequest, _ string, err error) {
+	ctx, span := startSpan(ctx, "PullRequestService.ReassignmentReviewers", attribute.String("pr.id", prID))
+	defer func() { endSpan(span, err) }()
+	log := logger.FromContext(ctx, pr.log).With(zap.String("pr_id", prID), zap.String("method", "ReassignmentReviewers"))
 	exists, err := pr.prRepo.Exists(ctx, prID)
 	if err != nil {
 		log.Error("Failed to check pr existence", zap.Error(err))
@@ -106,7 +173,7 @@ func (pr *PullRequestService) ReassignmentReviewers(ctx context.Context, prID st
 	}
 	if !exists {
 		log.Error("Pull request does not exist")
-		return nil, "", domain.ErrPRExists
+		return nil, "", domain.ErrPRNotExist
 	}
 
 	pullRequest, err := pr.prRepo.GetPRByID(ctx, prID)
@@ -115,6 +182,11 @@ func (pr *PullRequestService) ReassignmentReviewers(ctx context.Context, prID st
 		return nil, "", fmt.Errorf("failed to get pull request: %w", err)
 	}
 
+	if expectedVersion != nil && pullRequest.Version != *expectedVersion {
+		log.Warn("Pull request version does not match", zap.Int64("version", pullRequest.Version), zap.Int64p("expected_version", expectedVersion))
+		return nil, "", domain.ErrVersionConflict
+	}
+
 	if pullRequest.Status == domain.StatusMerged {
 		log.Warn("cannot reassign on a merged PR")
 		return nil, "", domain.ErrPRMerged
@@ -129,6 +201,10 @@ func (pr *PullRequestService) ReassignmentReviewers(ctx context.Context, prID st
 		log.Error("Failed to get user by author id", zap.Error(err))
 		return nil, "", fmt.Errorf("failed to get user by author id: %w", err)
 	}
+	if err := authorizeTeamLead(ctx, author.TeamName); err != nil {
+		log.Warn("Caller is not allowed to reassign reviewers in team", zap.String("team_name", author.TeamName))
+		return nil, "", err
+	}
 
 	if author.ID == oldUserID {
 		log.Warn("Author of the pull request cannot be deleted")
@@ -144,39 +220,65 @@ func (pr *PullRequestService) ReassignmentReviewers(ctx context.Context, prID st
 		log.Error("Failed to get active team members", zap.Error(err))
 		return nil, "", fmt.Errorf("failed to get active team members: %w", err)
 	}
-	newReviewerIDs := pr.getRandomReviewers(candidates, countReassignReviewer)
-	if len(newReviewerIDs) == 0 {
+	selection, err := pr.selector.SelectReviewers(ctx, candidates, pullRequest.Labels, countReassignReviewer)
+	if err != nil {
+		log.Error("Failed to select replacement reviewer", zap.Error(err))
+		return nil, "", fmt.Errorf("failed to select replacement reviewer: %w", err)
+	}
+	if len(selection.Reviewers) == 0 {
+		if selection.SkippedAtCapacity > 0 {
+			log.Warn("All replacement candidates are at review capacity", zap.Int("skipped_at_capacity", selection.SkippedAtCapacity))
+			pr.metrics.NoCandidate()
+			return nil, "", fmt.Errorf("%w: all candidates are at review capacity", domain.ErrNoCandidate)
+		}
 		log.Warn("No active replacement candidate in team")
+		pr.metrics.NoCandidate()
 		return nil, "", domain.ErrNoCandidate
 	}
-	newReviewerID := newReviewerIDs[0]
+	newReviewerID := selection.Reviewers[0]
 	reassignment := domain.Reassignment{
-		PullRequestID: prID,
-		OldUserID:     oldUserID,
-		NewUserID:     newReviewerID,
+		PullRequestID:   prID,
+		OldUserID:       oldUserID,
+		NewUserID:       newReviewerID,
+		ExpectedVersion: expectedVersion,
 	}
 	if err := pr.prRepo.ReassignReviewer(ctx, reassignment); err != nil {
 		if errors.Is(err, domain.ErrUserNotAssigned) {
 			log.Warn("user to be reassigned is not currently a reviewer", zap.String("old_user_id", oldUserID.String()))
 			return nil, "", domain.ErrUserNotAssigned
 		}
+		if errors.Is(err, domain.ErrVersionConflict) {
+			log.Warn("Pull request was modified concurrently")
+			return nil, "", domain.ErrVersionConflict
+		}
 
 		log.Error("Failed to update Pull request", zap.Error(err))
 		return nil, "", fmt.Errorf("failed to update Pull request: %w", err)
 	}
+	pr.metrics.ReviewerReassigned()
+	pr.publisher.Publish(ctx, domain.ReviewEvent{
+		Type:          domain.EventReviewerReassigned,
+		PullRequestID: prID,
+		ReviewerID:    oldUserID,
+		NewReviewerID: &newReviewerID,
+		OccurredAt:    time.Now().UTC(),
+	})
 
 	updatedPullRequest, err := pr.prRepo.GetPRByID(ctx, prID)
 	if err != nil {
-		pr.log.Error("Failed to get updated pull request", zap.Error(err))
+		log.Error("Failed to get updated pull request", zap.Error(err))
 		return nil, "", fmt.Errorf("failed to get updated pull request: %w", err)
 	}
 
 	return updatedPullRequest, newReviewerID.String(), nil
 }
 
-// SetMerge обрабатывает "мерж" Pull Request'а
-func (pr *PullRequestService) SetMerge(ctx context.Context, prID string) (*domain.PullRequest, error) {
-	log := pr.log.With(zap.String("pr_id", prID), zap.String("method", "SetMerge"))
+// SetMerge обрабатывает "мерж" Pull Request'а. Если expectedVersion задан, мерж выполняется только для PR этой версии.
+// Уже смерженный PR возвращается без изменений
+func (pr *PullRequestService) SetMerge(ctx context.Context, prID string, expectedVersion *int64) (_ *domain.PullRequest, err error) {
+	ctx, span := startSpan(ctx, "PullRequestService.SetMerge", attribute.String("pr.id", prID))
+	defer func() { endSpan(span, err) }()
+	log := logger.FromContext(ctx, pr.log).With(zap.String("pr_id", prID), zap.String("method", "SetMerge"))
 	exists, err := pr.prRepo.Exists(ctx, prID)
 	if err != nil {
 		log.Error("Failed to check pr existence", zap.Error(err))
@@ -186,11 +288,18 @@ func (pr *PullRequestService) SetMerge(ctx context.Context, prID string) (*domai
 		log.Error("Pull request does not exist")
 		return nil, domain.ErrPRNotExist
 	}
-	err = pr.prRepo.SetMerge(ctx, prID)
+	merged, err := pr.prRepo.SetMerge(ctx, prID, expectedVersion)
 	if err != nil {
+		if errors.Is(err, domain.ErrVersionConflict) {
+			log.Warn("Pull request was modified concurrently", zap.Int64p("expected_version", expectedVersion))
+			return nil, domain.ErrVersionConflict
+		}
 		log.Error("Failed to set pull request merge", zap.Error(err))
 		return nil, fmt.Errorf("failed to set pull request merge: %w", err)
 	}
+	if merged {
+		pr.metrics.PRMerged()
+	}
 
 	pullRequest, err := pr.prRepo.GetPRByID(ctx, prID)
 	if err != nil {
@@ -201,28 +310,95 @@ func (pr *PullRequestService) SetMerge(ctx context.Context, prID string) (*domai
 	return pullRequest, nil
 }
 
-// getRandomReviewers метод для выбора случайных ревьюеров из списка кандидатов
-func (pr *PullRequestService) getRandomReviewers(users []domain.User, count int) []uuid.UUID {
-	if len(users) == 0 {
-		pr.log.Warn("no active members available for review assignment")
-		return []uuid.UUID{}
+// SubmitVerdict сохраняет вердикт ревьюера по PR. Если reviewerID не указан, вердикт ставится
+// от имени вызывающего пользователя. Участник может ставить вердикт только на свои ревью
+func (pr *PullRequestService) SubmitVerdict(ctx context.Context, prID string, reviewerID uuid.UUID, verdict domain.Verdict) (_ *domain.ReviewVerdict, err error) {
+	ctx, span := startSpan(ctx, "PullRequestService.SubmitVerdict", attribute.String("pr.id", prID))
+	defer func() { endSpan(span, err) }()
+	log := logger.FromContext(ctx, pr.log).With(zap.String("pr_id", prID), zap.String("method", "SubmitVerdict"))
+	if !verdict.Valid() {
+		log.Warn("attempt to submit unknown verdict", zap.String("verdict", string(verdict)))
+		return nil, domain.ErrInvalidVerdict
+	}
+	if reviewerID == uuid.Nil {
+		if principal, ok := auth.PrincipalFromContext(ctx); ok && principal.UserID != nil {
+			reviewerID = *principal.UserID
+		}
+	}
+	if prID == "" || reviewerID == uuid.Nil {
+		log.Warn("attempt to submit verdict without pull request or reviewer")
+		return nil, domain.ErrOneOfParametersNil
+	}
+	if err := authorizeSelf(ctx, reviewerID); err != nil {
+		log.Warn("Caller is not allowed to submit verdict for another reviewer", zap.String("reviewer_id", reviewerID.String()))
+		return nil, err
 	}
 
-	r := rand.New(rand.NewSource(time.Now().UnixNano()))
-	r.Shuffle(len(users), func(i, j int) {
-		users[i], users[j] = users[j], users[i]
-	})
+	pullRequest, err := pr.prRepo.GetPRByID(ctx, prID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			log.Warn("Pull request does not exist")
+			return nil, domain.ErrNotFound
+		}
+		log.Error("Failed to get pull request", zap.Error(err))
+		return nil, fmt.Errorf("failed to get pull request: %w", err)
+	}
+	if pullRequest.Status == domain.StatusMerged {
+		log.Warn("cannot submit verdict on a merged PR")
+		return nil, domain.ErrPRMerged
+	}
 
-	numToAssign := count
-	if len(users) < count {
-		numToAssign = len(users)
+	reviewVerdict := domain.ReviewVerdict{
+		PullRequestID: prID,
+		ReviewerID:    reviewerID,
+		Verdict:       verdict,
+		SubmittedAt:   time.Now().UTC(),
 	}
+	if err := pr.prRepo.SetVerdict(ctx, reviewVerdict); err != nil {
+		if errors.Is(err, domain.ErrUserNotAssigned) {
+			log.Warn("verdict submitted by user who is not a reviewer", zap.String("reviewer_id", reviewerID.String()))
+			return nil, domain.ErrUserNotAssigned
+		}
+		log.Error("Failed to save verdict", zap.Error(err))
+		return nil, fmt.Errorf("failed to save verdict: %w", err)
+	}
+	log.Info("Review verdict submitted", zap.String("reviewer_id", reviewerID.String()), zap.String("verdict", string(verdict)))
+	return &reviewVerdict, nil
+}
 
-	reviewers := make([]uuid.UUID, numToAssign)
-	for i := 0; i < numToAssign; i++ {
-		reviewers[i] = users[i].ID
+// GetPullRequest возвращает PR вместе с ревьюерами и текущей версией
+func (pr *PullRequestService) GetPullRequest(ctx context.Context, prID string) (_ *domain.PullRequest, err error) {
+	ctx, span := startSpan(ctx, "PullRequestService.GetPullRequest", attribute.String("pr.id", prID))
+	defer func() { endSpan(span, err) }()
+	log := logger.FromContext(ctx, pr.log).With(zap.String("pr_id", prID), zap.String("method", "GetPullRequest"))
+	if prID == "" {
+		log.Warn("attempt to get pull request with empty id")
+		return nil, domain.ErrOneOfParametersNil
+	}
+	pullRequest, err := pr.prRepo.GetPRByID(ctx, prID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			log.Warn("Pull request does not exist")
+			return nil, domain.ErrPRNotExist
+		}
+		log.Error("Failed to get pull request", zap.Error(err))
+		return nil, fmt.Errorf("failed to get pull request: %w", err)
 	}
+	return pullRequest, nil
+}
 
-	pr.log.Debug("selected random reviewers", zap.Int("count", len(reviewers)))
-	return reviewers
+// StreamPullRequests передает в fn каждый PR, подходящий под фильтр, по мере чтения из базы
+func (pr *PullRequestService) StreamPullRequests(ctx context.Context, filter domain.PullRequestFilter, fn func(*domain.PullRequest) error) (err error) {
+	ctx, span := startSpan(ctx, "PullRequestService.StreamPullRequests")
+	defer func() { endSpan(span, err) }()
+	log := logger.FromContext(ctx, pr.log).With(zap.String("method", "StreamPullRequests"))
+	if filter.From != nil && filter.To != nil && !filter.To.After(*filter.From) {
+		log.Warn("pull requests requested with empty period", zap.Time("from", *filter.From), zap.Time("to", *filter.To))
+		return domain.ErrInvalidPeriod
+	}
+	if filter.Status != nil && *filter.Status != domain.StatusOpen && *filter.Status != domain.StatusMerged {
+		log.Warn("pull requests requested with unknown status", zap.String("status", string(*filter.Status)))
+		return domain.ErrOneOfParametersNil
+	}
+	return pr.prRepo.StreamPullRequests(ctx, filter, fn)
 }
@@ -0,0 +1,64 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"avito/internal/domain"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// fakePRRepo хранит PR в памяти. Остальные методы не нужны тестам и ничего не делают
+type fakePRRepo struct {
+	prs map[string]*domain.PullRequest
+}
+
+func (r *fakePRRepo) Create(_ context.Context, pr *domain.PullRequest) error {
+	r.prs[pr.ID] = pr
+	return nil
+}
+
+func (r *fakePRRepo) GetPRByID(_ context.Context, id string) (*domain.PullRequest, error) {
+	pr, ok := r.prs[id]
+	if !ok {
+		return nil, domain.ErrNotFound
+	}
+	return pr, nil
+}
+
+func (r *fakePRRepo) ReassignReviewer(context.Context, domain.Reassignment) error {
+	return nil
+}
+
+func (r *fakePRRepo) Exists(_ context.Context, id string) (bool, error) {
+	_, ok := r.prs[id]
+	return ok, nil
+}
+
+func (r *fakePRRepo) SetMerge(context.Context, string, *int64) (bool, error) {
+	return false, nil
+}
+
+func (r *fakePRRepo) SetVerdict(context.Context, domain.ReviewVerdict) error {
+	return nil
+}
+
+func (r *fakePRRepo) StreamPullRequests(context.Context, domain.PullRequestFilter, func(*domain.PullRequest) error) error {
+	return nil
+}
+
+func TestMissingPullRequestIsNotFound(t *testing.T) {
+	srv := NewPullRequestService(&fakePRRepo{prs: map[string]*domain.PullRequest{}}, nil, nil, nil, nil, zap.NewNop())
+
+	_, _, err := srv.ReassignmentReviewers(context.Background(), "pr-missing", uuid.New(), nil)
+	if !errors.Is(err, domain.ErrPRNotExist) {
+		t.Fatalf("reassign: got error %v, want %v", err, domain.ErrPRNotExist)
+	}
+	_, err = srv.SetMerge(context.Background(), "pr-missing", nil)
+	if !errors.Is(err, domain.ErrPRNotExist) {
+		t.Fatalf("merge: got error %v, want %v", err, domain.ErrPRNotExist)
+	}
+}
@@ -0,0 +1,143 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go.uber.org/zap"
+	"time"
+
+	"avito/internal/domain"
+	"avito/pkg/logger"
+
+	"github.com/google/uuid"
+)
+
+type StaleReviewRepository interface {
+	GetStaleReviews(ctx context.Context, defaultSLA, defaultEscalation time.Duration) ([]domain.StaleReview, error)
+	MarkReviewReminded(ctx context.Context, prID string, reviewerID uuid.UUID) error
+}
+
+type EventPublisher interface {
+	Publish(ctx context.Context, event domain.ReviewEvent)
+}
+
+// EscalationThresholds - пороги по умолчанию для команд, у которых SLA не задан
+type EscalationThresholds struct {
+	ReviewSLA  time.Duration
+	Escalation time.Duration
+}
+
+type ReviewEscalationService struct {
+	repo       StaleReviewRepository
+	reassigner ReviewReassigner
+	publisher  EventPublisher
+	thresholds EscalationThresholds
+	log        *zap.Logger
+}
+
+func NewReviewEscalationService(repo StaleReviewRepository, reassigner ReviewReassigner, publisher EventPublisher, thresholds EscalationThresholds, log *zap.Logger) *ReviewEscalationService {
+	return &ReviewEscalationService{
+		repo:       repo,
+		reassigner: reassigner,
+		publisher:  publisher,
+		thresholds: thresholds,
+		log:        log.Named("ReviewEscalationService"),
+	}
+}
+
+// ProcessStaleReviews отправляет напоминания по ревью с истекшим SLA,
+// а ревью с истекшим порогом эскалации переназначает через ReassignmentReviewers.
+// Ошибка одного ревью не прерывает обработку остальных и учитывается в отчете как Failed
+func (s *ReviewEscalationService) ProcessStaleReviews(ctx context.Context) (domain.StaleReviewReport, error) {
+	log := logger.FromContext(ctx, s.log).With(zap.String("method", "ProcessStaleReviews"))
+	var report domain.StaleReviewReport
+
+	reviews, err := s.repo.GetStaleReviews(ctx, s.thresholds.ReviewSLA, s.thresholds.Escalation)
+	if err != nil {
+		log.Error("Failed to get stale reviews", zap.Error(err))
+		return report, fmt.Errorf("failed to get stale reviews: %w", err)
+	}
+	report.Stale = len(reviews)
+
+	for _, review := range reviews {
+		if err := ctx.Err(); err != nil {
+			log.Warn("Stale review processing interrupted", zap.Error(err))
+			return report, err
+		}
+		reviewLog := log.With(zap.String("pr_id", review.PullRequestID), zap.String("reviewer_id", review.ReviewerID.String()))
+
+		if review.Escalate {
+			escalated, err := s.escalate(ctx, review, reviewLog)
+			if err != nil {
+				report.Failed++
+				continue
+			}
+			if escalated {
+				report.Escalated++
+				continue
+			}
+			report.Skipped++
+		}
+
+		if review.RemindedAt != nil {
+			continue
+		}
+		if err := s.remind(ctx, review); err != nil {
+			if errors.Is(err, domain.ErrUserNotAssigned) {
+				reviewLog.Warn("Reviewer was unassigned before reminder")
+				continue
+			}
+			reviewLog.Error("Failed to send review reminder", zap.Error(err))
+			report.Failed++
+			continue
+		}
+		report.Reminded++
+	}
+
+	if report.Failed > 0 {
+		log.Warn("Some stale reviews failed to process", zap.Int("failed", report.Failed), zap.Int("stale", report.Stale))
+	}
+	return report, nil
+}
+
+// escalate переназначает ревью. Возвращает false, если подходящей замены нет
+func (s *ReviewEscalationService) escalate(ctx context.Context, review domain.StaleReview, log *zap.Logger) (bool, error) {
+	_, newReviewer, err := s.reassigner.ReassignmentReviewers(ctx, review.PullRequestID, review.ReviewerID, nil)
+	if err != nil {
+		if errors.Is(err, domain.ErrNoCandidate) || errors.Is(err, domain.ErrUserNotAssigned) || errors.Is(err, domain.ErrPRMerged) {
+			log.Warn("Stale review was not escalated", zap.Error(err))
+			return false, nil
+		}
+		log.Error("Failed to escalate stale review", zap.Error(err))
+		return false, fmt.Errorf("failed to escalate stale review: %w", err)
+	}
+
+	newReviewerID, err := uuid.Parse(newReviewer)
+	if err != nil {
+		log.Error("Failed to parse new reviewer id", zap.String("new_reviewer_id", newReviewer), zap.Error(err))
+		return false, fmt.Errorf("failed to parse new reviewer id: %w", err)
+	}
+	s.publisher.Publish(ctx, domain.ReviewEvent{
+		Type:          domain.EventReviewEscalated,
+		PullRequestID: review.PullRequestID,
+		ReviewerID:    review.ReviewerID,
+		NewReviewerID: &newReviewerID,
+		OccurredAt:    time.Now().UTC(),
+	})
+	log.Info("Stale review escalated", zap.String("new_reviewer_id", newReviewer))
+	return true, nil
+}
+
+func (s *ReviewEscalationService) remind(ctx context.Context, review domain.StaleReview) error {
+	if err := s.repo.MarkReviewReminded(ctx, review.PullRequestID, review.ReviewerID); err != nil {
+		return err
+	}
+	s.publisher.Publish(ctx, domain.ReviewEvent{
+		Type:          domain.EventReviewReminder,
+		PullRequestID: review.PullRequestID,
+		ReviewerID:    review.ReviewerID,
+		OccurredAt:    time.Now().UTC(),
+	})
+	return nil
+}
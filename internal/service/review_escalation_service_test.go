@@ -0,0 +1,111 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"avito/internal/domain"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+var errStorage = errors.New("storage unavailable")
+
+// fakeStaleReviewRepo отдает заданные ревью и не может отметить напоминание по PR из failReminders
+type fakeStaleReviewRepo struct {
+	reviews       []domain.StaleReview
+	failReminders map[string]bool
+	reminded      []string
+}
+
+func (r *fakeStaleReviewRepo) GetStaleReviews(context.Context, time.Duration, time.Duration) ([]domain.StaleReview, error) {
+	return r.reviews, nil
+}
+
+func (r *fakeStaleReviewRepo) MarkReviewReminded(_ context.Context, prID string, _ uuid.UUID) error {
+	if r.failReminders[prID] {
+		return errStorage
+	}
+	r.reminded = append(r.reminded, prID)
+	return nil
+}
+
+// fakeReassigner переназначает ревью на newReviewer, а для PR из errs возвращает заданную ошибку
+type fakeReassigner struct {
+	newReviewer uuid.UUID
+	errs        map[string]error
+}
+
+func (r *fakeReassigner) ReassignmentReviewers(_ context.Context, prID string, _ uuid.UUID, _ *int64) (*domain.PullRequest, string, error) {
+	if err := r.errs[prID]; err != nil {
+		return nil, "", err
+	}
+	return &domain.PullRequest{ID: prID}, r.newReviewer.String(), nil
+}
+
+type recordingPublisher struct {
+	events []domain.ReviewEvent
+}
+
+func (p *recordingPublisher) Publish(_ context.Context, event domain.ReviewEvent) {
+	p.events = append(p.events, event)
+}
+
+func TestProcessStaleReviewsContinuesAfterFailures(t *testing.T) {
+	stale := func(prID string, escalate bool) domain.StaleReview {
+		return domain.StaleReview{PullRequestID: prID, ReviewerID: uuid.New(), TeamName: "backend", Escalate: escalate}
+	}
+	repo := &fakeStaleReviewRepo{
+		reviews: []domain.StaleReview{
+			stale("pr-escalation-fails", true),
+			stale("pr-reminder-fails", false),
+			stale("pr-no-candidate", true),
+			stale("pr-escalated", true),
+			stale("pr-reminded", false),
+		},
+		failReminders: map[string]bool{"pr-reminder-fails": true},
+	}
+	reassigner := &fakeReassigner{
+		newReviewer: uuid.New(),
+		errs: map[string]error{
+			"pr-escalation-fails": errStorage,
+			"pr-no-candidate":     domain.ErrNoCandidate,
+		},
+	}
+	publisher := &recordingPublisher{}
+	srv := NewReviewEscalationService(repo, reassigner, publisher, EscalationThresholds{ReviewSLA: time.Hour, Escalation: 2 * time.Hour}, zap.NewNop())
+
+	report, err := srv.ProcessStaleReviews(context.Background())
+	if err != nil {
+		t.Fatalf("got error %v, want nil", err)
+	}
+	want := domain.StaleReviewReport{Stale: 5, Reminded: 2, Escalated: 1, Skipped: 1, Failed: 2}
+	if report != want {
+		t.Fatalf("got report %+v, want %+v", report, want)
+	}
+	// Ревью без кандидата на замену получает напоминание
+	if len(repo.reminded) != 2 || repo.reminded[0] != "pr-no-candidate" || repo.reminded[1] != "pr-reminded" {
+		t.Fatalf("got reminders for %v, want [pr-no-candidate pr-reminded]", repo.reminded)
+	}
+	if len(publisher.events) != 3 {
+		t.Fatalf("got %d events, want 3", len(publisher.events))
+	}
+}
+
+func TestProcessStaleReviewsStopsOnCancel(t *testing.T) {
+	repo := &fakeStaleReviewRepo{reviews: []domain.StaleReview{{PullRequestID: "pr-1", ReviewerID: uuid.New()}}}
+	srv := NewReviewEscalationService(repo, &fakeReassigner{}, &recordingPublisher{}, EscalationThresholds{}, zap.NewNop())
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	report, err := srv.ProcessStaleReviews(ctx)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("got error %v, want %v", err, context.Canceled)
+	}
+	if report.Reminded != 0 || len(repo.reminded) != 0 {
+		t.Fatalf("reviews were processed after cancel: %+v", report)
+	}
+}
@@ -0,0 +1,163 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"go.uber.org/zap"
+	"math/rand"
+	"sort"
+	"strings"
+	"time"
+
+	"avito/internal/domain"
+	"avito/pkg/logger"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// skillWeight определяет вес совпадения тегов ревьюера с метками PR.
+	skillWeight = 1.0
+	// loadWeight определяет вес штрафа за текущую нагрузку ревьюера.
+	loadWeight = 0.5
+)
+
+// ReviewerSelector выбирает ревьюеров для PR из списка кандидатов
+type ReviewerSelector interface {
+	SelectReviewers(ctx context.Context, candidates []domain.User, labels []string, count int) (domain.ReviewerSelection, error)
+}
+
+type TagProviderForSelector interface {
+	GetTagsByUserIDs(ctx context.Context, userIDs []uuid.UUID) (map[uuid.UUID][]string, error)
+}
+
+type ReviewLoadProvider interface {
+	GetReviewLoads(ctx context.Context, userIDs []uuid.UUID) (map[uuid.UUID]domain.ReviewLoad, error)
+}
+
+// SkillBasedSelector оценивает кандидатов по совпадению их тегов с метками PR
+// и по количеству открытых ревью. Кандидаты с одинаковой оценкой выбираются случайно,
+// кандидаты, достигшие лимита открытых ревью, не выбираются.
+type SkillBasedSelector struct {
+	tagRepo  TagProviderForSelector
+	loadRepo ReviewLoadProvider
+	log      *zap.Logger
+}
+
+func NewSkillBasedSelector(tagRepo TagProviderForSelector, loadRepo ReviewLoadProvider, log *zap.Logger) *SkillBasedSelector {
+	return &SkillBasedSelector{
+		tagRepo:  tagRepo,
+		loadRepo: loadRepo,
+		log:      log.Named("SkillBasedSelector"),
+	}
+}
+
+type scoredCandidate struct {
+	id    uuid.UUID
+	score float64
+}
+
+// SelectReviewers возвращает до count кандидатов с наибольшей оценкой
+func (s *SkillBasedSelector) SelectReviewers(ctx context.Context, candidates []domain.User, labels []string, count int) (domain.ReviewerSelection, error) {
+	log := logger.FromContext(ctx, s.log)
+	if len(candidates) == 0 {
+		log.Warn("no active members available for review assignment")
+		return domain.ReviewerSelection{Reviewers: []uuid.UUID{}}, nil
+	}
+
+	ids := make([]uuid.UUID, 0, len(candidates))
+	for _, candidate := range candidates {
+		ids = append(ids, candidate.ID)
+	}
+
+	tags, err := s.tagRepo.GetTagsByUserIDs(ctx, ids)
+	if err != nil {
+		log.Error("Failed to get candidate tags", zap.Error(err))
+		return domain.ReviewerSelection{}, fmt.Errorf("failed to get candidate tags: %w", err)
+	}
+	loads, err := s.loadRepo.GetReviewLoads(ctx, ids)
+	if err != nil {
+		log.Error("Failed to get candidate review load", zap.Error(err))
+		return domain.ReviewerSelection{}, fmt.Errorf("failed to get candidate review load: %w", err)
+	}
+
+	maxLoad := 0
+	for _, load := range loads {
+		if load.OpenReviews > maxLoad {
+			maxLoad = load.OpenReviews
+		}
+	}
+
+	skipped := 0
+	scored := make([]scoredCandidate, 0, len(candidates))
+	for _, id := range ids {
+		if loads[id].AtCapacity() {
+			skipped++
+			continue
+		}
+		score := skillWeight * skillMatch(tags[id], labels)
+		if maxLoad > 0 {
+			score -= loadWeight * float64(loads[id].OpenReviews) / float64(maxLoad)
+		}
+		scored = append(scored, scoredCandidate{id: id, score: score})
+	}
+
+	// Перемешивание перед стабильной сортировкой делает выбор среди равных кандидатов случайным
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+	r.Shuffle(len(scored), func(i, j int) {
+		scored[i], scored[j] = scored[j], scored[i]
+	})
+	sort.SliceStable(scored, func(i, j int) bool {
+		return scored[i].score > scored[j].score
+	})
+
+	numToAssign := count
+	if len(scored) < count {
+		numToAssign = len(scored)
+	}
+
+	reviewers := make([]uuid.UUID, numToAssign)
+	for i := 0; i < numToAssign; i++ {
+		reviewers[i] = scored[i].id
+	}
+
+	log.Debug("selected reviewers by skill and load",
+		zap.Int("count", len(reviewers)), zap.Int("skipped_at_capacity", skipped), zap.Strings("labels", labels))
+	return domain.ReviewerSelection{Reviewers: reviewers, SkippedAtCapacity: skipped}, nil
+}
+
+// skillMatch возвращает долю меток PR, покрытых тегами кандидата
+func skillMatch(tags []string, labels []string) float64 {
+	if len(labels) == 0 || len(tags) == 0 {
+		return 0
+	}
+	tagSet := make(map[string]struct{}, len(tags))
+	for _, tag := range tags {
+		tagSet[tag] = struct{}{}
+	}
+	matched := 0
+	for _, label := range labels {
+		if _, ok := tagSet[label]; ok {
+			matched++
+		}
+	}
+	return float64(matched) / float64(len(labels))
+}
+
+// normalizeTags приводит теги к нижнему регистру, убирает пробелы и дубликаты
+func normalizeTags(tags []string) ([]string, error) {
+	seen := make(map[string]struct{}, len(tags))
+	normalized := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag == "" {
+			return nil, domain.ErrOneOfParametersNil
+		}
+		if _, ok := seen[tag]; ok {
+			continue
+		}
+		seen[tag] = struct{}{}
+		normalized = append(normalized, tag)
+	}
+	return normalized, nil
+}
@@ -0,0 +1,127 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"avito/internal/domain"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+func TestNormalizeTags(t *testing.T) {
+	tests := []struct {
+		name    string
+		tags    []string
+		want    []string
+		wantErr error
+	}{
+		{"nil", nil, []string{}, nil},
+		{"lower case and trim", []string{" Go ", "SQL"}, []string{"go", "sql"}, nil},
+		{"duplicates keep first position", []string{"go", "sql", "GO", " sql"}, []string{"go", "sql"}, nil},
+		{"empty tag", []string{"go", "   "}, nil, domain.ErrOneOfParametersNil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := normalizeTags(tt.tags)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("got error %v, want %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSkillMatch(t *testing.T) {
+	tests := []struct {
+		name   string
+		tags   []string
+		labels []string
+		want   float64
+	}{
+		{"no labels", []string{"go"}, nil, 0},
+		{"no tags", nil, []string{"go"}, 0},
+		{"all labels covered", []string{"go", "sql", "k8s"}, []string{"go", "sql"}, 1},
+		{"half of labels covered", []string{"go"}, []string{"go", "sql"}, 0.5},
+		{"no overlap", []string{"frontend"}, []string{"go", "sql"}, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := skillMatch(tt.tags, tt.labels); got != tt.want {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+type fakeSelectorData struct {
+	tags  map[uuid.UUID][]string
+	loads map[uuid.UUID]domain.ReviewLoad
+}
+
+func (f fakeSelectorData) GetTagsByUserIDs(context.Context, []uuid.UUID) (map[uuid.UUID][]string, error) {
+	return f.tags, nil
+}
+
+func (f fakeSelectorData) GetReviewLoads(context.Context, []uuid.UUID) (map[uuid.UUID]domain.ReviewLoad, error) {
+	return f.loads, nil
+}
+
+func TestSelectReviewersScoring(t *testing.T) {
+	expert, partial, newcomer := uuid.New(), uuid.New(), uuid.New()
+	capacity := 2
+	candidates := []domain.User{{ID: newcomer}, {ID: partial}, {ID: expert}}
+	tags := map[uuid.UUID][]string{
+		expert:  {"go", "sql", "k8s"},
+		partial: {"go", "sql"},
+	}
+	labels := []string{"go", "sql", "k8s"}
+
+	tests := []struct {
+		name        string
+		loads       map[uuid.UUID]domain.ReviewLoad
+		want        []uuid.UUID
+		wantSkipped int
+	}{
+		{
+			name: "skill match without load",
+			want: []uuid.UUID{expert, partial, newcomer},
+		},
+		{
+			// expert: 1 - 0.5*2/4 = 0.75, partial: 2/3 - 0.5*4/4 = 1/6
+			name:  "load lowers the score",
+			loads: map[uuid.UUID]domain.ReviewLoad{expert: {OpenReviews: 2}, partial: {OpenReviews: 4}},
+			want:  []uuid.UUID{expert, partial, newcomer},
+		},
+		{
+			// expert: 1 - 0.5*4/4 = 0.5 уступает partial с 2/3
+			name:  "load outweighs a small skill advantage",
+			loads: map[uuid.UUID]domain.ReviewLoad{expert: {OpenReviews: 4}},
+			want:  []uuid.UUID{partial, expert, newcomer},
+		},
+		{
+			name:        "candidates at capacity are skipped",
+			loads:       map[uuid.UUID]domain.ReviewLoad{expert: {OpenReviews: 2, Capacity: &capacity}},
+			want:        []uuid.UUID{partial, newcomer},
+			wantSkipped: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := fakeSelectorData{tags: tags, loads: tt.loads}
+			selector := NewSkillBasedSelector(data, data, zap.NewNop())
+			selection, err := selector.SelectReviewers(context.Background(), candidates, labels, len(candidates))
+			if err != nil {
+				t.Fatalf("got error %v", err)
+			}
+			if !reflect.DeepEqual(selection.Reviewers, tt.want) || selection.SkippedAtCapacity != tt.wantSkipped {
+				t.Fatalf("got %v skipped %d, want %v skipped %d", selection.Reviewers, selection.SkippedAtCapacity, tt.want, tt.wantSkipped)
+			}
+		})
+	}
+}
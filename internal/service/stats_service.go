@@ -4,36 +4,276 @@ import (
 	"context"
 	"fmt"
 	"go.uber.org/zap"
+	"math"
+	"sort"
+	"time"
 
 	"avito/internal/domain"
+	"avito/pkg/logger"
+
+	"github.com/google/uuid"
 )
 
 type StatsRepository interface {
-	GetUserReviewStats(ctx context.Context) ([]*domain.UserReviewStat, error)
+	GetUserReviewStats(ctx context.Context, filter domain.StatsFilter) ([]*domain.UserReviewStat, error)
+	StreamUserReviewStats(ctx context.Context, filter domain.StatsFilter, fn func(*domain.UserReviewStat) error) error
+	GetTeamReviewStats(ctx context.Context, filter domain.StatsFilter) ([]*domain.TeamReviewStat, error)
+	GetLatency(ctx context.Context, filter domain.LatencyFilter) ([]*domain.LatencySeries, error)
+	GetMemberAssignments(ctx context.Context, filter domain.FairnessFilter) ([]*domain.MemberAssignments, error)
+	GetTeamUnavailability(ctx context.Context, filter domain.FairnessFilter) ([]domain.Unavailability, error)
+}
+
+// defaultFairnessWindow - период отчета о равномерности, если границы не заданы
+const defaultFairnessWindow = 30 * 24 * time.Hour
+
+type TeamCheckerForStats interface {
+	ExistsTeam(ctx context.Context, name string) (bool, error)
 }
 
 type StatsService struct {
 	statsRepo StatsRepository
+	teamRepo  TeamCheckerForStats
 	log       *zap.Logger
 }
 
-func NewStatsService(statsRepo StatsRepository, log *zap.Logger) *StatsService {
+func NewStatsService(statsRepo StatsRepository, teamRepo TeamCheckerForStats, log *zap.Logger) *StatsService {
 	return &StatsService{
 		statsRepo: statsRepo,
+		teamRepo:  teamRepo,
 		log:       log.Named("StatsService"),
 	}
 }
 
-// GetUserReviewStats возвращает статистику по ревью для всех пользователей.
-func (s *StatsService) GetUserReviewStats(ctx context.Context) ([]*domain.UserReviewStat, error) {
+// GetUserReviewStats возвращает статистику по ревью для пользователей, подходящих под фильтр.
+func (s *StatsService) GetUserReviewStats(ctx context.Context, filter domain.StatsFilter) ([]*domain.UserReviewStat, error) {
+	log := logger.FromContext(ctx, s.log)
+	log.Info("Fetching user review stats")
 
-	s.log.Info("Fetching user review stats")
+	if err := s.validateFilter(ctx, filter); err != nil {
+		return nil, err
+	}
 
-	stats, err := s.statsRepo.GetUserReviewStats(ctx)
+	stats, err := s.statsRepo.GetUserReviewStats(ctx, filter)
 	if err != nil {
-		s.log.Error("Failed to get user review stats from repository", zap.Error(err))
+		log.Error("Failed to get user review stats from repository", zap.Error(err))
 		return nil, fmt.Errorf("failed to get stats from repo: %w", err)
 	}
 
 	return stats, nil
 }
+
+// StreamUserReviewStats передает в fn статистику каждого пользователя по мере чтения из базы.
+// Используется для выгрузки больших таблиц без накопления результата в памяти.
+func (s *StatsService) StreamUserReviewStats(ctx context.Context, filter domain.StatsFilter, fn func(*domain.UserReviewStat) error) error {
+	log := logger.FromContext(ctx, s.log)
+	log.Info("Streaming user review stats")
+
+	if err := s.validateFilter(ctx, filter); err != nil {
+		return err
+	}
+
+	return s.statsRepo.StreamUserReviewStats(ctx, filter, fn)
+}
+
+// GetTeamReviewStats возвращает агрегаты по PR для команд, подходящих под фильтр.
+func (s *StatsService) GetTeamReviewStats(ctx context.Context, filter domain.StatsFilter) ([]*domain.TeamReviewStat, error) {
+	log := logger.FromContext(ctx, s.log)
+	log.Info("Fetching team review stats")
+
+	if err := s.validateFilter(ctx, filter); err != nil {
+		return nil, err
+	}
+
+	stats, err := s.statsRepo.GetTeamReviewStats(ctx, filter)
+	if err != nil {
+		log.Error("Failed to get team review stats from repository", zap.Error(err))
+		return nil, fmt.Errorf("failed to get team stats from repo: %w", err)
+	}
+
+	return stats, nil
+}
+
+// GetLatency возвращает перцентили времени от создания до мержа PR по командам или авторам
+// либо времени от назначения до вердикта по ревьюерам. По умолчанию группирует по командам.
+func (s *StatsService) GetLatency(ctx context.Context, filter domain.LatencyFilter) ([]*domain.LatencySeries, error) {
+	log := logger.FromContext(ctx, s.log)
+	log.Info("Fetching latency stats")
+
+	if filter.GroupBy == "" {
+		filter.GroupBy = domain.LatencyByTeam
+	}
+	if filter.GroupBy != domain.LatencyByTeam && filter.GroupBy != domain.LatencyByAuthor && filter.GroupBy != domain.LatencyByReviewer {
+		log.Warn("latency requested with unknown grouping", zap.String("group_by", string(filter.GroupBy)))
+		return nil, domain.ErrInvalidGrouping
+	}
+	if filter.Bucket != "" && filter.Bucket != domain.BucketDay && filter.Bucket != domain.BucketWeek {
+		log.Warn("latency requested with unknown bucket", zap.String("bucket", string(filter.Bucket)))
+		return nil, domain.ErrInvalidGrouping
+	}
+	if err := s.validateFilter(ctx, domain.StatsFilter{From: filter.From, To: filter.To, TeamName: filter.TeamName}); err != nil {
+		return nil, err
+	}
+
+	series, err := s.statsRepo.GetLatency(ctx, filter)
+	if err != nil {
+		log.Error("Failed to get latency from repository", zap.Error(err))
+		return nil, fmt.Errorf("failed to get latency from repo: %w", err)
+	}
+
+	return series, nil
+}
+
+// GetFairness строит отчет о равномерности назначений по командам за период.
+// Ожидаемая доля участника пропорциональна его активному времени: неактивные пользователи
+// и периоды недоступности не учитываются. Если границы не заданы, берутся последние 30 дней.
+func (s *StatsService) GetFairness(ctx context.Context, filter domain.FairnessFilter) ([]*domain.TeamFairness, error) {
+	log := logger.FromContext(ctx, s.log)
+	log.Info("Fetching assignment fairness report")
+
+	if filter.To.IsZero() {
+		filter.To = time.Now().UTC()
+	}
+	if filter.From.IsZero() {
+		filter.From = filter.To.Add(-defaultFairnessWindow)
+	}
+	if err := s.validateFilter(ctx, domain.StatsFilter{From: &filter.From, To: &filter.To, TeamName: filter.TeamName}); err != nil {
+		return nil, err
+	}
+
+	members, err := s.statsRepo.GetMemberAssignments(ctx, filter)
+	if err != nil {
+		log.Error("Failed to get member assignments from repository", zap.Error(err))
+		return nil, fmt.Errorf("failed to get member assignments from repo: %w", err)
+	}
+	periods, err := s.statsRepo.GetTeamUnavailability(ctx, filter)
+	if err != nil {
+		log.Error("Failed to get team unavailability from repository", zap.Error(err))
+		return nil, fmt.Errorf("failed to get team unavailability from repo: %w", err)
+	}
+
+	unavailable := unavailableDurations(periods)
+	window := filter.To.Sub(filter.From)
+
+	var reports []*domain.TeamFairness
+	byTeam := make(map[string]*domain.TeamFairness)
+	for _, m := range members {
+		report, ok := byTeam[m.TeamName]
+		if !ok {
+			report = &domain.TeamFairness{TeamName: m.TeamName}
+			byTeam[m.TeamName] = report
+			reports = append(reports, report)
+		}
+		var active time.Duration
+		if m.IsActive {
+			active = window - unavailable[m.UserID]
+		}
+		report.TotalAssignments += m.Assignments
+		report.Members = append(report.Members, domain.MemberFairness{
+			UserID:      m.UserID,
+			Username:    m.Username,
+			IsActive:    m.IsActive,
+			Assignments: m.Assignments,
+			ActiveDays:  active.Hours() / 24,
+		})
+	}
+
+	for _, report := range reports {
+		fillFairness(report)
+	}
+	return reports, nil
+}
+
+// fillFairness считает доли участников, отклонения от ожидаемой доли и коэффициент Джини команды
+func fillFairness(report *domain.TeamFairness) {
+	totalActiveDays := 0.0
+	for _, m := range report.Members {
+		totalActiveDays += m.ActiveDays
+	}
+
+	rates := make([]float64, 0, len(report.Members))
+	for i := range report.Members {
+		m := &report.Members[i]
+		if report.TotalAssignments > 0 {
+			m.Share = float64(m.Assignments) / float64(report.TotalAssignments)
+		}
+		if totalActiveDays > 0 {
+			m.ExpectedShare = m.ActiveDays / totalActiveDays
+		}
+		m.Deviation = m.Share - m.ExpectedShare
+		if m.ActiveDays > 0 {
+			rates = append(rates, float64(m.Assignments)/m.ActiveDays)
+		}
+	}
+	report.Gini = gini(rates)
+}
+
+// gini возвращает коэффициент Джини для неотрицательных значений
+func gini(values []float64) float64 {
+	n := len(values)
+	if n == 0 {
+		return 0
+	}
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+
+	// G = sum((2i - n - 1) * x_i) / (n * sum(x_i)) для i от 1 до n по возрастанию x
+	var weighted, total float64
+	for i, v := range sorted {
+		weighted += float64(2*(i+1)-n-1) * v
+		total += v
+	}
+	if total == 0 {
+		return 0
+	}
+	return math.Max(0, weighted/(float64(n)*total))
+}
+
+// unavailableDurations суммирует для каждого пользователя длительность периодов недоступности,
+// склеивая пересекающиеся периоды. Периоды должны быть отсортированы по пользователю и началу
+func unavailableDurations(periods []domain.Unavailability) map[uuid.UUID]time.Duration {
+	result := make(map[uuid.UUID]time.Duration)
+	var current domain.Unavailability
+	flush := func() {
+		if current.UserID != uuid.Nil {
+			result[current.UserID] += current.EndsAt.Sub(current.StartsAt)
+		}
+	}
+	for _, period := range periods {
+		if period.UserID == current.UserID && !period.StartsAt.After(current.EndsAt) {
+			if period.EndsAt.After(current.EndsAt) {
+				current.EndsAt = period.EndsAt
+			}
+			continue
+		}
+		flush()
+		current = period
+	}
+	flush()
+	return result
+}
+
+// validateFilter проверяет границы периода и существование команды из фильтра
+func (s *StatsService) validateFilter(ctx context.Context, filter domain.StatsFilter) error {
+	log := logger.FromContext(ctx, s.log)
+	if filter.From != nil && filter.To != nil && !filter.To.After(*filter.From) {
+		log.Warn("stats requested with empty period", zap.Time("from", *filter.From), zap.Time("to", *filter.To))
+		return domain.ErrInvalidPeriod
+	}
+	if filter.Status != nil && *filter.Status != domain.StatusOpen && *filter.Status != domain.StatusMerged {
+		log.Warn("stats requested with unknown status", zap.String("status", string(*filter.Status)))
+		return domain.ErrOneOfParametersNil
+	}
+	if filter.TeamName == "" {
+		return nil
+	}
+	exists, err := s.teamRepo.ExistsTeam(ctx, filter.TeamName)
+	if err != nil {
+		log.Error("Failed to check team existence", zap.String("team_name", filter.TeamName), zap.Error(err))
+		return fmt.Errorf("failed to check team existence: %w", err)
+	}
+	if !exists {
+		log.Warn("stats requested for unknown team", zap.String("team_name", filter.TeamName))
+		return domain.ErrNotFound
+	}
+	return nil
+}
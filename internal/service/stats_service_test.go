@@ -0,0 +1,89 @@
+package service
+
+import (
+	"math"
+	"reflect"
+	"testing"
+	"time"
+
+	"avito/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+func TestGini(t *testing.T) {
+	tests := []struct {
+		name   string
+		values []float64
+		want   float64
+	}{
+		{"empty", nil, 0},
+		{"all zero", []float64{0, 0, 0}, 0},
+		{"equal", []float64{5, 5, 5, 5}, 0},
+		{"two members", []float64{1, 3}, 0.25},
+		{"unsorted input", []float64{4, 1, 3, 2}, 0.25},
+		{"one member takes everything", []float64{0, 0, 0, 8}, 0.75},
+		{"single member", []float64{7}, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			values := append([]float64(nil), tt.values...)
+			if got := gini(values); math.Abs(got-tt.want) > 1e-9 {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+			if !reflect.DeepEqual(values, tt.values) {
+				t.Fatalf("input was modified: %v", values)
+			}
+		})
+	}
+}
+
+func TestUnavailableDurations(t *testing.T) {
+	alice, bob := uuid.New(), uuid.New()
+	base := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	period := func(user uuid.UUID, startDay, endDay int) domain.Unavailability {
+		return domain.Unavailability{UserID: user, StartsAt: base.AddDate(0, 0, startDay), EndsAt: base.AddDate(0, 0, endDay)}
+	}
+	day := 24 * time.Hour
+
+	tests := []struct {
+		name    string
+		periods []domain.Unavailability
+		want    map[uuid.UUID]time.Duration
+	}{
+		{"no periods", nil, map[uuid.UUID]time.Duration{}},
+		{"single period", []domain.Unavailability{period(alice, 0, 3)}, map[uuid.UUID]time.Duration{alice: 3 * day}},
+		{
+			"disjoint periods are summed",
+			[]domain.Unavailability{period(alice, 0, 2), period(alice, 5, 6)},
+			map[uuid.UUID]time.Duration{alice: 3 * day},
+		},
+		{
+			"overlapping periods are merged",
+			[]domain.Unavailability{period(alice, 0, 4), period(alice, 2, 6)},
+			map[uuid.UUID]time.Duration{alice: 6 * day},
+		},
+		{
+			"nested period is counted once",
+			[]domain.Unavailability{period(alice, 0, 10), period(alice, 2, 3), period(alice, 4, 5)},
+			map[uuid.UUID]time.Duration{alice: 10 * day},
+		},
+		{
+			"adjacent periods are merged",
+			[]domain.Unavailability{period(alice, 0, 2), period(alice, 2, 4)},
+			map[uuid.UUID]time.Duration{alice: 4 * day},
+		},
+		{
+			"periods of different users are not merged",
+			[]domain.Unavailability{period(alice, 0, 4), period(bob, 1, 3), period(bob, 2, 5)},
+			map[uuid.UUID]time.Duration{alice: 4 * day, bob: 4 * day},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := unavailableDurations(tt.periods); !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"go.uber.org/zap"
 
 	"avito/internal/domain"
+	"avito/pkg/logger"
 )
 
 type TeamStore interface {
@@ -14,6 +15,8 @@ type TeamStore interface {
 	GetTeamByName(ctx context.Context, name string) (*domain.Team, error)
 	ExistsTeam(ctx context.Context, name string) (bool, error)
 	CreateTeamWithMembersTx(ctx context.Context, team domain.Team) error
+	SetDefaultReviewCapacity(ctx context.Context, name string, capacity *int, expectedVersion *int64) error
+	SetReviewSLA(ctx context.Context, name string, slaMinutes, escalationMinutes *int, expectedVersion *int64) error
 }
 
 type UserRepositoryForTeamService interface {
@@ -36,55 +39,164 @@ func NewTeamService(repo TeamStore, userRepo UserRepositoryForTeamService, log *
 
 // CreateTeamWithMembers создает команду с ее членами
 func (ts *TeamService) CreateTeamWithMembers(ctx context.Context, team domain.Team) (*domain.Team, error) {
+	log := logger.FromContext(ctx, ts.log)
 	// TODO: сделать добавление/ изменение участников
+	if err := authorizeAdmin(ctx); err != nil {
+		log.Warn("Caller is not allowed to create teams", zap.String("name", team.Name))
+		return nil, err
+	}
 	if team.Name == "" {
-		ts.log.Warn("attempt to create team with empty name")
+		log.Warn("attempt to create team with empty name")
 		return nil, domain.ErrOneOfParametersNil
 	}
 	if len(team.Members) == 0 {
-		ts.log.Warn("attempt to create team with empty members")
+		log.Warn("attempt to create team with empty members")
 		return nil, domain.ErrOneOfParametersNil
 	}
+	if !validCapacity(team.DefaultReviewCapacity) {
+		log.Warn("attempt to create team with negative review capacity", zap.String("name", team.Name))
+		return nil, domain.ErrInvalidCapacity
+	}
+	if !validSLA(team.ReviewSLAMinutes, team.EscalationMinutes) {
+		log.Warn("attempt to create team with invalid review SLA", zap.String("name", team.Name))
+		return nil, domain.ErrInvalidSLA
+	}
+	for _, member := range team.Members {
+		if !validCapacity(member.ReviewCapacity) {
+			log.Warn("attempt to create team member with negative review capacity", zap.String("user_id", member.ID.String()))
+			return nil, domain.ErrInvalidCapacity
+		}
+		if member.Role != "" && !member.Role.Valid() {
+			log.Warn("attempt to create team member with unknown role", zap.String("user_id", member.ID.String()), zap.String("role", string(member.Role)))
+			return nil, domain.ErrInvalidRole
+		}
+	}
 	exists, err := ts.teamRepo.ExistsTeam(ctx, team.Name)
 	if err != nil {
-		ts.log.Error("Failed to check if team exists", zap.String("name", team.Name), zap.Error(err))
+		log.Error("Failed to check if team exists", zap.String("name", team.Name), zap.Error(err))
 		return nil, fmt.Errorf("failed to check if team exists: %w", err)
 	}
 	if exists {
-		ts.log.Warn("Team already exists", zap.String("name", team.Name))
+		log.Warn("Team already exists", zap.String("name", team.Name))
 		return nil, domain.ErrTeamExists
 	}
 
 	if err := ts.teamRepo.CreateTeamWithMembersTx(ctx, team); err != nil {
-		ts.log.Error("Failed to create team", zap.String("name", team.Name), zap.Error(err))
+		log.Error("Failed to create team", zap.String("name", team.Name), zap.Error(err))
 		return nil, fmt.Errorf("failed to create team: %w", err)
 	}
-	ts.log.Info("Team with members created", zap.String("name", team.Name), zap.Int("members", len(team.Members)))
+	log.Info("Team with members created", zap.String("name", team.Name), zap.Int("members", len(team.Members)))
+	team.Version = domain.InitialVersion
 	return &team, nil
 }
 
 func (ts *TeamService) GetTeamByName(ctx context.Context, name string) (*domain.Team, error) {
+	log := logger.FromContext(ctx, ts.log)
 	team, err := ts.teamRepo.GetTeamByName(ctx, name)
 	if err != nil {
 		if errors.Is(err, domain.ErrNotFound) {
-			ts.log.Warn("team not found", zap.String("name", name))
+			log.Warn("team not found", zap.String("name", name))
 			return nil, domain.ErrNotFound
 		}
-		ts.log.Error("failed to retrieve team", zap.String("name", name), zap.Error(err))
+		log.Error("failed to retrieve team", zap.String("name", name), zap.Error(err))
 		return nil, fmt.Errorf("failed to retrieve team: %w", err)
 	}
 	return team, nil
 }
 
 func (ts *TeamService) ExistsTeam(ctx context.Context, name string) (bool, error) {
+	log := logger.FromContext(ctx, ts.log)
 	if name == "" {
-		ts.log.Warn("name is null", zap.String("name", name))
+		log.Warn("name is null", zap.String("name", name))
 		return false, domain.ErrOneOfParametersNil
 	}
 	exists, err := ts.teamRepo.ExistsTeam(ctx, name)
 	if err != nil {
-		ts.log.Error("failed to check if team exists", zap.String("name", name), zap.Error(err))
+		log.Error("failed to check if team exists", zap.String("name", name), zap.Error(err))
 		return false, fmt.Errorf("failed to check if team exists: %w", err)
 	}
 	return exists, nil
 }
+
+// SetDefaultReviewCapacity устанавливает лимит открытых ревью по умолчанию для участников команды.
+// Если expectedVersion задан, изменение применяется только к команде этой версии
+func (ts *TeamService) SetDefaultReviewCapacity(ctx context.Context, name string, capacity *int, expectedVersion *int64) (*domain.Team, error) {
+	log := logger.FromContext(ctx, ts.log)
+	if name == "" {
+		log.Warn("attempt to set review capacity for team with empty name")
+		return nil, domain.ErrOneOfParametersNil
+	}
+	if err := authorizeTeamLead(ctx, name); err != nil {
+		log.Warn("Caller is not allowed to change team review capacity", zap.String("name", name))
+		return nil, err
+	}
+	if !validCapacity(capacity) {
+		log.Warn("attempt to set negative team review capacity", zap.String("name", name))
+		return nil, domain.ErrInvalidCapacity
+	}
+	if err := ts.teamRepo.SetDefaultReviewCapacity(ctx, name, capacity, expectedVersion); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			log.Warn("team not found", zap.String("name", name))
+			return nil, domain.ErrNotFound
+		}
+		if errors.Is(err, domain.ErrVersionConflict) {
+			log.Warn("team was modified concurrently", zap.String("name", name), zap.Int64p("expected_version", expectedVersion))
+			return nil, domain.ErrVersionConflict
+		}
+		log.Error("failed to set team review capacity", zap.String("name", name), zap.Error(err))
+		return nil, fmt.Errorf("failed to set team review capacity: %w", err)
+	}
+	return ts.GetTeamByName(ctx, name)
+}
+
+// SetReviewSLA устанавливает пороги напоминания и переназначения ревью для команды.
+// nil означает значение по умолчанию из конфигурации. Если expectedVersion задан, изменение применяется
+// только к команде этой версии
+func (ts *TeamService) SetReviewSLA(ctx context.Context, name string, slaMinutes, escalationMinutes *int, expectedVersion *int64) (*domain.Team, error) {
+	log := logger.FromContext(ctx, ts.log)
+	if name == "" {
+		log.Warn("attempt to set review SLA for team with empty name")
+		return nil, domain.ErrOneOfParametersNil
+	}
+	if err := authorizeTeamLead(ctx, name); err != nil {
+		log.Warn("Caller is not allowed to change team review SLA", zap.String("name", name))
+		return nil, err
+	}
+	if !validSLA(slaMinutes, escalationMinutes) {
+		log.Warn("attempt to set invalid team review SLA", zap.String("name", name),
+			zap.Intp("review_sla_minutes", slaMinutes), zap.Intp("escalation_minutes", escalationMinutes))
+		return nil, domain.ErrInvalidSLA
+	}
+	if err := ts.teamRepo.SetReviewSLA(ctx, name, slaMinutes, escalationMinutes, expectedVersion); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			log.Warn("team not found", zap.String("name", name))
+			return nil, domain.ErrNotFound
+		}
+		if errors.Is(err, domain.ErrVersionConflict) {
+			log.Warn("team was modified concurrently", zap.String("name", name), zap.Int64p("expected_version", expectedVersion))
+			return nil, domain.ErrVersionConflict
+		}
+		log.Error("failed to set team review SLA", zap.String("name", name), zap.Error(err))
+		return nil, fmt.Errorf("failed to set team review SLA: %w", err)
+	}
+	return ts.GetTeamByName(ctx, name)
+}
+
+// validSLA проверяет, что пороги положительны и напоминание наступает раньше эскалации
+func validSLA(slaMinutes, escalationMinutes *int) bool {
+	if slaMinutes != nil && *slaMinutes <= 0 {
+		return false
+	}
+	if escalationMinutes != nil && *escalationMinutes <= 0 {
+		return false
+	}
+	if slaMinutes != nil && escalationMinutes != nil && *escalationMinutes <= *slaMinutes {
+		return false
+	}
+	return true
+}
+
+// validCapacity проверяет лимит открытых ревью: nil (без лимита) или неотрицательное число
+func validCapacity(capacity *int) bool {
+	return capacity == nil || *capacity >= 0
+}
@@ -0,0 +1,106 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go.uber.org/zap"
+	"strings"
+
+	"avito/internal/domain"
+	"avito/pkg/jwtauth"
+	"avito/pkg/logger"
+
+	"github.com/google/uuid"
+)
+
+type TokenVerifier interface {
+	Verify(token string) (jwtauth.Claims, error)
+}
+
+type UserProviderForAuth interface {
+	GetUserByID(ctx context.Context, id uuid.UUID) (*domain.User, error)
+}
+
+// TokenClaims - имена утверждений JWT, из которых берутся пользователь и права
+type TokenClaims struct {
+	// UserClaim содержит users.id вызывающего
+	UserClaim string
+	// ScopeClaim содержит права: строку через пробел (scope) или массив строк (scp)
+	ScopeClaim string
+}
+
+type TokenService struct {
+	verifier TokenVerifier
+	users    UserProviderForAuth
+	claims   TokenClaims
+	log      *zap.Logger
+}
+
+func NewTokenService(verifier TokenVerifier, users UserProviderForAuth, claims TokenClaims, log *zap.Logger) *TokenService {
+	return &TokenService{
+		verifier: verifier,
+		users:    users,
+		claims:   claims,
+		log:      log.Named("TokenService"),
+	}
+}
+
+// AuthenticateToken проверяет JWT и сопоставляет его с пользователем сервиса.
+// Непроверенный токен или неизвестный пользователь дают domain.ErrUnauthorized
+func (s *TokenService) AuthenticateToken(ctx context.Context, token string) (*domain.Principal, error) {
+	log := logger.FromContext(ctx, s.log).With(zap.String("method", "AuthenticateToken"))
+	claims, err := s.verifier.Verify(token)
+	if err != nil {
+		log.Debug("Token verification failed", zap.Error(err))
+		return nil, domain.ErrUnauthorized
+	}
+
+	userID, err := uuid.Parse(claims.String(s.claims.UserClaim))
+	if err != nil {
+		log.Debug("Token user claim is not a user ID", zap.String("claim", s.claims.UserClaim))
+		return nil, domain.ErrUnauthorized
+	}
+	user, err := s.users.GetUserByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			log.Debug("Token subject is not a known user", zap.String("user_id", userID.String()))
+			return nil, domain.ErrUnauthorized
+		}
+		log.Error("Failed to get token user", zap.String("user_id", userID.String()), zap.Error(err))
+		return nil, fmt.Errorf("failed to get token user: %w", err)
+	}
+
+	return &domain.Principal{
+		Type:     domain.PrincipalUser,
+		ID:       user.ID.String(),
+		Name:     user.Username,
+		Scopes:   tokenScopes(claims[s.claims.ScopeClaim]),
+		UserID:   &user.ID,
+		Role:     user.Role,
+		TeamName: user.TeamName,
+	}, nil
+}
+
+// tokenScopes разбирает права из строки через пробел или массива строк, неизвестные права пропускаются
+func tokenScopes(value any) []domain.Scope {
+	var raw []string
+	switch v := value.(type) {
+	case string:
+		raw = strings.Fields(v)
+	case []any:
+		for _, item := range v {
+			if str, ok := item.(string); ok {
+				raw = append(raw, str)
+			}
+		}
+	}
+
+	scopes := make([]domain.Scope, 0, len(raw))
+	for _, item := range raw {
+		if scope := domain.Scope(item); scope.Valid() {
+			scopes = append(scopes, scope)
+		}
+	}
+	return scopes
+}
@@ -0,0 +1,106 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"avito/internal/domain"
+	"avito/pkg/jwtauth"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// fakeVerifier принимает только токен "valid" и возвращает для него заданные утверждения
+type fakeVerifier struct {
+	claims jwtauth.Claims
+}
+
+func (v fakeVerifier) Verify(token string) (jwtauth.Claims, error) {
+	if token != "valid" {
+		return nil, jwtauth.ErrInvalidToken
+	}
+	return v.claims, nil
+}
+
+// failingUserProvider имитирует недоступное хранилище пользователей
+type failingUserProvider struct{}
+
+func (failingUserProvider) GetUserByID(context.Context, uuid.UUID) (*domain.User, error) {
+	return nil, errStorage
+}
+
+func TestAuthenticateToken(t *testing.T) {
+	lead := domain.User{ID: uuid.New(), Username: "lead", TeamName: "backend", Role: domain.RoleTeamLead}
+	users := &fakeUserRepo{users: map[uuid.UUID]domain.User{lead.ID: lead}}
+	claimNames := TokenClaims{UserClaim: "sub", ScopeClaim: "scope"}
+
+	tests := []struct {
+		name    string
+		token   string
+		claims  jwtauth.Claims
+		users   UserProviderForAuth
+		want    *domain.Principal
+		wantErr error
+	}{
+		{
+			name:   "known user",
+			token:  "valid",
+			claims: jwtauth.Claims{"sub": lead.ID.String(), "scope": "stats:read unknown:scope users:write"},
+			users:  users,
+			want: &domain.Principal{
+				Type: domain.PrincipalUser, ID: lead.ID.String(), Name: "lead", UserID: &lead.ID,
+				Role: domain.RoleTeamLead, TeamName: "backend", Scopes: []domain.Scope{domain.ScopeStatsRead, domain.ScopeUsersWrite},
+			},
+		},
+		{
+			name:    "invalid token",
+			token:   "forged",
+			users:   users,
+			wantErr: domain.ErrUnauthorized,
+		},
+		{
+			name:    "subject is not a UUID",
+			token:   "valid",
+			claims:  jwtauth.Claims{"sub": "auth0|12345"},
+			users:   users,
+			wantErr: domain.ErrUnauthorized,
+		},
+		{
+			name:    "unknown user",
+			token:   "valid",
+			claims:  jwtauth.Claims{"sub": uuid.NewString()},
+			users:   users,
+			wantErr: domain.ErrUnauthorized,
+		},
+		{
+			name:    "storage failure is not reported as unauthorized",
+			token:   "valid",
+			claims:  jwtauth.Claims{"sub": lead.ID.String()},
+			users:   failingUserProvider{},
+			wantErr: errStorage,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := NewTokenService(fakeVerifier{claims: tt.claims}, tt.users, claimNames, zap.NewNop())
+			got, err := srv.AuthenticateToken(context.Background(), tt.token)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("got error %v, want %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTokenScopesFromArray(t *testing.T) {
+	got := tokenScopes([]any{"stats:read", 42, "users:write"})
+	want := []domain.Scope{domain.ScopeStatsRead, domain.ScopeUsersWrite}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,28 @@
+package service
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var tracer = otel.Tracer("avito/internal/service")
+
+// startSpan начинает спан метода сервиса. Провайдер берется глобальный, поэтому
+// при выключенной трассировке спаны ничего не стоят
+func startSpan(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return tracer.Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// endSpan завершает спан метода сервиса и отмечает в нем ошибку, с которой метод завершился.
+// Вызывается через defer с именованным результатом err
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
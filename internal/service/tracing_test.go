@@ -0,0 +1,42 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"avito/internal/domain"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.uber.org/zap"
+)
+
+func TestServiceSpanRecordsError(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	srv := NewPullRequestService(&fakePRRepo{prs: map[string]*domain.PullRequest{"pr-1": {ID: "pr-1"}}}, nil, nil, nil, nil, zap.NewNop())
+
+	if _, err := srv.GetPullRequest(context.Background(), "pr-1"); err != nil {
+		t.Fatalf("got error %v, want nil", err)
+	}
+	if _, err := srv.GetPullRequest(context.Background(), "pr-missing"); err == nil {
+		t.Fatal("got nil error for a missing pull request")
+	}
+
+	spans := recorder.Ended()
+	if len(spans) != 2 {
+		t.Fatalf("got %d spans, want 2", len(spans))
+	}
+	if status := spans[0].Status(); status.Code != codes.Unset || len(spans[0].Events()) != 0 {
+		t.Fatalf("successful call: got status %+v and %d events, want no error", status, len(spans[0].Events()))
+	}
+	failed := spans[1]
+	if status := failed.Status(); status.Code != codes.Error || status.Description != domain.ErrPRNotExist.Error() {
+		t.Fatalf("failed call: got status %+v, want error %q", status, domain.ErrPRNotExist.Error())
+	}
+	if events := failed.Events(); len(events) != 1 || events[0].Name != "exception" {
+		t.Fatalf("failed call: got events %+v, want one exception", events)
+	}
+}
@@ -0,0 +1,250 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go.uber.org/zap"
+	"io"
+	"time"
+
+	"avito/internal/domain"
+	"avito/pkg/ical"
+	"avito/pkg/logger"
+
+	"github.com/google/uuid"
+)
+
+type UnavailabilityRepository interface {
+	CreateUnavailability(ctx context.Context, period domain.Unavailability) error
+	CreateUnavailabilities(ctx context.Context, periods []domain.Unavailability) ([]domain.Unavailability, error)
+	GetUnavailabilityByUserID(ctx context.Context, userID uuid.UUID) ([]domain.Unavailability, error)
+	GetUnavailabilityByID(ctx context.Context, id uuid.UUID) (*domain.Unavailability, error)
+	DeleteUnavailability(ctx context.Context, id uuid.UUID) (*domain.Unavailability, error)
+	GetStartedUnavailability(ctx context.Context) ([]domain.Unavailability, error)
+	MarkReviewsReassigned(ctx context.Context, id uuid.UUID) error
+}
+
+type UserProviderForUnavailability interface {
+	GetUserByID(ctx context.Context, id uuid.UUID) (*domain.User, error)
+}
+
+type ReviewProviderForUnavailability interface {
+	GetByReviewerID(ctx context.Context, reviewerID uuid.UUID) ([]*domain.PullRequest, error)
+}
+
+type ReviewReassigner interface {
+	ReassignmentReviewers(ctx context.Context, prID string, oldUserID uuid.UUID, expectedVersion *int64) (*domain.PullRequest, string, error)
+}
+
+type UnavailabilityService struct {
+	repo       UnavailabilityRepository
+	userSvc    UserProviderForUnavailability
+	prRepo     ReviewProviderForUnavailability
+	reassigner ReviewReassigner
+	log        *zap.Logger
+}
+
+func NewUnavailabilityService(repo UnavailabilityRepository, userSvc UserProviderForUnavailability, prRepo ReviewProviderForUnavailability, reassigner ReviewReassigner, log *zap.Logger) *UnavailabilityService {
+	return &UnavailabilityService{
+		repo:       repo,
+		userSvc:    userSvc,
+		prRepo:     prRepo,
+		reassigner: reassigner,
+		log:        log.Named("UnavailabilityService"),
+	}
+}
+
+// AddUnavailability добавляет пользователю период недоступности
+func (s *UnavailabilityService) AddUnavailability(ctx context.Context, userID uuid.UUID, startsAt, endsAt time.Time, reason string) (*domain.Unavailability, error) {
+	log := logger.FromContext(ctx, s.log).With(zap.String("user_id", userID.String()), zap.String("method", "AddUnavailability"))
+	if !endsAt.After(startsAt) {
+		log.Warn("attempt to add period with end before start", zap.Time("starts_at", startsAt), zap.Time("ends_at", endsAt))
+		return nil, domain.ErrInvalidPeriod
+	}
+	if err := s.authorizeUserChange(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	period := newUnavailability(userID, startsAt, endsAt, reason)
+	if err := s.repo.CreateUnavailability(ctx, period); err != nil {
+		log.Error("Failed to create unavailability period", zap.Error(err))
+		return nil, fmt.Errorf("failed to create unavailability period: %w", err)
+	}
+	log.Info("Unavailability period added", zap.String("id", period.ID.String()))
+	return &period, nil
+}
+
+// GetUnavailability возвращает все периоды недоступности пользователя
+func (s *UnavailabilityService) GetUnavailability(ctx context.Context, userID uuid.UUID) ([]domain.Unavailability, error) {
+	log := logger.FromContext(ctx, s.log)
+	if _, err := s.userSvc.GetUserByID(ctx, userID); err != nil {
+		return nil, err
+	}
+	periods, err := s.repo.GetUnavailabilityByUserID(ctx, userID)
+	if err != nil {
+		log.Error("Failed to get unavailability periods", zap.String("user_id", userID.String()), zap.Error(err))
+		return nil, fmt.Errorf("failed to get unavailability periods: %w", err)
+	}
+	return periods, nil
+}
+
+// DeleteUnavailability удаляет период недоступности
+func (s *UnavailabilityService) DeleteUnavailability(ctx context.Context, id uuid.UUID) (*domain.Unavailability, error) {
+	log := logger.FromContext(ctx, s.log)
+	if id == uuid.Nil {
+		log.Warn("attempt to delete unavailability period with nil id")
+		return nil, domain.ErrOneOfParametersNil
+	}
+	existing, err := s.repo.GetUnavailabilityByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			log.Warn("unavailability period not found", zap.String("id", id.String()))
+			return nil, domain.ErrNotFound
+		}
+		log.Error("Failed to get unavailability period", zap.String("id", id.String()), zap.Error(err))
+		return nil, fmt.Errorf("failed to get unavailability period: %w", err)
+	}
+	if err := s.authorizeUserChange(ctx, existing.UserID); err != nil {
+		return nil, err
+	}
+	period, err := s.repo.DeleteUnavailability(ctx, id)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			log.Warn("unavailability period not found", zap.String("id", id.String()))
+			return nil, domain.ErrNotFound
+		}
+		log.Error("Failed to delete unavailability period", zap.String("id", id.String()), zap.Error(err))
+		return nil, fmt.Errorf("failed to delete unavailability period: %w", err)
+	}
+	return period, nil
+}
+
+// ImportICS создает периоды недоступности из событий календаря в формате iCalendar.
+// События сопоставляются с уже импортированными по UID, поэтому повторный импорт того же календаря обновляет периоды
+func (s *UnavailabilityService) ImportICS(ctx context.Context, userID uuid.UUID, calendar io.Reader) ([]domain.Unavailability, error) {
+	log := logger.FromContext(ctx, s.log).With(zap.String("user_id", userID.String()), zap.String("method", "ImportICS"))
+	if err := s.authorizeUserChange(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	events, err := ical.Parse(calendar)
+	if err != nil {
+		log.Warn("Failed to parse calendar", zap.Error(err))
+		return nil, fmt.Errorf("%w: %v", domain.ErrInvalidCalendar, err)
+	}
+
+	periods := make([]domain.Unavailability, 0, len(events))
+	byUID := make(map[string]int, len(events))
+	for _, event := range events {
+		if !event.End.After(event.Start) {
+			log.Warn("calendar event with end before start", zap.String("uid", event.UID))
+			return nil, domain.ErrInvalidPeriod
+		}
+		period := newUnavailability(userID, event.Start, event.End, event.Summary)
+		period.UID = event.UID
+		// Событие с уже встреченным UID заменяет предыдущее, как при повторном импорте
+		if i, ok := byUID[event.UID]; ok && event.UID != "" {
+			periods[i] = period
+			continue
+		}
+		byUID[event.UID] = len(periods)
+		periods = append(periods, period)
+	}
+
+	periods, err = s.repo.CreateUnavailabilities(ctx, periods)
+	if err != nil {
+		log.Error("Failed to save imported unavailability periods", zap.Error(err))
+		return nil, fmt.Errorf("failed to save imported unavailability periods: %w", err)
+	}
+	log.Info("Imported unavailability periods", zap.Int("count", len(periods)))
+	return periods, nil
+}
+
+// ReassignReviewsOfStartedLeaves переназначает открытые ревью пользователей, у которых начался период недоступности.
+// Ошибка одного ревью или периода не прерывает обработку остальных: она учитывается в отчете как Failed,
+// а период остается неотмеченным и обрабатывается повторно при следующем запуске
+func (s *UnavailabilityService) ReassignReviewsOfStartedLeaves(ctx context.Context) (domain.LeaveReassignmentReport, error) {
+	log := logger.FromContext(ctx, s.log).With(zap.String("method", "ReassignReviewsOfStartedLeaves"))
+	var report domain.LeaveReassignmentReport
+	periods, err := s.repo.GetStartedUnavailability(ctx)
+	if err != nil {
+		log.Error("Failed to get started unavailability periods", zap.Error(err))
+		return report, fmt.Errorf("failed to get started unavailability periods: %w", err)
+	}
+	report.Periods = len(periods)
+
+	for _, period := range periods {
+		if err := ctx.Err(); err != nil {
+			log.Warn("Leave reassignment interrupted", zap.Error(err))
+			return report, err
+		}
+		periodLog := log.With(zap.String("user_id", period.UserID.String()), zap.String("unavailability_id", period.ID.String()))
+		prs, err := s.prRepo.GetByReviewerID(ctx, period.UserID)
+		if err != nil {
+			periodLog.Error("Failed to get reviews of unavailable user", zap.Error(err))
+			report.Failed++
+			continue
+		}
+
+		periodFailed := false
+		for _, pr := range prs {
+			if pr.Status != domain.StatusOpen {
+				continue
+			}
+			_, newReviewerID, err := s.reassigner.ReassignmentReviewers(ctx, pr.ID, period.UserID, nil)
+			if err != nil {
+				// Если замены нет, ревью остается за пользователем: повтор ничего не изменит
+				if errors.Is(err, domain.ErrNoCandidate) || errors.Is(err, domain.ErrUserNotAssigned) || errors.Is(err, domain.ErrPRMerged) {
+					periodLog.Warn("Review was not reassigned", zap.String("pr_id", pr.ID), zap.Error(err))
+					continue
+				}
+				periodLog.Error("Failed to reassign review", zap.String("pr_id", pr.ID), zap.Error(err))
+				report.Failed++
+				periodFailed = true
+				continue
+			}
+			report.Reassigned++
+			periodLog.Info("Review reassigned because of unavailability", zap.String("pr_id", pr.ID), zap.String("new_reviewer_id", newReviewerID))
+		}
+
+		if periodFailed {
+			periodLog.Warn("Unavailability period left unprocessed for retry")
+			continue
+		}
+		if err := s.repo.MarkReviewsReassigned(ctx, period.ID); err != nil {
+			periodLog.Error("Failed to mark unavailability period as processed", zap.Error(err))
+			report.Failed++
+		}
+	}
+
+	if report.Failed > 0 {
+		log.Warn("Some leave reassignments failed", zap.Int("failed", report.Failed), zap.Int("periods", report.Periods))
+	}
+	return report, nil
+}
+
+// authorizeUserChange проверяет, что вызывающий может менять периоды недоступности пользователя:
+// это сам пользователь или руководитель его команды
+func (s *UnavailabilityService) authorizeUserChange(ctx context.Context, userID uuid.UUID) error {
+	user, err := s.userSvc.GetUserByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if err := authorizeSelfOrTeamLead(ctx, user); err != nil {
+		logger.FromContext(ctx, s.log).Warn("Caller is not allowed to change user unavailability", zap.String("user_id", userID.String()))
+		return err
+	}
+	return nil
+}
+
+func newUnavailability(userID uuid.UUID, startsAt, endsAt time.Time, reason string) domain.Unavailability {
+	return domain.Unavailability{
+		ID:        uuid.New(),
+		UserID:    userID,
+		StartsAt:  startsAt.UTC(),
+		EndsAt:    endsAt.UTC(),
+		Reason:    reason,
+		CreatedAt: time.Now().UTC(),
+	}
+}
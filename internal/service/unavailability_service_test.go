@@ -0,0 +1,142 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"avito/internal/domain"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// fakeUnavailabilityRepo хранит периоды в памяти и не может отметить обработанными периоды из failMarks
+type fakeUnavailabilityRepo struct {
+	periods   []domain.Unavailability
+	failMarks map[uuid.UUID]bool
+	marked    []uuid.UUID
+}
+
+func (r *fakeUnavailabilityRepo) CreateUnavailability(_ context.Context, period domain.Unavailability) error {
+	r.periods = append(r.periods, period)
+	return nil
+}
+
+func (r *fakeUnavailabilityRepo) CreateUnavailabilities(_ context.Context, periods []domain.Unavailability) ([]domain.Unavailability, error) {
+	r.periods = append(r.periods, periods...)
+	return periods, nil
+}
+
+func (r *fakeUnavailabilityRepo) GetUnavailabilityByUserID(context.Context, uuid.UUID) ([]domain.Unavailability, error) {
+	return nil, nil
+}
+
+func (r *fakeUnavailabilityRepo) GetUnavailabilityByID(context.Context, uuid.UUID) (*domain.Unavailability, error) {
+	return nil, domain.ErrNotFound
+}
+
+func (r *fakeUnavailabilityRepo) DeleteUnavailability(context.Context, uuid.UUID) (*domain.Unavailability, error) {
+	return nil, domain.ErrNotFound
+}
+
+func (r *fakeUnavailabilityRepo) GetStartedUnavailability(context.Context) ([]domain.Unavailability, error) {
+	return r.periods, nil
+}
+
+func (r *fakeUnavailabilityRepo) MarkReviewsReassigned(_ context.Context, id uuid.UUID) error {
+	if r.failMarks[id] {
+		return errStorage
+	}
+	r.marked = append(r.marked, id)
+	return nil
+}
+
+// fakeReviewProvider отдает PR ревьюера, а для ревьюеров из fail возвращает ошибку
+type fakeReviewProvider struct {
+	prs  map[uuid.UUID][]*domain.PullRequest
+	fail map[uuid.UUID]bool
+}
+
+func (p *fakeReviewProvider) GetByReviewerID(_ context.Context, reviewerID uuid.UUID) ([]*domain.PullRequest, error) {
+	if p.fail[reviewerID] {
+		return nil, errStorage
+	}
+	return p.prs[reviewerID], nil
+}
+
+func TestReassignReviewsOfStartedLeavesContinuesAfterFailures(t *testing.T) {
+	alice, bob, carol, dave := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	leave := func(user uuid.UUID) domain.Unavailability {
+		return domain.Unavailability{ID: uuid.New(), UserID: user}
+	}
+	open := func(id string) *domain.PullRequest {
+		return &domain.PullRequest{ID: id, Status: domain.StatusOpen}
+	}
+	aliceLeave, bobLeave, carolLeave, daveLeave := leave(alice), leave(bob), leave(carol), leave(dave)
+
+	repo := &fakeUnavailabilityRepo{
+		periods:   []domain.Unavailability{aliceLeave, bobLeave, carolLeave, daveLeave},
+		failMarks: map[uuid.UUID]bool{daveLeave.ID: true},
+	}
+	reviews := &fakeReviewProvider{
+		prs: map[uuid.UUID][]*domain.PullRequest{
+			alice: {open("pr-a-fails"), open("pr-a")},
+			carol: {open("pr-c"), open("pr-c-no-candidate"), {ID: "pr-c-merged", Status: domain.StatusMerged}},
+			dave:  {open("pr-d")},
+		},
+		fail: map[uuid.UUID]bool{bob: true},
+	}
+	reassigner := &fakeReassigner{
+		newReviewer: uuid.New(),
+		errs: map[string]error{
+			"pr-a-fails":        errStorage,
+			"pr-c-no-candidate": domain.ErrNoCandidate,
+		},
+	}
+	srv := NewUnavailabilityService(repo, nil, reviews, reassigner, zap.NewNop())
+
+	report, err := srv.ReassignReviewsOfStartedLeaves(context.Background())
+	if err != nil {
+		t.Fatalf("got error %v, want nil", err)
+	}
+	want := domain.LeaveReassignmentReport{Periods: 4, Reassigned: 3, Failed: 3}
+	if report != want {
+		t.Fatalf("got report %+v, want %+v", report, want)
+	}
+	// Периоды с ошибкой остаются неотмеченными и будут обработаны при следующем запуске
+	if len(repo.marked) != 1 || repo.marked[0] != carolLeave.ID {
+		t.Fatalf("got marked periods %v, want only %v", repo.marked, carolLeave.ID)
+	}
+}
+
+func TestImportICSKeepsEventUID(t *testing.T) {
+	user := uuid.New()
+	repo := &fakeUnavailabilityRepo{}
+	users := &fakeUserRepo{users: map[uuid.UUID]domain.User{user: {ID: user}}}
+	srv := NewUnavailabilityService(repo, users, &fakeReviewProvider{}, &fakeReassigner{}, zap.NewNop())
+
+	calendar := strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"BEGIN:VEVENT", "UID:vacation", "SUMMARY:Vacation", "DTSTART:20250701", "DTEND:20250710", "END:VEVENT",
+		"BEGIN:VEVENT", "SUMMARY:Day off", "DTSTART:20250801", "END:VEVENT",
+		"BEGIN:VEVENT", "SUMMARY:Day off", "DTSTART:20250802", "END:VEVENT",
+		"BEGIN:VEVENT", "UID:vacation", "SUMMARY:Vacation moved", "DTSTART:20250703", "DTEND:20250712", "END:VEVENT",
+		"END:VCALENDAR",
+	}, "\r\n")
+
+	periods, err := srv.ImportICS(context.Background(), user, strings.NewReader(calendar))
+	if err != nil {
+		t.Fatalf("got error %v, want nil", err)
+	}
+	// Событие с повторяющимся UID заменяет первое, события без UID сохраняются все
+	if len(periods) != 3 {
+		t.Fatalf("got %d periods, want 3", len(periods))
+	}
+	if periods[0].UID != "vacation" || periods[0].Reason != "Vacation moved" {
+		t.Fatalf("got first period %+v, want the moved vacation", periods[0])
+	}
+	if periods[1].UID != "" || periods[2].UID != "" {
+		t.Fatalf("got UIDs %q and %q for events without UID, want empty", periods[1].UID, periods[2].UID)
+	}
+}
@@ -7,8 +7,10 @@ import (
 	"go.uber.org/zap"
 
 	"avito/internal/domain"
+	"avito/pkg/logger"
 
 	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 type PullRequestProviderForUser interface {
@@ -19,98 +21,162 @@ type UserRepository interface {
 	SaveUser(ctx context.Context, user domain.User) error
 	GetUserByID(ctx context.Context, id uuid.UUID) (*domain.User, error)
 	GetActiveTeamMembers(ctx context.Context, teamName string, excludeIDs []uuid.UUID) ([]domain.User, error)
-	SetIsActive(ctx context.Context, id uuid.UUID, isActive bool) error
+	SetIsActive(ctx context.Context, id uuid.UUID, isActive bool, expectedTeamVersion *int64) error
+	SetReviewCapacity(ctx context.Context, id uuid.UUID, capacity *int) error
+	SetRole(ctx context.Context, id uuid.UUID, role domain.Role) error
+}
+
+type TagRepository interface {
+	GetUserTags(ctx context.Context, userID uuid.UUID) ([]string, error)
+	AddUserTags(ctx context.Context, userID uuid.UUID, tags []string) error
+	ReplaceUserTags(ctx context.Context, userID uuid.UUID, tags []string) error
+	DeleteUserTag(ctx context.Context, userID uuid.UUID, tag string) error
 }
 
 type UserService struct {
 	userRepo UserRepository
 	prRepo   PullRequestProviderForUser
+	tagRepo  TagRepository
 	log      *zap.Logger
 }
 
-func NewUserService(userRepo UserRepository, prRepo PullRequestProviderForUser, log *zap.Logger) *UserService {
+func NewUserService(userRepo UserRepository, prRepo PullRequestProviderForUser, tagRepo TagRepository, log *zap.Logger) *UserService {
 	return &UserService{
 		userRepo: userRepo,
 		prRepo:   prRepo,
+		tagRepo:  tagRepo,
 		log:      log.Named("UserService"),
 	}
 }
 
-func (us *UserService) SaveUser(ctx context.Context, user domain.User) error {
+func (us *UserService) SaveUser(ctx context.Context, user domain.User) (err error) {
+	ctx, span := startSpan(ctx, "UserService.SaveUser", attribute.String("user.id", user.ID.String()))
+	defer func() { endSpan(span, err) }()
+	log := logger.FromContext(ctx, us.log)
 	if user.ID == uuid.Nil {
-		us.log.Error("attempted to save user with nil ID")
+		log.Error("attempted to save user with nil ID")
 		return domain.ErrUserIDNil
 	}
 	if err := us.userRepo.SaveUser(ctx, user); err != nil {
-		us.log.Error("failed to save user", zap.Error(err))
+		log.Error("failed to save user", zap.Error(err))
 		return fmt.Errorf("failed to save user: %w", err)
 	}
 	return nil
 }
 
-func (us *UserService) GetUserByID(ctx context.Context, id uuid.UUID) (*domain.User, error) {
+func (us *UserService) GetUserByID(ctx context.Context, id uuid.UUID) (_ *domain.User, err error) {
+	ctx, span := startSpan(ctx, "UserService.GetUserByID", attribute.String("user.id", id.String()))
+	defer func() { endSpan(span, err) }()
+	log := logger.FromContext(ctx, us.log)
 	if id == uuid.Nil {
-		us.log.Warn("user not found, id is null", zap.String("id", id.String()))
+		log.Warn("user not found, id is null", zap.String("id", id.String()))
 		return nil, domain.ErrOneOfParametersNil
 	}
 	user, err := us.userRepo.GetUserByID(ctx, id)
 	if err != nil {
 		if errors.Is(err, domain.ErrNotFound) {
-			us.log.Warn("user not found", zap.String("id", id.String()))
+			log.Warn("user not found", zap.String("id", id.String()))
 			return nil, domain.ErrNotFound
 		}
-		us.log.Error("failed to get user", zap.String("id", id.String()))
+		log.Error("failed to get user", zap.String("id", id.String()))
 		return nil, fmt.Errorf("failed to get user: %w", err)
 	}
 	return user, nil
 }
 
-func (us *UserService) GetActiveTeamMembers(ctx context.Context, teamName string, excludeIDs []uuid.UUID) ([]domain.User, error) {
+func (us *UserService) GetActiveTeamMembers(ctx context.Context, teamName string, excludeIDs []uuid.UUID) (_ []domain.User, err error) {
+	ctx, span := startSpan(ctx, "UserService.GetActiveTeamMembers", attribute.String("team.name", teamName))
+	defer func() { endSpan(span, err) }()
+	log := logger.FromContext(ctx, us.log)
 	if teamName == "" {
-		us.log.Warn("users not found, teamName is null", zap.String("teamName", teamName))
+		log.Warn("users not found, teamName is null", zap.String("teamName", teamName))
 		return nil, domain.ErrOneOfParametersNil
 	}
 	if excludeIDs == nil {
-		us.log.Warn("users not found, excludeIDs is nil")
+		log.Warn("users not found, excludeIDs is nil")
 		return nil, domain.ErrOneOfParametersNil
 	}
 	users, err := us.userRepo.GetActiveTeamMembers(ctx, teamName, excludeIDs)
 	if err != nil {
-		us.log.Error("Failed to get active team members", zap.String("teamName", teamName), zap.Any("excludeIDs", excludeIDs), zap.Error(err))
+		log.Error("Failed to get active team members", zap.String("teamName", teamName), zap.Any("excludeIDs", excludeIDs), zap.Error(err))
 		return nil, fmt.Errorf("failed to get active team members: %w", err)
 	}
 	return users, nil
 }
 
-func (us *UserService) SetIsActive(ctx context.Context, id uuid.UUID, isActive bool) (*domain.User, error) {
+// SetIsActive меняет активность пользователя. Активность определяет состав команды, поэтому при заданном
+// expectedTeamVersion изменение применяется только если команда пользователя имеет эту версию
+func (us *UserService) SetIsActive(ctx context.Context, id uuid.UUID, isActive bool, expectedTeamVersion *int64) (_ *domain.User, err error) {
+	ctx, span := startSpan(ctx, "UserService.SetIsActive", attribute.String("user.id", id.String()))
+	defer func() { endSpan(span, err) }()
+	log := logger.FromContext(ctx, us.log)
 	if id == uuid.Nil {
-		us.log.Warn("Failed to setting is_active, id is null", zap.String("id", id.String()))
+		log.Warn("Failed to setting is_active, id is null", zap.String("id", id.String()))
 		return nil, domain.ErrOneOfParametersNil
 	}
-	err := us.userRepo.SetIsActive(ctx, id, isActive)
+	// Участник может менять только свою активность, руководитель - активность участников своей команды
+	if err := us.authorizeUserChange(ctx, id, authorizeSelfOrTeamLead); err != nil {
+		log.Warn("Caller is not allowed to change user activity", zap.String("id", id.String()))
+		return nil, err
+	}
+	err = us.userRepo.SetIsActive(ctx, id, isActive, expectedTeamVersion)
 	if err != nil {
 		if errors.Is(err, domain.ErrNotFound) {
-			us.log.Warn("User not found for SetIsActive", zap.String("id", id.String()))
+			log.Warn("User not found for SetIsActive", zap.String("id", id.String()))
 			return nil, domain.ErrNotFound
 		}
-		us.log.Error("failed to set is_active", zap.String("id", id.String()))
+		if errors.Is(err, domain.ErrVersionConflict) {
+			log.Warn("Team was modified concurrently", zap.String("id", id.String()), zap.Int64p("expected_team_version", expectedTeamVersion))
+			return nil, domain.ErrVersionConflict
+		}
+		log.Error("failed to set is_active", zap.String("id", id.String()))
 		return nil, fmt.Errorf("failed to set is_active: %w", err)
 	}
 
 	user, err := us.userRepo.GetUserByID(ctx, id)
 	if err != nil {
-		us.log.Error("failed to get user", zap.String("id", id.String()))
+		log.Error("failed to get user", zap.String("id", id.String()))
 		return nil, fmt.Errorf("failed to get user: %w", err)
 	}
 
 	return user, nil
 }
 
+// SetReviewCapacity устанавливает личный лимит открытых ревью пользователя. nil возвращает лимит команды
+func (us *UserService) SetReviewCapacity(ctx context.Context, id uuid.UUID, capacity *int) (_ *domain.User, err error) {
+	ctx, span := startSpan(ctx, "UserService.SetReviewCapacity", attribute.String("user.id", id.String()))
+	defer func() { endSpan(span, err) }()
+	log := logger.FromContext(ctx, us.log)
+	if id == uuid.Nil {
+		log.Warn("Failed to set review capacity, id is null")
+		return nil, domain.ErrOneOfParametersNil
+	}
+	if !validCapacity(capacity) {
+		log.Warn("attempt to set negative review capacity", zap.String("id", id.String()))
+		return nil, domain.ErrInvalidCapacity
+	}
+	if err := us.authorizeUserChange(ctx, id, authorizeTeamLeadOf); err != nil {
+		log.Warn("Caller is not allowed to change user review capacity", zap.String("id", id.String()))
+		return nil, err
+	}
+	if err := us.userRepo.SetReviewCapacity(ctx, id, capacity); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			log.Warn("User not found for SetReviewCapacity", zap.String("id", id.String()))
+			return nil, domain.ErrNotFound
+		}
+		log.Error("failed to set review capacity", zap.String("id", id.String()), zap.Error(err))
+		return nil, fmt.Errorf("failed to set review capacity: %w", err)
+	}
+	return us.GetUserByID(ctx, id)
+}
+
 // GetReviewsForUser Возвращает список всех PR, где указанный пользователь назначен ревьюером
-func (us *UserService) GetReviewsForUser(ctx context.Context, userID uuid.UUID) ([]*domain.PullRequest, error) {
-	log := us.log.With(zap.String("user_id", userID.String()))
+func (us *UserService) GetReviewsForUser(ctx context.Context, userID uuid.UUID) (_ []*domain.PullRequest, err error) {
+	ctx, span := startSpan(ctx, "UserService.GetReviewsForUser", attribute.String("user.id", userID.String()))
+	defer func() { endSpan(span, err) }()
+	log := logger.FromContext(ctx, us.log).With(zap.String("user_id", userID.String()))
 
-	_, err := us.userRepo.GetUserByID(ctx, userID)
+	_, err = us.userRepo.GetUserByID(ctx, userID)
 	if err != nil {
 		if errors.Is(err, domain.ErrNotFound) {
 			log.Warn("attempted to get reviews for a non-existent user")
@@ -130,3 +196,129 @@ func (us *UserService) GetReviewsForUser(ctx context.Context, userID uuid.UUID)
 	log.Info("successfully fetched pull requests for review", zap.Int("count", len(pullRequests)))
 	return pullRequests, nil
 }
+
+// GetUserTags возвращает теги экспертизы пользователя
+func (us *UserService) GetUserTags(ctx context.Context, userID uuid.UUID) (_ []string, err error) {
+	ctx, span := startSpan(ctx, "UserService.GetUserTags", attribute.String("user.id", userID.String()))
+	defer func() { endSpan(span, err) }()
+	log := logger.FromContext(ctx, us.log).With(zap.String("user_id", userID.String()), zap.String("method", "GetUserTags"))
+	if err := us.ensureUserExists(ctx, userID); err != nil {
+		return nil, err
+	}
+	tags, err := us.tagRepo.GetUserTags(ctx, userID)
+	if err != nil {
+		log.Error("failed to get user tags", zap.Error(err))
+		return nil, fmt.Errorf("failed to get user tags: %w", err)
+	}
+	return tags, nil
+}
+
+// AddUserTags добавляет пользователю теги и возвращает итоговый список
+func (us *UserService) AddUserTags(ctx context.Context, userID uuid.UUID, tags []string) (_ []string, err error) {
+	ctx, span := startSpan(ctx, "UserService.AddUserTags", attribute.String("user.id", userID.String()))
+	defer func() { endSpan(span, err) }()
+	log := logger.FromContext(ctx, us.log).With(zap.String("user_id", userID.String()), zap.String("method", "AddUserTags"))
+	normalized, err := normalizeTags(tags)
+	if err != nil || len(normalized) == 0 {
+		log.Warn("attempt to add empty or invalid tags", zap.Strings("tags", tags))
+		return nil, domain.ErrOneOfParametersNil
+	}
+	if err := us.authorizeUserChange(ctx, userID, authorizeTeamLeadOf); err != nil {
+		return nil, err
+	}
+	if err := us.tagRepo.AddUserTags(ctx, userID, normalized); err != nil {
+		log.Error("failed to add user tags", zap.Error(err))
+		return nil, fmt.Errorf("failed to add user tags: %w", err)
+	}
+	return us.GetUserTags(ctx, userID)
+}
+
+// SetUserTags заменяет все теги пользователя на переданные
+func (us *UserService) SetUserTags(ctx context.Context, userID uuid.UUID, tags []string) (_ []string, err error) {
+	ctx, span := startSpan(ctx, "UserService.SetUserTags", attribute.String("user.id", userID.String()))
+	defer func() { endSpan(span, err) }()
+	log := logger.FromContext(ctx, us.log).With(zap.String("user_id", userID.String()), zap.String("method", "SetUserTags"))
+	normalized, err := normalizeTags(tags)
+	if err != nil {
+		log.Warn("attempt to set invalid tags", zap.Strings("tags", tags))
+		return nil, domain.ErrOneOfParametersNil
+	}
+	if err := us.authorizeUserChange(ctx, userID, authorizeTeamLeadOf); err != nil {
+		return nil, err
+	}
+	if err := us.tagRepo.ReplaceUserTags(ctx, userID, normalized); err != nil {
+		log.Error("failed to replace user tags", zap.Error(err))
+		return nil, fmt.Errorf("failed to replace user tags: %w", err)
+	}
+	return us.GetUserTags(ctx, userID)
+}
+
+// RemoveUserTag удаляет тег пользователя и возвращает оставшиеся теги
+func (us *UserService) RemoveUserTag(ctx context.Context, userID uuid.UUID, tag string) (_ []string, err error) {
+	ctx, span := startSpan(ctx, "UserService.RemoveUserTag", attribute.String("user.id", userID.String()))
+	defer func() { endSpan(span, err) }()
+	log := logger.FromContext(ctx, us.log).With(zap.String("user_id", userID.String()), zap.String("method", "RemoveUserTag"))
+	normalized, err := normalizeTags([]string{tag})
+	if err != nil {
+		log.Warn("attempt to remove empty tag")
+		return nil, domain.ErrOneOfParametersNil
+	}
+	if err := us.authorizeUserChange(ctx, userID, authorizeTeamLeadOf); err != nil {
+		return nil, err
+	}
+	if err := us.tagRepo.DeleteUserTag(ctx, userID, normalized[0]); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			log.Warn("user tag not found", zap.String("tag", normalized[0]))
+			return nil, domain.ErrNotFound
+		}
+		log.Error("failed to delete user tag", zap.Error(err))
+		return nil, fmt.Errorf("failed to delete user tag: %w", err)
+	}
+	return us.GetUserTags(ctx, userID)
+}
+
+// SetRole назначает пользователю роль. Доступно только администратору
+func (us *UserService) SetRole(ctx context.Context, id uuid.UUID, role domain.Role) (_ *domain.User, err error) {
+	ctx, span := startSpan(ctx, "UserService.SetRole", attribute.String("user.id", id.String()))
+	defer func() { endSpan(span, err) }()
+	log := logger.FromContext(ctx, us.log).With(zap.String("user_id", id.String()), zap.String("method", "SetRole"))
+	if id == uuid.Nil {
+		log.Warn("Failed to set role, id is null")
+		return nil, domain.ErrOneOfParametersNil
+	}
+	if !role.Valid() {
+		log.Warn("attempt to set unknown role", zap.String("role", string(role)))
+		return nil, domain.ErrInvalidRole
+	}
+	if err := authorizeAdmin(ctx); err != nil {
+		log.Warn("Caller is not allowed to assign roles")
+		return nil, err
+	}
+	if err := us.userRepo.SetRole(ctx, id, role); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			log.Warn("User not found for SetRole")
+			return nil, domain.ErrNotFound
+		}
+		log.Error("failed to set role", zap.Error(err))
+		return nil, fmt.Errorf("failed to set role: %w", err)
+	}
+	log.Info("User role changed", zap.String("role", string(role)))
+	return us.GetUserByID(ctx, id)
+}
+
+// authorizeUserChange загружает пользователя и проверяет, что вызывающему можно его менять
+func (us *UserService) authorizeUserChange(ctx context.Context, userID uuid.UUID, authorize func(context.Context, *domain.User) error) error {
+	user, err := us.GetUserByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	return authorize(ctx, user)
+}
+
+// ensureUserExists проверяет, что пользователь с указанным ID существует
+func (us *UserService) ensureUserExists(ctx context.Context, userID uuid.UUID) error {
+	if _, err := us.GetUserByID(ctx, userID); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,98 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"avito/internal/auth"
+	"avito/internal/domain"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// fakeUserRepo хранит пользователей в памяти и запоминает, менялась ли активность
+type fakeUserRepo struct {
+	users     map[uuid.UUID]domain.User
+	activeSet bool
+}
+
+func (r *fakeUserRepo) SaveUser(_ context.Context, user domain.User) error {
+	r.users[user.ID] = user
+	return nil
+}
+
+func (r *fakeUserRepo) GetUserByID(_ context.Context, id uuid.UUID) (*domain.User, error) {
+	user, ok := r.users[id]
+	if !ok {
+		return nil, domain.ErrNotFound
+	}
+	return &user, nil
+}
+
+func (r *fakeUserRepo) GetActiveTeamMembers(context.Context, string, []uuid.UUID) ([]domain.User, error) {
+	return nil, nil
+}
+
+func (r *fakeUserRepo) SetIsActive(_ context.Context, id uuid.UUID, isActive bool, _ *int64) error {
+	user := r.users[id]
+	user.IsActive = isActive
+	r.users[id] = user
+	r.activeSet = true
+	return nil
+}
+
+func (r *fakeUserRepo) SetReviewCapacity(context.Context, uuid.UUID, *int) error {
+	return nil
+}
+
+func (r *fakeUserRepo) SetRole(context.Context, uuid.UUID, domain.Role) error {
+	return nil
+}
+
+func userPrincipal(id uuid.UUID, role domain.Role, team string, scopes ...domain.Scope) domain.Principal {
+	return domain.Principal{Type: domain.PrincipalUser, ID: id.String(), UserID: &id, Role: role, TeamName: team, Scopes: scopes}
+}
+
+func TestSetIsActiveAuthorization(t *testing.T) {
+	member, colleague, lead := uuid.New(), uuid.New(), uuid.New()
+	users := map[uuid.UUID]domain.User{
+		member:    {ID: member, Username: "member", IsActive: true, TeamName: "backend", Role: domain.RoleMember},
+		colleague: {ID: colleague, Username: "colleague", IsActive: true, TeamName: "backend", Role: domain.RoleMember},
+		lead:      {ID: lead, Username: "lead", IsActive: true, TeamName: "backend", Role: domain.RoleTeamLead},
+	}
+
+	tests := []struct {
+		name      string
+		principal domain.Principal
+		target    uuid.UUID
+		wantErr   error
+	}{
+		{"member on self without scope", userPrincipal(member, domain.RoleMember, "backend"), member, nil},
+		{"member on another user", userPrincipal(member, domain.RoleMember, "backend", domain.ScopeUsersWrite), colleague, domain.ErrForbidden},
+		{"lead on own team", userPrincipal(lead, domain.RoleTeamLead, "backend", domain.ScopeUsersWrite), member, nil},
+		{"lead on own team without scope", userPrincipal(lead, domain.RoleTeamLead, "backend"), member, domain.ErrForbidden},
+		{"lead on another team", userPrincipal(lead, domain.RoleTeamLead, "frontend", domain.ScopeUsersWrite), member, domain.ErrForbidden},
+		{"integration key without scope", domain.Principal{Type: domain.PrincipalAPIKey, Role: domain.RoleIntegration, Scopes: []domain.Scope{domain.ScopeStatsRead}}, member, domain.ErrForbidden},
+		{"integration key with users:write", domain.Principal{Type: domain.PrincipalAPIKey, Role: domain.RoleIntegration, Scopes: []domain.Scope{domain.ScopeUsersWrite}}, member, nil},
+		{"admin key", domain.Principal{Type: domain.PrincipalAPIKey, Role: domain.RoleAdmin, Scopes: []domain.Scope{domain.ScopeAdmin}}, member, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeUserRepo{users: make(map[uuid.UUID]domain.User, len(users))}
+			for id, user := range users {
+				repo.users[id] = user
+			}
+			srv := NewUserService(repo, nil, nil, zap.NewNop())
+
+			_, err := srv.SetIsActive(auth.WithPrincipal(context.Background(), tt.principal), tt.target, false, nil)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("got error %v, want %v", err, tt.wantErr)
+			}
+			if repo.activeSet != (tt.wantErr == nil) {
+				t.Fatalf("activity changed: %v, want %v", repo.activeSet, tt.wantErr == nil)
+			}
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package graphql
+
+import (
+	"context"
+	"go.uber.org/zap"
+
+	"avito/internal/auth"
+	"avito/internal/domain"
+	"avito/pkg/logger"
+)
+
+// codeInternalError - код ошибок, которые не относятся к предметной области, как в HTTP API
+const codeInternalError = "INTERNAL_ERROR"
+
+// queryError - ошибка поля в ответе. Код попадает в extensions.code, как error.code в HTTP API
+type queryError struct {
+	code    string
+	message string
+}
+
+func (e *queryError) Error() string {
+	return e.message
+}
+
+// Extensions вызывается graphql-go при сборке ошибки ответа
+func (e *queryError) Extensions() map[string]any {
+	return map[string]any{"code": e.code}
+}
+
+// toQueryError оставляет клиенту код и сообщение ошибки предметной области. Прочие ошибки
+// журналируются, а клиент получает INTERNAL_ERROR без подробностей
+func (r *resolver) toQueryError(ctx context.Context, err error) error {
+	if domainErr, ok := domain.AsError(err); ok {
+		return &queryError{code: string(domainErr.Code), message: domainErr.Message}
+	}
+	logger.FromContext(ctx, r.log).Error("Failed to resolve field", zap.Error(err))
+	return &queryError{code: codeInternalError, message: "internal server error"}
+}
+
+// requireScope проверяет право вызывающего на чтение ресурса. Поле без права получает null и ошибку FORBIDDEN,
+// остальные поля запроса выполняются
+func requireScope(ctx context.Context, scope domain.Scope) error {
+	principal, ok := auth.PrincipalFromContext(ctx)
+	if ok && principal.HasScope(scope) {
+		return nil
+	}
+	return &queryError{code: string(domain.CodeForbidden), message: "caller lacks required scope " + string(scope)}
+}
+
+// notFound - ошибка связи, которая ссылается на отсутствующую запись
+func notFound() error {
+	return &queryError{code: string(domain.CodeNotFound), message: domain.ErrNotFound.Message}
+}
@@ -0,0 +1,104 @@
+// Package graphql обслуживает /graphql: клиент одним запросом получает команду, ее участников, их ревью
+// и авторов PR. Связи между типами резолвятся загрузчиками: они собирают ключи, запрошенные на одном
+// уровне запроса, и читают их одним обращением к базе, поэтому число запросов не растет с числом узлов.
+// Схема только читает данные; права проверяются на каждом поле, которое ведет к ресурсу другого типа
+package graphql
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"go.uber.org/zap"
+	"net/http"
+
+	"avito/internal/domain"
+	"avito/pkg/logger"
+
+	"github.com/graph-gophers/graphql-go"
+	gqlerrors "github.com/graph-gophers/graphql-go/errors"
+)
+
+//go:embed schema.graphql
+var schemaSDL string
+
+const (
+	// maxDepth ограничивает вложенность запроса: связи замкнуты (user.reviews.pr.author.reviews...)
+	maxDepth = 12
+	// maxParallelism - сколько резолверов выполняется одновременно. Загрузчик собирает в пакет
+	// только одновременные обращения, поэтому значение ограничивает и размер пакета
+	maxParallelism = 64
+	// maxBodyBytes ограничивает размер тела запроса
+	maxBodyBytes = 1 << 20
+	// codeInvalidBody - код ответа на тело, которое не разбирается как запрос GraphQL, как в HTTP API
+	codeInvalidBody = "INVALID_BODY"
+)
+
+// StatsProvider отдает статистику ревью для типа Stats
+type StatsProvider interface {
+	GetUserReviewStats(ctx context.Context, filter domain.StatsFilter) ([]*domain.UserReviewStat, error)
+	GetTeamReviewStats(ctx context.Context, filter domain.StatsFilter) ([]*domain.TeamReviewStat, error)
+}
+
+type Services struct {
+	Lookup Lookup
+	Stats  StatsProvider
+}
+
+type Handler struct {
+	schema *graphql.Schema
+	lookup Lookup
+	log    *zap.Logger
+}
+
+// NewHandler разбирает встроенную схему. Ошибка схемы - ошибка программы, поэтому приводит к панике
+func NewHandler(services Services, log *zap.Logger) *Handler {
+	log = log.Named("GraphQL")
+	r := &resolver{stats: services.Stats, log: log}
+	return &Handler{
+		schema: graphql.MustParseSchema(schemaSDL, r,
+			graphql.MaxDepth(maxDepth),
+			graphql.MaxParallelism(maxParallelism),
+			graphql.UseStringDescriptions(),
+		),
+		lookup: services.Lookup,
+		log:    log,
+	}
+}
+
+// request - тело запроса по соглашению GraphQL over HTTP
+type request struct {
+	Query         string         `json:"query"`
+	OperationName string         `json:"operationName"`
+	Variables     map[string]any `json:"variables"`
+}
+
+// ServeHTTP выполняет запрос из тела POST. Ошибки полей возвращаются в errors вместе с данными
+// и статусом 200, 400 означает, что запрос не удалось разобрать
+func (h *Handler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	ctx := req.Context()
+	log := logger.FromContext(ctx, h.log)
+
+	var body request
+	if err := json.NewDecoder(http.MaxBytesReader(w, req.Body, maxBodyBytes)).Decode(&body); err != nil || body.Query == "" {
+		log.Warn("Invalid GraphQL request body", zap.Error(err))
+		writeResponse(w, log, http.StatusBadRequest, &graphql.Response{Errors: []*gqlerrors.QueryError{{
+			Message:    "request body must be a JSON object with a query",
+			Extensions: map[string]any{"code": codeInvalidBody},
+		}}})
+		return
+	}
+
+	response := h.schema.Exec(withLoaders(ctx, newLoaders(h.lookup)), body.Query, body.OperationName, body.Variables)
+	if len(response.Errors) > 0 {
+		log.Warn("GraphQL query completed with errors", zap.Int("errors", len(response.Errors)), zap.String("first_error", response.Errors[0].Message))
+	}
+	writeResponse(w, log, http.StatusOK, response)
+}
+
+func writeResponse(w http.ResponseWriter, log *zap.Logger, status int, response *graphql.Response) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		log.Error("Failed to write GraphQL response", zap.Error(err))
+	}
+}
@@ -0,0 +1,195 @@
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"avito/internal/auth"
+	"avito/internal/domain"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// fakeLookup отдает данные из памяти и считает обращения к каждому методу
+type fakeLookup struct {
+	mu      sync.Mutex
+	calls   map[string]int
+	users   map[uuid.UUID]*domain.User
+	teams   map[string]*domain.Team
+	prs     map[string]*domain.PullRequest
+	reviews map[uuid.UUID][]domain.Review
+}
+
+func (f *fakeLookup) record(method string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls[method]++
+}
+
+func pick[K comparable, V any](all map[K]V, keys []K) map[K]V {
+	result := make(map[K]V, len(keys))
+	for _, key := range keys {
+		if value, ok := all[key]; ok {
+			result[key] = value
+		}
+	}
+	return result
+}
+
+func (f *fakeLookup) UsersByIDs(_ context.Context, ids []uuid.UUID) (map[uuid.UUID]*domain.User, error) {
+	f.record("users")
+	return pick(f.users, ids), nil
+}
+
+func (f *fakeLookup) TeamsByNames(_ context.Context, names []string) (map[string]*domain.Team, error) {
+	f.record("teams")
+	return pick(f.teams, names), nil
+}
+
+func (f *fakeLookup) PullRequestsByIDs(_ context.Context, ids []string) (map[string]*domain.PullRequest, error) {
+	f.record("pullRequests")
+	return pick(f.prs, ids), nil
+}
+
+func (f *fakeLookup) ReviewsByReviewerIDs(_ context.Context, ids []uuid.UUID) (map[uuid.UUID][]domain.Review, error) {
+	f.record("reviews")
+	return pick(f.reviews, ids), nil
+}
+
+func (f *fakeLookup) TagsByUserIDs(_ context.Context, ids []uuid.UUID) (map[uuid.UUID][]string, error) {
+	f.record("tags")
+	return map[uuid.UUID][]string{}, nil
+}
+
+// newTeamLookup собирает команду из members участников, каждый из которых ревьюит PR двух других
+func newTeamLookup(members int) *fakeLookup {
+	f := &fakeLookup{
+		calls:   make(map[string]int),
+		users:   make(map[uuid.UUID]*domain.User),
+		teams:   make(map[string]*domain.Team),
+		prs:     make(map[string]*domain.PullRequest),
+		reviews: make(map[uuid.UUID][]domain.Review),
+	}
+	team := &domain.Team{Name: "backend", Version: 1}
+	for i := 0; i < members; i++ {
+		user := domain.User{ID: uuid.New(), Username: "user-" + string(rune('a'+i)), IsActive: true, TeamName: team.Name, Role: domain.RoleMember}
+		team.Members = append(team.Members, user)
+		f.users[user.ID] = &user
+		pr := &domain.PullRequest{ID: "pr-" + user.Username, Name: "change", Status: domain.StatusOpen, AuthorID: user.ID, CreatedAt: time.Now()}
+		f.prs[pr.ID] = pr
+	}
+	for i, reviewer := range team.Members {
+		for _, offset := range []int{1, 2} {
+			author := team.Members[(i+offset)%members]
+			f.reviews[reviewer.ID] = append(f.reviews[reviewer.ID], domain.Review{PullRequestID: "pr-" + author.Username, ReviewerID: reviewer.ID, AssignedAt: time.Now()})
+		}
+	}
+	f.teams[team.Name] = team
+	return f
+}
+
+type response struct {
+	Data   json.RawMessage `json:"data"`
+	Errors []struct {
+		Message    string         `json:"message"`
+		Extensions map[string]any `json:"extensions"`
+	} `json:"errors"`
+}
+
+func query(t *testing.T, h http.Handler, principal domain.Principal, body string) (int, response) {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(body))
+	req = req.WithContext(auth.WithPrincipal(req.Context(), principal))
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	var resp response
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to decode response %q: %v", rec.Body.String(), err)
+	}
+	return rec.Code, resp
+}
+
+var admin = domain.Principal{Type: domain.PrincipalAnonymous, Scopes: []domain.Scope{domain.ScopeAdmin}, Role: domain.RoleAdmin}
+
+func TestNestedQueryBatchesEachLevel(t *testing.T) {
+	lookup := newTeamLookup(5)
+	h := NewHandler(Services{Lookup: lookup}, zap.NewNop())
+
+	code, resp := query(t, h, admin, `{"query":"{ team(name: \"backend\") { members { username reviews { pr { id author { username team { name } } } } } } }"}`)
+	if code != http.StatusOK || len(resp.Errors) > 0 {
+		t.Fatalf("got %d with errors %v", code, resp.Errors)
+	}
+
+	var data struct {
+		Team struct {
+			Members []struct {
+				Reviews []struct {
+					Pr struct {
+						Author struct{ Username string }
+					}
+				}
+			}
+		}
+	}
+	if err := json.Unmarshal(resp.Data, &data); err != nil {
+		t.Fatalf("failed to decode data: %v", err)
+	}
+	if len(data.Team.Members) != 5 || len(data.Team.Members[0].Reviews) != 2 || data.Team.Members[0].Reviews[0].Pr.Author.Username == "" {
+		t.Fatalf("unexpected data: %s", resp.Data)
+	}
+
+	// Каждый уровень запроса читается одним пакетом, сколько бы участников и ревью ни было.
+	// Команда автора уже в кэше загрузчика после первого уровня
+	want := map[string]int{"teams": 1, "reviews": 1, "pullRequests": 1, "users": 1}
+	for method, calls := range want {
+		if lookup.calls[method] != calls {
+			t.Errorf("%s: got %d calls, want %d (all calls: %v)", method, lookup.calls[method], calls, lookup.calls)
+		}
+	}
+}
+
+func TestFieldWithoutScopeIsForbidden(t *testing.T) {
+	lookup := newTeamLookup(3)
+	h := NewHandler(Services{Lookup: lookup}, zap.NewNop())
+	teamsOnly := domain.Principal{Type: domain.PrincipalAPIKey, Scopes: []domain.Scope{domain.ScopeTeamsRead}, Role: domain.RoleIntegration}
+
+	code, resp := query(t, h, teamsOnly, `{"query":"{ team(name: \"backend\") { name members { username } } }"}`)
+	if code != http.StatusOK {
+		t.Fatalf("got status %d, want 200", code)
+	}
+	if len(resp.Errors) != 1 || resp.Errors[0].Extensions["code"] != string(domain.CodeForbidden) {
+		t.Fatalf("got errors %v, want one FORBIDDEN", resp.Errors)
+	}
+	if lookup.calls["users"] != 0 {
+		t.Fatalf("users were read without users:read scope")
+	}
+}
+
+func TestMissingEntityIsNull(t *testing.T) {
+	h := NewHandler(Services{Lookup: newTeamLookup(1)}, zap.NewNop())
+
+	_, resp := query(t, h, admin, `{"query":"{ team(name: \"unknown\") { name } user(id: \"not-a-uuid\") { username } }"}`)
+	if string(resp.Data) != `{"team":null,"user":null}` {
+		t.Fatalf("got data %s, want both fields null", resp.Data)
+	}
+	if len(resp.Errors) != 1 || resp.Errors[0].Extensions["code"] != string(domain.CodeInvalidArgument) {
+		t.Fatalf("got errors %v, want one %s for the invalid id", resp.Errors, domain.CodeInvalidArgument)
+	}
+}
+
+func TestInvalidBodyIsRejected(t *testing.T) {
+	h := NewHandler(Services{Lookup: newTeamLookup(1)}, zap.NewNop())
+
+	code, resp := query(t, h, admin, `{"variables":{}}`)
+	if code != http.StatusBadRequest || len(resp.Errors) != 1 || resp.Errors[0].Extensions["code"] != codeInvalidBody {
+		t.Fatalf("got %d with errors %v, want 400 %s", code, resp.Errors, codeInvalidBody)
+	}
+}
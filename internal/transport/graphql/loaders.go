@@ -0,0 +1,67 @@
+package graphql
+
+import (
+	"context"
+
+	"avito/internal/domain"
+
+	"github.com/google/uuid"
+	"github.com/graph-gophers/dataloader/v7"
+)
+
+// Lookup - пакетные чтения, на которых построены загрузчики. Реализуется service.LookupService
+type Lookup interface {
+	UsersByIDs(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]*domain.User, error)
+	TeamsByNames(ctx context.Context, names []string) (map[string]*domain.Team, error)
+	PullRequestsByIDs(ctx context.Context, ids []string) (map[string]*domain.PullRequest, error)
+	ReviewsByReviewerIDs(ctx context.Context, reviewerIDs []uuid.UUID) (map[uuid.UUID][]domain.Review, error)
+	TagsByUserIDs(ctx context.Context, userIDs []uuid.UUID) (map[uuid.UUID][]string, error)
+}
+
+// loaders собирают ключи, запрошенные резолверами одного запроса, и читают их одним вызовом Lookup.
+// Создаются на каждый запрос: кэш загрузчика не должен переживать запрос и отдавать устаревшие данные
+// или данные, прочитанные с правами другого вызывающего
+type loaders struct {
+	users        *dataloader.Loader[uuid.UUID, *domain.User]
+	teams        *dataloader.Loader[string, *domain.Team]
+	pullRequests *dataloader.Loader[string, *domain.PullRequest]
+	reviews      *dataloader.Loader[uuid.UUID, []domain.Review]
+	tags         *dataloader.Loader[uuid.UUID, []string]
+}
+
+func newLoaders(lookup Lookup) *loaders {
+	return &loaders{
+		users:        dataloader.NewBatchedLoader(batch(lookup.UsersByIDs)),
+		teams:        dataloader.NewBatchedLoader(batch(lookup.TeamsByNames)),
+		pullRequests: dataloader.NewBatchedLoader(batch(lookup.PullRequestsByIDs)),
+		reviews:      dataloader.NewBatchedLoader(batch(lookup.ReviewsByReviewerIDs)),
+		tags:         dataloader.NewBatchedLoader(batch(lookup.TagsByUserIDs)),
+	}
+}
+
+// batch превращает чтение в карту в функцию пакета загрузчика. Результаты идут в порядке ключей,
+// отсутствующему ключу соответствует нулевое значение, ошибка чтения достается всем ключам пакета
+func batch[K comparable, V any](fetch func(context.Context, []K) (map[K]V, error)) dataloader.BatchFunc[K, V] {
+	return func(ctx context.Context, keys []K) []*dataloader.Result[V] {
+		results := make([]*dataloader.Result[V], len(keys))
+		values, err := fetch(ctx, keys)
+		for i, key := range keys {
+			if err != nil {
+				results[i] = &dataloader.Result[V]{Error: err}
+				continue
+			}
+			results[i] = &dataloader.Result[V]{Data: values[key]}
+		}
+		return results
+	}
+}
+
+type loadersKey struct{}
+
+func withLoaders(ctx context.Context, l *loaders) context.Context {
+	return context.WithValue(ctx, loadersKey{}, l)
+}
+
+func loadersFrom(ctx context.Context) *loaders {
+	return ctx.Value(loadersKey{}).(*loaders)
+}
@@ -0,0 +1,135 @@
+package graphql
+
+import (
+	"context"
+	"go.uber.org/zap"
+
+	"avito/internal/domain"
+
+	"github.com/google/uuid"
+	"github.com/graph-gophers/graphql-go"
+)
+
+// resolver - корневой тип Query. Загрузчики берутся из контекста запроса
+type resolver struct {
+	stats StatsProvider
+	log   *zap.Logger
+}
+
+func (r *resolver) Team(ctx context.Context, args struct{ Name string }) (*teamResolver, error) {
+	return r.loadTeam(ctx, args.Name)
+}
+
+func (r *resolver) User(ctx context.Context, args struct{ ID graphql.ID }) (*userResolver, error) {
+	id, err := uuid.Parse(string(args.ID))
+	if err != nil {
+		return nil, &queryError{code: string(domain.CodeInvalidArgument), message: "id must be a UUID"}
+	}
+	return r.loadUser(ctx, id)
+}
+
+func (r *resolver) PullRequest(ctx context.Context, args struct{ ID graphql.ID }) (*pullRequestResolver, error) {
+	return r.loadPullRequest(ctx, string(args.ID))
+}
+
+func (r *resolver) Stats(ctx context.Context, args struct {
+	From     *graphql.Time
+	To       *graphql.Time
+	TeamName *string
+	Status   *string
+}) (*statsResolver, error) {
+	if err := requireScope(ctx, domain.ScopeStatsRead); err != nil {
+		return nil, err
+	}
+	filter := domain.StatsFilter{From: timePtr(args.From), To: timePtr(args.To)}
+	if args.TeamName != nil {
+		filter.TeamName = *args.TeamName
+	}
+	if args.Status != nil {
+		status := domain.StatusPR(*args.Status)
+		filter.Status = &status
+	}
+	return &statsResolver{root: r, filter: filter}, nil
+}
+
+// loadTeam читает команду через загрузчик. nil без ошибки означает, что команды нет
+func (r *resolver) loadTeam(ctx context.Context, name string) (*teamResolver, error) {
+	if err := requireScope(ctx, domain.ScopeTeamsRead); err != nil {
+		return nil, err
+	}
+	team, err := loadersFrom(ctx).teams.Load(ctx, name)()
+	if err != nil {
+		return nil, r.toQueryError(ctx, err)
+	}
+	if team == nil {
+		return nil, nil
+	}
+	return &teamResolver{root: r, team: team}, nil
+}
+
+// loadUser читает пользователя через загрузчик. nil без ошибки означает, что пользователя нет
+func (r *resolver) loadUser(ctx context.Context, id uuid.UUID) (*userResolver, error) {
+	if err := requireScope(ctx, domain.ScopeUsersRead); err != nil {
+		return nil, err
+	}
+	user, err := loadersFrom(ctx).users.Load(ctx, id)()
+	if err != nil {
+		return nil, r.toQueryError(ctx, err)
+	}
+	if user == nil {
+		return nil, nil
+	}
+	return &userResolver{root: r, user: user}, nil
+}
+
+// loadPullRequest читает PR через загрузчик. nil без ошибки означает, что PR нет
+func (r *resolver) loadPullRequest(ctx context.Context, id string) (*pullRequestResolver, error) {
+	if err := requireScope(ctx, domain.ScopePRsRead); err != nil {
+		return nil, err
+	}
+	pr, err := loadersFrom(ctx).pullRequests.Load(ctx, id)()
+	if err != nil {
+		return nil, r.toQueryError(ctx, err)
+	}
+	if pr == nil {
+		return nil, nil
+	}
+	return &pullRequestResolver{root: r, pr: pr}, nil
+}
+
+// requireUser - loadUser для обязательной связи: отсутствующий пользователь - ошибка поля
+func (r *resolver) requireUser(ctx context.Context, id uuid.UUID) (*userResolver, error) {
+	user, err := r.loadUser(ctx, id)
+	if err == nil && user == nil {
+		err = notFound()
+	}
+	return user, err
+}
+
+// requireTeam - loadTeam для обязательной связи: отсутствующая команда - ошибка поля
+func (r *resolver) requireTeam(ctx context.Context, name string) (*teamResolver, error) {
+	team, err := r.loadTeam(ctx, name)
+	if err == nil && team == nil {
+		err = notFound()
+	}
+	return team, err
+}
+
+// loadUsers читает пользователей параллельно, чтобы загрузчик собрал их в один пакет
+func (r *resolver) loadUsers(ctx context.Context, ids []uuid.UUID) ([]*userResolver, error) {
+	if err := requireScope(ctx, domain.ScopeUsersRead); err != nil {
+		return nil, err
+	}
+	users, errs := loadersFrom(ctx).users.LoadMany(ctx, ids)()
+	result := make([]*userResolver, 0, len(users))
+	for i, user := range users {
+		if errs != nil && errs[i] != nil {
+			return nil, r.toQueryError(ctx, errs[i])
+		}
+		if user == nil {
+			return nil, notFound()
+		}
+		result = append(result, &userResolver{root: r, user: user})
+	}
+	return result, nil
+}
@@ -0,0 +1,309 @@
+package graphql
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"avito/internal/domain"
+
+	"github.com/graph-gophers/graphql-go"
+)
+
+type teamResolver struct {
+	root *resolver
+	team *domain.Team
+}
+
+func (t *teamResolver) Name() string {
+	return t.team.Name
+}
+
+// Members берет участников из самой команды: загрузчик команд читает их тем же запросом
+func (t *teamResolver) Members(ctx context.Context) ([]*userResolver, error) {
+	if err := requireScope(ctx, domain.ScopeUsersRead); err != nil {
+		return nil, err
+	}
+	members := make([]*userResolver, 0, len(t.team.Members))
+	for i := range t.team.Members {
+		members = append(members, &userResolver{root: t.root, user: &t.team.Members[i]})
+	}
+	return members, nil
+}
+
+func (t *teamResolver) DefaultReviewCapacity() *int32 {
+	return int32Ptr(t.team.DefaultReviewCapacity)
+}
+
+func (t *teamResolver) ReviewSlaMinutes() *int32 {
+	return int32Ptr(t.team.ReviewSLAMinutes)
+}
+
+func (t *teamResolver) EscalationMinutes() *int32 {
+	return int32Ptr(t.team.EscalationMinutes)
+}
+
+func (t *teamResolver) Version() int32 {
+	return int32(t.team.Version)
+}
+
+type userResolver struct {
+	root *resolver
+	user *domain.User
+}
+
+func (u *userResolver) ID() graphql.ID {
+	return graphql.ID(u.user.ID.String())
+}
+
+func (u *userResolver) Username() string {
+	return u.user.Username
+}
+
+func (u *userResolver) IsActive() bool {
+	return u.user.IsActive
+}
+
+func (u *userResolver) Role() string {
+	return strings.ToUpper(string(u.user.Role))
+}
+
+func (u *userResolver) Tags(ctx context.Context) ([]string, error) {
+	tags, err := loadersFrom(ctx).tags.Load(ctx, u.user.ID)()
+	if err != nil {
+		return nil, u.root.toQueryError(ctx, err)
+	}
+	if tags == nil {
+		tags = []string{}
+	}
+	return tags, nil
+}
+
+func (u *userResolver) ReviewCapacity() *int32 {
+	return int32Ptr(u.user.ReviewCapacity)
+}
+
+func (u *userResolver) Team(ctx context.Context) (*teamResolver, error) {
+	return u.root.requireTeam(ctx, u.user.TeamName)
+}
+
+// Reviews требует то же право, что и список ревью пользователя в HTTP API. Фильтр по статусу
+// читает PR через загрузчик, поэтому требует и право на PR
+func (u *userResolver) Reviews(ctx context.Context, args struct{ Status *string }) ([]*reviewResolver, error) {
+	if err := requireScope(ctx, domain.ScopeUsersRead); err != nil {
+		return nil, err
+	}
+	reviews, err := loadersFrom(ctx).reviews.Load(ctx, u.user.ID)()
+	if err != nil {
+		return nil, u.root.toQueryError(ctx, err)
+	}
+
+	result := make([]*reviewResolver, 0, len(reviews))
+	if args.Status == nil {
+		for i := range reviews {
+			result = append(result, &reviewResolver{root: u.root, review: &reviews[i]})
+		}
+		return result, nil
+	}
+
+	if err := requireScope(ctx, domain.ScopePRsRead); err != nil {
+		return nil, err
+	}
+	ids := make([]string, 0, len(reviews))
+	for _, review := range reviews {
+		ids = append(ids, review.PullRequestID)
+	}
+	prs, errs := loadersFrom(ctx).pullRequests.LoadMany(ctx, ids)()
+	for i, pr := range prs {
+		if errs != nil && errs[i] != nil {
+			return nil, u.root.toQueryError(ctx, errs[i])
+		}
+		if pr != nil && string(pr.Status) == *args.Status {
+			result = append(result, &reviewResolver{root: u.root, review: &reviews[i]})
+		}
+	}
+	return result, nil
+}
+
+type reviewResolver struct {
+	root   *resolver
+	review *domain.Review
+}
+
+func (r *reviewResolver) Pr(ctx context.Context) (*pullRequestResolver, error) {
+	pr, err := r.root.loadPullRequest(ctx, r.review.PullRequestID)
+	if err == nil && pr == nil {
+		err = notFound()
+	}
+	return pr, err
+}
+
+func (r *reviewResolver) Reviewer(ctx context.Context) (*userResolver, error) {
+	return r.root.requireUser(ctx, r.review.ReviewerID)
+}
+
+func (r *reviewResolver) AssignedAt() graphql.Time {
+	return graphql.Time{Time: r.review.AssignedAt}
+}
+
+func (r *reviewResolver) Verdict() *string {
+	if r.review.Verdict == nil {
+		return nil
+	}
+	verdict := string(*r.review.Verdict)
+	return &verdict
+}
+
+func (r *reviewResolver) VerdictAt() *graphql.Time {
+	return graphqlTime(r.review.VerdictAt)
+}
+
+type pullRequestResolver struct {
+	root *resolver
+	pr   *domain.PullRequest
+}
+
+func (p *pullRequestResolver) ID() graphql.ID {
+	return graphql.ID(p.pr.ID)
+}
+
+func (p *pullRequestResolver) Name() string {
+	return p.pr.Name
+}
+
+func (p *pullRequestResolver) Status() string {
+	return string(p.pr.Status)
+}
+
+func (p *pullRequestResolver) Author(ctx context.Context) (*userResolver, error) {
+	return p.root.requireUser(ctx, p.pr.AuthorID)
+}
+
+func (p *pullRequestResolver) Reviewers(ctx context.Context) ([]*userResolver, error) {
+	return p.root.loadUsers(ctx, p.pr.AssignedReviewers)
+}
+
+func (p *pullRequestResolver) Labels() []string {
+	if p.pr.Labels == nil {
+		return []string{}
+	}
+	return p.pr.Labels
+}
+
+func (p *pullRequestResolver) CreatedAt() graphql.Time {
+	return graphql.Time{Time: p.pr.CreatedAt}
+}
+
+func (p *pullRequestResolver) MergedAt() *graphql.Time {
+	return graphqlTime(p.pr.MergedAt)
+}
+
+func (p *pullRequestResolver) Version() int32 {
+	return int32(p.pr.Version)
+}
+
+// statsResolver читает только запрошенные разделы статистики
+type statsResolver struct {
+	root   *resolver
+	filter domain.StatsFilter
+}
+
+func (s *statsResolver) Users(ctx context.Context) ([]*userStatResolver, error) {
+	stats, err := s.root.stats.GetUserReviewStats(ctx, s.filter)
+	if err != nil {
+		return nil, s.root.toQueryError(ctx, err)
+	}
+	result := make([]*userStatResolver, 0, len(stats))
+	for _, stat := range stats {
+		result = append(result, &userStatResolver{root: s.root, stat: stat})
+	}
+	return result, nil
+}
+
+func (s *statsResolver) Teams(ctx context.Context) ([]*teamStatResolver, error) {
+	stats, err := s.root.stats.GetTeamReviewStats(ctx, s.filter)
+	if err != nil {
+		return nil, s.root.toQueryError(ctx, err)
+	}
+	result := make([]*teamStatResolver, 0, len(stats))
+	for _, stat := range stats {
+		result = append(result, &teamStatResolver{root: s.root, stat: stat})
+	}
+	return result, nil
+}
+
+type userStatResolver struct {
+	root *resolver
+	stat *domain.UserReviewStat
+}
+
+func (s *userStatResolver) User(ctx context.Context) (*userResolver, error) {
+	return s.root.requireUser(ctx, s.stat.UserID)
+}
+
+func (s *userStatResolver) ReviewCount() int32 {
+	return int32(s.stat.ReviewCount)
+}
+
+func (s *userStatResolver) OpenReviewCount() int32 {
+	return int32(s.stat.OpenReviewCount)
+}
+
+func (s *userStatResolver) ReviewCapacity() *int32 {
+	return int32Ptr(s.stat.ReviewCapacity)
+}
+
+func (s *userStatResolver) Utilization() *float64 {
+	return s.stat.Utilization()
+}
+
+type teamStatResolver struct {
+	root *resolver
+	stat *domain.TeamReviewStat
+}
+
+func (s *teamStatResolver) Team(ctx context.Context) (*teamResolver, error) {
+	return s.root.requireTeam(ctx, s.stat.TeamName)
+}
+
+func (s *teamStatResolver) PrsOpened() int32 {
+	return int32(s.stat.PRsOpened)
+}
+
+func (s *teamStatResolver) PrsMerged() int32 {
+	return int32(s.stat.PRsMerged)
+}
+
+func (s *teamStatResolver) AvgReviewersPerPr() float64 {
+	return s.stat.AvgReviewersPerPR
+}
+
+func (s *teamStatResolver) PrsWithoutReviewers() int32 {
+	return int32(s.stat.PRsWithoutReviewers)
+}
+
+func (s *teamStatResolver) ZeroReviewersShare() float64 {
+	return s.stat.ZeroReviewersShare()
+}
+
+func int32Ptr(v *int) *int32 {
+	if v == nil {
+		return nil
+	}
+	i := int32(*v)
+	return &i
+}
+
+func timePtr(t *graphql.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	return &t.Time
+}
+
+func graphqlTime(t *time.Time) *graphql.Time {
+	if t == nil {
+		return nil
+	}
+	return &graphql.Time{Time: *t}
+}
@@ -0,0 +1,194 @@
+package handler
+
+import (
+	"time"
+
+	"avito/internal/domain"
+	pb "avito/internal/transport/grpc/pb/reviewer/v1"
+
+	"github.com/google/uuid"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// invalidField возвращает InvalidArgument с описанием поля в деталях BadRequest,
+// как details у ответа VALIDATION_FAILED в HTTP API
+func invalidField(field, reason string) error {
+	st := status.New(codes.InvalidArgument, field+" "+reason)
+	detailed, err := st.WithDetails(&errdetails.BadRequest{
+		FieldViolations: []*errdetails.BadRequest_FieldViolation{{Field: field, Description: reason}},
+	})
+	if err != nil {
+		return st.Err()
+	}
+	return detailed.Err()
+}
+
+// parseUUID разбирает обязательный идентификатор из поля field запроса
+func parseUUID(field, value string) (uuid.UUID, error) {
+	if value == "" {
+		return uuid.Nil, invalidField(field, "is required")
+	}
+	id, err := uuid.Parse(value)
+	if err != nil {
+		return uuid.Nil, invalidField(field, "must be a UUID")
+	}
+	return id, nil
+}
+
+// requireString проверяет, что строковое поле field заполнено
+func requireString(field, value string) error {
+	if value == "" {
+		return invalidField(field, "is required")
+	}
+	return nil
+}
+
+func intPtr(v *int32) *int {
+	if v == nil {
+		return nil
+	}
+	i := int(*v)
+	return &i
+}
+
+func int32Ptr(v *int) *int32 {
+	if v == nil {
+		return nil
+	}
+	i := int32(*v)
+	return &i
+}
+
+func timePtr(ts *timestamppb.Timestamp) *time.Time {
+	if ts == nil {
+		return nil
+	}
+	t := ts.AsTime()
+	return &t
+}
+
+func timestamp(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return timestamppb.New(*t)
+}
+
+var roles = map[domain.Role]pb.Role{
+	domain.RoleAdmin:    pb.Role_ROLE_ADMIN,
+	domain.RoleTeamLead: pb.Role_ROLE_TEAM_LEAD,
+	domain.RoleMember:   pb.Role_ROLE_MEMBER,
+}
+
+// roleFromProto переводит роль из запроса. ROLE_UNSPECIFIED дает пустую роль, которую сервис заменяет на member
+func roleFromProto(role pb.Role) domain.Role {
+	for domainRole, protoRole := range roles {
+		if protoRole == role {
+			return domainRole
+		}
+	}
+	return ""
+}
+
+var statuses = map[domain.StatusPR]pb.PullRequestStatus{
+	domain.StatusOpen:   pb.PullRequestStatus_PULL_REQUEST_STATUS_OPEN,
+	domain.StatusMerged: pb.PullRequestStatus_PULL_REQUEST_STATUS_MERGED,
+}
+
+// statusFilter переводит статус из фильтра. PULL_REQUEST_STATUS_UNSPECIFIED отключает фильтр
+func statusFilter(st pb.PullRequestStatus) *domain.StatusPR {
+	for domainStatus, protoStatus := range statuses {
+		if protoStatus == st {
+			return &domainStatus
+		}
+	}
+	return nil
+}
+
+var verdicts = map[domain.Verdict]pb.Verdict{
+	domain.VerdictApproved:         pb.Verdict_VERDICT_APPROVED,
+	domain.VerdictChangesRequested: pb.Verdict_VERDICT_CHANGES_REQUESTED,
+}
+
+func verdictFromProto(verdict pb.Verdict) domain.Verdict {
+	for domainVerdict, protoVerdict := range verdicts {
+		if protoVerdict == verdict {
+			return domainVerdict
+		}
+	}
+	return ""
+}
+
+var eventTypes = map[domain.ReviewEventType]pb.ReviewEventType{
+	domain.EventReviewerAssigned:   pb.ReviewEventType_REVIEW_EVENT_TYPE_REVIEWER_ASSIGNED,
+	domain.EventReviewerReassigned: pb.ReviewEventType_REVIEW_EVENT_TYPE_REVIEWER_REASSIGNED,
+	domain.EventReviewReminder:     pb.ReviewEventType_REVIEW_EVENT_TYPE_REVIEW_REMINDER,
+	domain.EventReviewEscalated:    pb.ReviewEventType_REVIEW_EVENT_TYPE_REVIEW_ESCALATED,
+}
+
+func toTeam(team *domain.Team) *pb.Team {
+	members := make([]*pb.TeamMember, 0, len(team.Members))
+	for _, member := range team.Members {
+		members = append(members, &pb.TeamMember{
+			UserId:         member.ID.String(),
+			Username:       member.Username,
+			IsActive:       member.IsActive,
+			Role:           roles[member.Role],
+			ReviewCapacity: int32Ptr(member.ReviewCapacity),
+		})
+	}
+	return &pb.Team{
+		TeamName:              team.Name,
+		Members:               members,
+		DefaultReviewCapacity: int32Ptr(team.DefaultReviewCapacity),
+		ReviewSlaMinutes:      int32Ptr(team.ReviewSLAMinutes),
+		EscalationMinutes:     int32Ptr(team.EscalationMinutes),
+		Version:               team.Version,
+	}
+}
+
+func toUser(user *domain.User) *pb.User {
+	return &pb.User{
+		UserId:         user.ID.String(),
+		Username:       user.Username,
+		TeamName:       user.TeamName,
+		IsActive:       user.IsActive,
+		Role:           roles[user.Role],
+		Tags:           user.Tags,
+		ReviewCapacity: int32Ptr(user.ReviewCapacity),
+	}
+}
+
+func toPullRequest(pr *domain.PullRequest) *pb.PullRequest {
+	reviewers := make([]string, 0, len(pr.AssignedReviewers))
+	for _, id := range pr.AssignedReviewers {
+		reviewers = append(reviewers, id.String())
+	}
+	return &pb.PullRequest{
+		PullRequestId:     pr.ID,
+		PullRequestName:   pr.Name,
+		AuthorId:          pr.AuthorID.String(),
+		Status:            statuses[pr.Status],
+		AssignedReviewers: reviewers,
+		Labels:            pr.Labels,
+		CreatedAt:         timestamp(&pr.CreatedAt),
+		MergedAt:          timestamp(pr.MergedAt),
+		Version:           pr.Version,
+	}
+}
+
+func toReviewEvent(event domain.ReviewEvent) *pb.ReviewEvent {
+	msg := &pb.ReviewEvent{
+		Type:          eventTypes[event.Type],
+		PullRequestId: event.PullRequestID,
+		ReviewerId:    event.ReviewerID.String(),
+		OccurredAt:    timestamppb.New(event.OccurredAt),
+	}
+	if event.NewReviewerID != nil {
+		msg.NewReviewerId = event.NewReviewerID.String()
+	}
+	return msg
+}
@@ -0,0 +1,33 @@
+// Package handler реализует сервисы gRPC API поверх тех же сервисов предметной области, что и HTTP API.
+// Обработчики возвращают ошибки сервисов как есть: коды gRPC им назначает interceptor.Errors
+package handler
+
+import (
+	"avito/internal/domain"
+	"avito/internal/service"
+	pb "avito/internal/transport/grpc/pb/reviewer/v1"
+
+	"google.golang.org/grpc"
+)
+
+// EventSubscriber подписывает на события назначения ревьюеров
+type EventSubscriber interface {
+	Subscribe() (<-chan domain.ReviewEvent, func())
+}
+
+// Services - сервисы, к которым обращается gRPC API
+type Services struct {
+	Teams        *service.TeamService
+	Users        *service.UserService
+	PullRequests *service.PullRequestService
+	Stats        *service.StatsService
+	Events       EventSubscriber
+}
+
+// Register регистрирует реализации TeamService, UserService, PullRequestService и StatsService на сервере
+func Register(s grpc.ServiceRegistrar, services Services) {
+	pb.RegisterTeamServiceServer(s, &teamServer{teams: services.Teams})
+	pb.RegisterUserServiceServer(s, &userServer{users: services.Users})
+	pb.RegisterPullRequestServiceServer(s, &pullRequestServer{prs: services.PullRequests, events: services.Events})
+	pb.RegisterStatsServiceServer(s, &statsServer{stats: services.Stats})
+}
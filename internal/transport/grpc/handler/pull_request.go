@@ -0,0 +1,172 @@
+package handler
+
+import (
+	"context"
+
+	"avito/internal/domain"
+	"avito/internal/service"
+	pb "avito/internal/transport/grpc/pb/reviewer/v1"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type pullRequestServer struct {
+	pb.UnimplementedPullRequestServiceServer
+	prs    *service.PullRequestService
+	events EventSubscriber
+}
+
+func (s *pullRequestServer) CreatePullRequest(ctx context.Context, req *pb.CreatePullRequestRequest) (*pb.CreatePullRequestResponse, error) {
+	if err := requireString("pull_request_id", req.GetPullRequestId()); err != nil {
+		return nil, err
+	}
+	if err := requireString("pull_request_name", req.GetPullRequestName()); err != nil {
+		return nil, err
+	}
+	authorID, err := parseUUID("author_id", req.GetAuthorId())
+	if err != nil {
+		return nil, err
+	}
+	pr, report, err := s.prs.CreatePR(ctx, req.GetPullRequestId(), req.GetPullRequestName(), authorID, req.GetLabels())
+	if err != nil {
+		return nil, err
+	}
+	return &pb.CreatePullRequestResponse{
+		PullRequest: toPullRequest(pr),
+		Assignment: &pb.AssignmentReport{
+			Required:          int32(report.Required),
+			Assigned:          int32(report.Assigned),
+			SkippedAtCapacity: int32(report.SkippedAtCapacity),
+		},
+	}, nil
+}
+
+func (s *pullRequestServer) GetPullRequest(ctx context.Context, req *pb.GetPullRequestRequest) (*pb.PullRequest, error) {
+	if err := requireString("pull_request_id", req.GetPullRequestId()); err != nil {
+		return nil, err
+	}
+	pr, err := s.prs.GetPullRequest(ctx, req.GetPullRequestId())
+	if err != nil {
+		return nil, err
+	}
+	return toPullRequest(pr), nil
+}
+
+func (s *pullRequestServer) ListPullRequests(req *pb.ListPullRequestsRequest, stream grpc.ServerStreamingServer[pb.PullRequest]) error {
+	filter := domain.PullRequestFilter{
+		From:     timePtr(req.GetFrom()),
+		To:       timePtr(req.GetTo()),
+		TeamName: req.GetTeamName(),
+		Status:   statusFilter(req.GetStatus()),
+	}
+	if req.GetAuthorId() != "" {
+		authorID, err := parseUUID("author_id", req.GetAuthorId())
+		if err != nil {
+			return err
+		}
+		filter.AuthorID = &authorID
+	}
+	return s.prs.StreamPullRequests(stream.Context(), filter, func(pr *domain.PullRequest) error {
+		return stream.Send(toPullRequest(pr))
+	})
+}
+
+func (s *pullRequestServer) MergePullRequest(ctx context.Context, req *pb.MergePullRequestRequest) (*pb.PullRequest, error) {
+	if err := requireString("pull_request_id", req.GetPullRequestId()); err != nil {
+		return nil, err
+	}
+	pr, err := s.prs.SetMerge(ctx, req.GetPullRequestId(), req.ExpectedVersion)
+	if err != nil {
+		return nil, err
+	}
+	return toPullRequest(pr), nil
+}
+
+func (s *pullRequestServer) ReassignReviewer(ctx context.Context, req *pb.ReassignReviewerRequest) (*pb.ReassignReviewerResponse, error) {
+	if err := requireString("pull_request_id", req.GetPullRequestId()); err != nil {
+		return nil, err
+	}
+	oldReviewerID, err := parseUUID("old_reviewer_id", req.GetOldReviewerId())
+	if err != nil {
+		return nil, err
+	}
+	pr, replacedBy, err := s.prs.ReassignmentReviewers(ctx, req.GetPullRequestId(), oldReviewerID, req.ExpectedVersion)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.ReassignReviewerResponse{PullRequest: toPullRequest(pr), ReplacedBy: replacedBy}, nil
+}
+
+func (s *pullRequestServer) SubmitVerdict(ctx context.Context, req *pb.SubmitVerdictRequest) (*pb.SubmitVerdictResponse, error) {
+	if err := requireString("pull_request_id", req.GetPullRequestId()); err != nil {
+		return nil, err
+	}
+	// Пустой reviewer_id означает вызывающего пользователя, его подставляет сервис
+	var reviewerID uuid.UUID
+	if req.GetReviewerId() != "" {
+		id, err := parseUUID("reviewer_id", req.GetReviewerId())
+		if err != nil {
+			return nil, err
+		}
+		reviewerID = id
+	}
+	verdict, err := s.prs.SubmitVerdict(ctx, req.GetPullRequestId(), reviewerID, verdictFromProto(req.GetVerdict()))
+	if err != nil {
+		return nil, err
+	}
+	return &pb.SubmitVerdictResponse{
+		PullRequestId: verdict.PullRequestID,
+		ReviewerId:    verdict.ReviewerID.String(),
+		Verdict:       verdicts[verdict.Verdict],
+		SubmittedAt:   timestamppb.New(verdict.SubmittedAt),
+	}, nil
+}
+
+// WatchReviewEvents передает события шины до отмены вызова клиентом или остановки сервера
+func (s *pullRequestServer) WatchReviewEvents(req *pb.WatchReviewEventsRequest, stream grpc.ServerStreamingServer[pb.ReviewEvent]) error {
+	var reviewerID *uuid.UUID
+	if req.GetReviewerId() != "" {
+		id, err := parseUUID("reviewer_id", req.GetReviewerId())
+		if err != nil {
+			return err
+		}
+		reviewerID = &id
+	}
+	events, unsubscribe := s.events.Subscribe()
+	defer unsubscribe()
+	// Заголовки отправляются сразу после подписки: получив их, клиент знает, что не пропустит следующие события
+	if err := stream.SendHeader(nil); err != nil {
+		return err
+	}
+
+	ctx := stream.Context()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case event, ok := <-events:
+			if !ok {
+				return nil
+			}
+			if !matchesEvent(req.GetPullRequestId(), reviewerID, event) {
+				continue
+			}
+			if err := stream.Send(toReviewEvent(event)); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// matchesEvent проверяет событие по фильтру подписки. Ревьюер совпадает и с прежним, и с новым ревьюером переназначения
+func matchesEvent(prID string, reviewerID *uuid.UUID, event domain.ReviewEvent) bool {
+	if prID != "" && event.PullRequestID != prID {
+		return false
+	}
+	if reviewerID == nil {
+		return true
+	}
+	return event.ReviewerID == *reviewerID || (event.NewReviewerID != nil && *event.NewReviewerID == *reviewerID)
+}
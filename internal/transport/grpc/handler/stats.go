@@ -0,0 +1,62 @@
+package handler
+
+import (
+	"context"
+
+	"avito/internal/domain"
+	"avito/internal/service"
+	pb "avito/internal/transport/grpc/pb/reviewer/v1"
+)
+
+type statsServer struct {
+	pb.UnimplementedStatsServiceServer
+	stats *service.StatsService
+}
+
+func (s *statsServer) GetUserReviewStats(ctx context.Context, req *pb.StatsRequest) (*pb.UserReviewStatsResponse, error) {
+	stats, err := s.stats.GetUserReviewStats(ctx, statsFilter(req))
+	if err != nil {
+		return nil, err
+	}
+	resp := &pb.UserReviewStatsResponse{Stats: make([]*pb.UserReviewStat, 0, len(stats))}
+	for _, stat := range stats {
+		resp.Stats = append(resp.Stats, &pb.UserReviewStat{
+			UserId:          stat.UserID.String(),
+			Username:        stat.Username,
+			IsActive:        stat.IsActive,
+			ReviewCount:     int32(stat.ReviewCount),
+			OpenReviewCount: int32(stat.OpenReviewCount),
+			ReviewCapacity:  int32Ptr(stat.ReviewCapacity),
+			Utilization:     stat.Utilization(),
+		})
+	}
+	return resp, nil
+}
+
+func (s *statsServer) GetTeamReviewStats(ctx context.Context, req *pb.StatsRequest) (*pb.TeamReviewStatsResponse, error) {
+	stats, err := s.stats.GetTeamReviewStats(ctx, statsFilter(req))
+	if err != nil {
+		return nil, err
+	}
+	resp := &pb.TeamReviewStatsResponse{Stats: make([]*pb.TeamReviewStat, 0, len(stats))}
+	for _, stat := range stats {
+		resp.Stats = append(resp.Stats, &pb.TeamReviewStat{
+			TeamName:            stat.TeamName,
+			PrsOpened:           int32(stat.PRsOpened),
+			PrsMerged:           int32(stat.PRsMerged),
+			AvgReviewersPerPr:   stat.AvgReviewersPerPR,
+			PrsWithoutReviewers: int32(stat.PRsWithoutReviewers),
+			ZeroReviewersShare:  stat.ZeroReviewersShare(),
+		})
+	}
+	return resp, nil
+}
+
+func statsFilter(req *pb.StatsRequest) domain.StatsFilter {
+	return domain.StatsFilter{
+		From:     timePtr(req.GetFrom()),
+		To:       timePtr(req.GetTo()),
+		TeamName: req.GetTeamName(),
+		Status:   statusFilter(req.GetStatus()),
+	}
+}
@@ -0,0 +1,89 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"avito/internal/domain"
+	"avito/internal/service"
+	pb "avito/internal/transport/grpc/pb/reviewer/v1"
+)
+
+type teamServer struct {
+	pb.UnimplementedTeamServiceServer
+	teams *service.TeamService
+}
+
+func (s *teamServer) CreateTeam(ctx context.Context, req *pb.CreateTeamRequest) (*pb.Team, error) {
+	if strings.TrimSpace(req.GetTeamName()) == "" {
+		return nil, invalidField("team_name", "must not be blank")
+	}
+	members := make([]domain.User, 0, len(req.GetMembers()))
+	seen := make(map[string]bool, len(req.GetMembers()))
+	for i, member := range req.GetMembers() {
+		field := fmt.Sprintf("members[%d]", i)
+		id, err := parseUUID(field+".user_id", member.GetUserId())
+		if err != nil {
+			return nil, err
+		}
+		if seen[id.String()] {
+			return nil, invalidField("members", "must not contain duplicates")
+		}
+		seen[id.String()] = true
+		if strings.TrimSpace(member.GetUsername()) == "" {
+			return nil, invalidField(field+".username", "must not be blank")
+		}
+		members = append(members, domain.User{
+			ID:             id,
+			Username:       member.GetUsername(),
+			IsActive:       member.GetIsActive(),
+			Role:           roleFromProto(member.GetRole()),
+			ReviewCapacity: intPtr(member.ReviewCapacity),
+		})
+	}
+	team, err := s.teams.CreateTeamWithMembers(ctx, domain.Team{
+		Name:                  req.GetTeamName(),
+		Members:               members,
+		DefaultReviewCapacity: intPtr(req.DefaultReviewCapacity),
+		ReviewSLAMinutes:      intPtr(req.ReviewSlaMinutes),
+		EscalationMinutes:     intPtr(req.EscalationMinutes),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toTeam(team), nil
+}
+
+func (s *teamServer) GetTeam(ctx context.Context, req *pb.GetTeamRequest) (*pb.Team, error) {
+	if err := requireString("team_name", req.GetTeamName()); err != nil {
+		return nil, err
+	}
+	team, err := s.teams.GetTeamByName(ctx, req.GetTeamName())
+	if err != nil {
+		return nil, err
+	}
+	return toTeam(team), nil
+}
+
+func (s *teamServer) SetTeamReviewCapacity(ctx context.Context, req *pb.SetTeamReviewCapacityRequest) (*pb.Team, error) {
+	if err := requireString("team_name", req.GetTeamName()); err != nil {
+		return nil, err
+	}
+	team, err := s.teams.SetDefaultReviewCapacity(ctx, req.GetTeamName(), intPtr(req.DefaultReviewCapacity), req.ExpectedVersion)
+	if err != nil {
+		return nil, err
+	}
+	return toTeam(team), nil
+}
+
+func (s *teamServer) SetTeamReviewSLA(ctx context.Context, req *pb.SetTeamReviewSLARequest) (*pb.Team, error) {
+	if err := requireString("team_name", req.GetTeamName()); err != nil {
+		return nil, err
+	}
+	team, err := s.teams.SetReviewSLA(ctx, req.GetTeamName(), intPtr(req.ReviewSlaMinutes), intPtr(req.EscalationMinutes), req.ExpectedVersion)
+	if err != nil {
+		return nil, err
+	}
+	return toTeam(team), nil
+}
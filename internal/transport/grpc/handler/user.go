@@ -0,0 +1,53 @@
+package handler
+
+import (
+	"context"
+
+	"avito/internal/service"
+	pb "avito/internal/transport/grpc/pb/reviewer/v1"
+)
+
+type userServer struct {
+	pb.UnimplementedUserServiceServer
+	users *service.UserService
+}
+
+func (s *userServer) GetUser(ctx context.Context, req *pb.GetUserRequest) (*pb.User, error) {
+	id, err := parseUUID("user_id", req.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+	user, err := s.users.GetUserByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return toUser(user), nil
+}
+
+func (s *userServer) SetUserActive(ctx context.Context, req *pb.SetUserActiveRequest) (*pb.User, error) {
+	id, err := parseUUID("user_id", req.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+	user, err := s.users.SetIsActive(ctx, id, req.GetIsActive(), req.ExpectedTeamVersion)
+	if err != nil {
+		return nil, err
+	}
+	return toUser(user), nil
+}
+
+func (s *userServer) ListUserReviews(ctx context.Context, req *pb.ListUserReviewsRequest) (*pb.ListUserReviewsResponse, error) {
+	id, err := parseUUID("user_id", req.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+	prs, err := s.users.GetReviewsForUser(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	resp := &pb.ListUserReviewsResponse{UserId: id.String(), PullRequests: make([]*pb.PullRequest, 0, len(prs))}
+	for _, pr := range prs {
+		resp.PullRequests = append(resp.PullRequests, toPullRequest(pr))
+	}
+	return resp, nil
+}
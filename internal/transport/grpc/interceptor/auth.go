@@ -0,0 +1,209 @@
+package interceptor
+
+import (
+	"context"
+	"errors"
+	"go.uber.org/zap"
+	"net/http"
+	"strings"
+
+	"avito/internal/auth"
+	"avito/internal/domain"
+	"avito/pkg/logger"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// APIKeyMetadata - ключ метаданных с ключом доступа к API, как заголовок X-API-Key в HTTP API
+const APIKeyMetadata = "x-api-key"
+
+// Authenticator проверяет ключ доступа и возвращает вызывающего
+type Authenticator interface {
+	Authenticate(ctx context.Context, apiKey string) (*domain.Principal, error)
+}
+
+// TokenAuthenticator проверяет bearer JWT и возвращает вызывающего
+type TokenAuthenticator interface {
+	AuthenticateToken(ctx context.Context, token string) (*domain.Principal, error)
+}
+
+// AuditRecorder сохраняет записи журнала аудита
+type AuditRecorder interface {
+	Record(ctx context.Context, event domain.AuditEvent) error
+}
+
+// Security - аутентификация вызовов. При Enabled=false вызовы выполняются от анонимного администратора
+type Security struct {
+	Enabled       bool
+	Authenticator Authenticator
+	// Tokens проверяет bearer JWT, nil отключает аутентификацию по JWT
+	Tokens TokenAuthenticator
+	Audit  AuditRecorder
+}
+
+// MethodRule - право, которое требуется для метода, и нужно ли записывать вызов в журнал аудита
+type MethodRule struct {
+	// Scope пустой, если права проверяет сервис (например, смена собственной активности)
+	Scope    domain.Scope
+	Mutating bool
+}
+
+// AuthUnary пропускает вызовы с действующим ключом в x-api-key или JWT в authorization: Bearer и нужным правом.
+// Методы без правила в rules отклоняются. Изменяющие вызовы записываются в журнал аудита с итоговым кодом
+func AuthUnary(security Security, rules map[string]MethodRule) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		var resp any
+		err := authorize(ctx, security, rules, info.FullMethod, func(ctx context.Context) error {
+			var err error
+			resp, err = handler(ctx, req)
+			return err
+		})
+		return resp, err
+	}
+}
+
+// AuthStream - AuthUnary для потоковых вызовов
+func AuthStream(security Security, rules map[string]MethodRule) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return authorize(ss.Context(), security, rules, info.FullMethod, func(ctx context.Context) error {
+			return handler(srv, &serverStream{ServerStream: ss, ctx: ctx})
+		})
+	}
+}
+
+func authorize(ctx context.Context, security Security, rules map[string]MethodRule, method string, call func(context.Context) error) error {
+	log := callLogger(ctx)
+	rule, known := rules[method]
+
+	principal, err := authenticate(ctx, log, security)
+	if err == nil && !known {
+		log.Warn("Method has no access rule")
+		err = status.Error(codes.PermissionDenied, "method is not available")
+	}
+	if err == nil && rule.Scope != "" && !principal.HasScope(rule.Scope) {
+		log.Warn("Insufficient scope", zap.String("required_scope", string(rule.Scope)))
+		err = status.Error(codes.PermissionDenied, "caller lacks required scope "+string(rule.Scope))
+	}
+	if err == nil {
+		fields := []zap.Field{zap.String("principal_type", string(principal.Type)), zap.String("principal_id", principal.ID), zap.String("principal_role", string(principal.Role))}
+		ctx = logger.WithFields(auth.WithPrincipal(ctx, *principal), fields...)
+		ctx = context.WithValue(ctx, loggerKey{}, log.With(fields...))
+		err = call(ctx)
+	}
+
+	if rule.Mutating && security.Audit != nil {
+		recordAudit(ctx, security.Audit, method, principal, err)
+	}
+	return err
+}
+
+func authenticate(ctx context.Context, log *zap.Logger, security Security) (*domain.Principal, error) {
+	if !security.Enabled {
+		return &domain.Principal{
+			Type:   domain.PrincipalAnonymous,
+			ID:     string(domain.PrincipalAnonymous),
+			Scopes: []domain.Scope{domain.ScopeAdmin},
+			Role:   domain.RoleAdmin,
+		}, nil
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	var principal *domain.Principal
+	var err error
+	credential := "API key"
+	if token, ok := bearerToken(md.Get("authorization")); ok {
+		credential = "bearer token"
+		if security.Tokens == nil {
+			log.Warn("Bearer token used while JWT authentication is disabled")
+			return nil, status.Error(codes.Unauthenticated, "bearer tokens are not accepted")
+		}
+		principal, err = security.Tokens.AuthenticateToken(ctx, token)
+	} else if keys := md.Get(APIKeyMetadata); len(keys) > 0 && keys[0] != "" {
+		principal, err = security.Authenticator.Authenticate(ctx, keys[0])
+	} else {
+		log.Warn("Request without credentials")
+		return nil, status.Error(codes.Unauthenticated, "missing API key or bearer token")
+	}
+
+	if err != nil {
+		if errors.Is(err, domain.ErrUnauthorized) {
+			log.Warn("Invalid credentials", zap.String("credential", credential))
+			return nil, status.Error(codes.Unauthenticated, "invalid "+credential)
+		}
+		log.Error("Failed to authenticate request", zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to authenticate request")
+	}
+	return principal, nil
+}
+
+// bearerToken извлекает токен из метаданных authorization со схемой Bearer
+func bearerToken(values []string) (string, bool) {
+	const scheme = "bearer "
+	if len(values) == 0 || len(values[0]) <= len(scheme) || !strings.EqualFold(values[0][:len(scheme)], scheme) {
+		return "", false
+	}
+	token := strings.TrimSpace(values[0][len(scheme):])
+	return token, token != ""
+}
+
+// recordAudit записывает изменяющий вызов в тот же журнал, что и HTTP API. Status содержит
+// статус HTTP, соответствующий коду gRPC, чтобы выборки по статусу работали для обоих транспортов
+func recordAudit(ctx context.Context, recorder AuditRecorder, method string, principal *domain.Principal, callErr error) {
+	code := status.Code(callErr)
+	event := domain.AuditEvent{
+		Action:    "GRPC " + method,
+		Status:    httpStatus(code),
+		RequestID: requestID(ctx),
+		Details: map[string]any{
+			"grpc_code": code.String(),
+		},
+	}
+	if p, ok := peer.FromContext(ctx); ok {
+		event.Details["remote_addr"] = p.Addr.String()
+	}
+	if principal != nil {
+		event.PrincipalType = principal.Type
+		event.PrincipalID = principal.ID
+		event.PrincipalName = principal.Name
+	}
+
+	// Запись не должна теряться, если клиент уже отменил вызов
+	if err := recorder.Record(context.WithoutCancel(ctx), event); err != nil {
+		callLogger(ctx).Error("Failed to record audit event", zap.Error(err))
+	}
+}
+
+// httpStatus сопоставляет коды gRPC статусам HTTP по таблице из документации gRPC
+func httpStatus(code codes.Code) int {
+	switch code {
+	case codes.OK:
+		return http.StatusOK
+	case codes.InvalidArgument, codes.OutOfRange, codes.FailedPrecondition:
+		return http.StatusBadRequest
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.AlreadyExists, codes.Aborted:
+		return http.StatusConflict
+	case codes.ResourceExhausted:
+		return http.StatusTooManyRequests
+	case codes.Canceled:
+		// Нестандартный статус "клиент закрыл запрос", как у nginx и grpc-gateway
+		return 499
+	case codes.DeadlineExceeded:
+		return http.StatusGatewayTimeout
+	case codes.Unimplemented:
+		return http.StatusNotImplemented
+	case codes.Unavailable:
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusInternalServerError
+	}
+}
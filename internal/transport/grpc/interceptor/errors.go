@@ -0,0 +1,93 @@
+package interceptor
+
+import (
+	"context"
+	"errors"
+	"go.uber.org/zap"
+	"strings"
+
+	"avito/internal/domain"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ErrorDomain - домен ErrorInfo в деталях ошибки. Reason содержит код ошибки предметной области,
+// тот же, что поле code в ответах HTTP API
+const ErrorDomain = "reviewer.v1"
+
+// errorCodes переводит коды ошибок предметной области в коды gRPC. Код без записи получает InvalidArgument
+var errorCodes = map[domain.ErrorCode]codes.Code{
+	domain.CodeNotFound:        codes.NotFound,
+	domain.CodeTeamExists:      codes.AlreadyExists,
+	domain.CodePRExists:        codes.AlreadyExists,
+	domain.CodePRMerged:        codes.FailedPrecondition,
+	domain.CodeNoCandidate:     codes.FailedPrecondition,
+	domain.CodeNotAssigned:     codes.FailedPrecondition,
+	domain.CodeAuthorInactive:  codes.FailedPrecondition,
+	domain.CodeForbidden:       codes.PermissionDenied,
+	domain.CodeUnauthorized:    codes.Unauthenticated,
+	domain.CodeVersionConflict: codes.Aborted,
+}
+
+// ErrorsUnary переводит ошибки обработчиков в статусы gRPC. Ошибки, уже ставшие статусом, не меняются
+func ErrorsUnary() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		resp, err := handler(ctx, req)
+		return resp, toStatus(ctx, err)
+	}
+}
+
+// ErrorsStream - ErrorsUnary для потоковых вызовов
+func ErrorsStream() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return toStatus(ss.Context(), handler(srv, ss))
+	}
+}
+
+// toStatus отдает ошибку предметной области с ее кодом и сообщением, а остальные ошибки
+// логирует как внутренние и скрывает от клиента
+func toStatus(ctx context.Context, err error) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	switch {
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, "request canceled")
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, "request deadline exceeded")
+	}
+
+	log := callLogger(ctx)
+	domainErr, ok := domain.AsError(err)
+	if !ok {
+		log.Error("Request failed", zap.Error(err))
+		return status.Error(codes.Internal, "internal error")
+	}
+	code, ok := errorCodes[domainErr.Code]
+	if !ok {
+		code = codes.InvalidArgument
+	}
+	log.Warn("Request rejected", zap.String("code", string(domainErr.Code)), zap.Error(err))
+
+	st := status.New(code, domainMessage(err, domainErr))
+	detailed, detailsErr := st.WithDetails(&errdetails.ErrorInfo{Reason: string(domainErr.Code), Domain: ErrorDomain})
+	if detailsErr != nil {
+		return st.Err()
+	}
+	return detailed.Err()
+}
+
+// domainMessage оставляет уточнение, которое сервис добавил после ошибки ("%w: причина"),
+// и отбрасывает контекст вызовов перед ней ("failed to ...: %w"), чтобы он не попадал к клиенту
+func domainMessage(err error, domainErr *domain.Error) string {
+	if message := err.Error(); strings.HasPrefix(message, domainErr.Message) {
+		return message
+	}
+	return domainErr.Message
+}
@@ -0,0 +1,124 @@
+// Package interceptor содержит перехватчики gRPC-сервера: журналирование вызовов, аутентификацию
+// с проверкой прав и журналом аудита и перевод ошибок предметной области в коды gRPC.
+// Порядок подключения: Logging, Auth, Errors - тогда журнал и аудит видят уже переведенный код
+package interceptor
+
+import (
+	"context"
+	"go.uber.org/zap"
+	"time"
+
+	"avito/pkg/logger"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// RequestIDMetadata - ключ метаданных, в котором клиент может передать идентификатор запроса
+	RequestIDMetadata = "x-request-id"
+	// maxRequestIDLength ограничивает длину идентификатора от клиента, чтобы он не раздувал логи
+	maxRequestIDLength = 128
+)
+
+type loggerKey struct{}
+
+type requestIDKey struct{}
+
+// LoggingUnary журналирует начало и завершение вызова с кодом и длительностью.
+// Идентификатор запроса берется из x-request-id или генерируется и возвращается клиенту в заголовке ответа
+func LoggingUnary(log *zap.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		ctx, requestID, callLog := startCall(ctx, log, info.FullMethod)
+		if err := grpc.SetHeader(ctx, metadata.Pairs(RequestIDMetadata, requestID)); err != nil {
+			callLog.Warn("Failed to set request ID header", zap.Error(err))
+		}
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		finishCall(callLog, start, err)
+		return resp, err
+	}
+}
+
+// LoggingStream - LoggingUnary для потоковых вызовов
+func LoggingStream(log *zap.Logger) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, requestID, callLog := startCall(ss.Context(), log, info.FullMethod)
+		if err := ss.SetHeader(metadata.Pairs(RequestIDMetadata, requestID)); err != nil {
+			callLog.Warn("Failed to set request ID header", zap.Error(err))
+		}
+		start := time.Now()
+		err := handler(srv, &serverStream{ServerStream: ss, ctx: ctx})
+		finishCall(callLog, start, err)
+		return err
+	}
+}
+
+// startCall сохраняет в контексте идентификатор запроса и логгер вызова, чтобы логи сервисов содержали request_id
+func startCall(ctx context.Context, log *zap.Logger, method string) (context.Context, string, *zap.Logger) {
+	requestID := incomingRequestID(ctx)
+	ctx = logger.WithFields(ctx, zap.String("request_id", requestID))
+	fields := []zap.Field{zap.String("grpc_method", method)}
+	if p, ok := peer.FromContext(ctx); ok {
+		fields = append(fields, zap.String("remote_addr", p.Addr.String()))
+	}
+	callLog := logger.FromContext(ctx, log).With(fields...)
+	callLog.Info("Request started")
+
+	ctx = context.WithValue(ctx, requestIDKey{}, requestID)
+	return context.WithValue(ctx, loggerKey{}, callLog), requestID, callLog
+}
+
+func finishCall(log *zap.Logger, start time.Time, err error) {
+	log.Info("Request completed",
+		zap.String("code", status.Code(err).String()),
+		zap.Duration("latency", time.Since(start)),
+	)
+}
+
+// incomingRequestID принимает идентификатор клиента из печатных ASCII-символов разумной длины или генерирует новый
+func incomingRequestID(ctx context.Context) string {
+	md, _ := metadata.FromIncomingContext(ctx)
+	if values := md.Get(RequestIDMetadata); len(values) > 0 && validRequestID(values[0]) {
+		return values[0]
+	}
+	return uuid.NewString()
+}
+
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if id[i] < 0x21 || id[i] > 0x7e {
+			return false
+		}
+	}
+	return true
+}
+
+// callLogger возвращает логгер вызова, сохраненный LoggingUnary или LoggingStream
+func callLogger(ctx context.Context) *zap.Logger {
+	if log, ok := ctx.Value(loggerKey{}).(*zap.Logger); ok {
+		return log
+	}
+	return zap.NewNop()
+}
+
+func requestID(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+// serverStream подменяет контекст потока, чтобы обработчик получил контекст с логгером и вызывающим
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,6 @@
+// Package pb содержит код, сгенерированный из internal/transport/grpc/proto. Файлы в reviewer/v1 не редактируются
+// вручную: после изменения .proto их нужно перегенерировать командой go generate ./internal/transport/grpc/pb
+// (нужны protoc, protoc-gen-go и protoc-gen-go-grpc)
+package pb
+
+//go:generate protoc -I ../proto --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative reviewer/v1/models.proto reviewer/v1/team.proto reviewer/v1/user.proto reviewer/v1/pull_request.proto reviewer/v1/stats.proto
@@ -0,0 +1,1034 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.9
+// 	protoc        (unknown)
+// source: reviewer/v1/pull_request.proto
+
+package reviewerv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Verdict int32
+
+const (
+	Verdict_VERDICT_UNSPECIFIED       Verdict = 0
+	Verdict_VERDICT_APPROVED          Verdict = 1
+	Verdict_VERDICT_CHANGES_REQUESTED Verdict = 2
+)
+
+// Enum value maps for Verdict.
+var (
+	Verdict_name = map[int32]string{
+		0: "VERDICT_UNSPECIFIED",
+		1: "VERDICT_APPROVED",
+		2: "VERDICT_CHANGES_REQUESTED",
+	}
+	Verdict_value = map[string]int32{
+		"VERDICT_UNSPECIFIED":       0,
+		"VERDICT_APPROVED":          1,
+		"VERDICT_CHANGES_REQUESTED": 2,
+	}
+)
+
+func (x Verdict) Enum() *Verdict {
+	p := new(Verdict)
+	*p = x
+	return p
+}
+
+func (x Verdict) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Verdict) Descriptor() protoreflect.EnumDescriptor {
+	return file_reviewer_v1_pull_request_proto_enumTypes[0].Descriptor()
+}
+
+func (Verdict) Type() protoreflect.EnumType {
+	return &file_reviewer_v1_pull_request_proto_enumTypes[0]
+}
+
+func (x Verdict) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Verdict.Descriptor instead.
+func (Verdict) EnumDescriptor() ([]byte, []int) {
+	return file_reviewer_v1_pull_request_proto_rawDescGZIP(), []int{0}
+}
+
+type ReviewEventType int32
+
+const (
+	ReviewEventType_REVIEW_EVENT_TYPE_UNSPECIFIED ReviewEventType = 0
+	// Ревьюер назначен на новый PR
+	ReviewEventType_REVIEW_EVENT_TYPE_REVIEWER_ASSIGNED ReviewEventType = 1
+	// Ревью передано другому ревьюеру вручную или при уходе ревьюера
+	ReviewEventType_REVIEW_EVENT_TYPE_REVIEWER_REASSIGNED ReviewEventType = 2
+	// Ревьюеру отправлено напоминание по истечении SLA команды
+	ReviewEventType_REVIEW_EVENT_TYPE_REVIEW_REMINDER ReviewEventType = 3
+	// Ревью переназначено по истечении порога эскалации
+	ReviewEventType_REVIEW_EVENT_TYPE_REVIEW_ESCALATED ReviewEventType = 4
+)
+
+// Enum value maps for ReviewEventType.
+var (
+	ReviewEventType_name = map[int32]string{
+		0: "REVIEW_EVENT_TYPE_UNSPECIFIED",
+		1: "REVIEW_EVENT_TYPE_REVIEWER_ASSIGNED",
+		2: "REVIEW_EVENT_TYPE_REVIEWER_REASSIGNED",
+		3: "REVIEW_EVENT_TYPE_REVIEW_REMINDER",
+		4: "REVIEW_EVENT_TYPE_REVIEW_ESCALATED",
+	}
+	ReviewEventType_value = map[string]int32{
+		"REVIEW_EVENT_TYPE_UNSPECIFIED":         0,
+		"REVIEW_EVENT_TYPE_REVIEWER_ASSIGNED":   1,
+		"REVIEW_EVENT_TYPE_REVIEWER_REASSIGNED": 2,
+		"REVIEW_EVENT_TYPE_REVIEW_REMINDER":     3,
+		"REVIEW_EVENT_TYPE_REVIEW_ESCALATED":    4,
+	}
+)
+
+func (x ReviewEventType) Enum() *ReviewEventType {
+	p := new(ReviewEventType)
+	*p = x
+	return p
+}
+
+func (x ReviewEventType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ReviewEventType) Descriptor() protoreflect.EnumDescriptor {
+	return file_reviewer_v1_pull_request_proto_enumTypes[1].Descriptor()
+}
+
+func (ReviewEventType) Type() protoreflect.EnumType {
+	return &file_reviewer_v1_pull_request_proto_enumTypes[1]
+}
+
+func (x ReviewEventType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ReviewEventType.Descriptor instead.
+func (ReviewEventType) EnumDescriptor() ([]byte, []int) {
+	return file_reviewer_v1_pull_request_proto_rawDescGZIP(), []int{1}
+}
+
+type CreatePullRequestRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	PullRequestId   string                 `protobuf:"bytes,1,opt,name=pull_request_id,json=pullRequestId,proto3" json:"pull_request_id,omitempty"`
+	PullRequestName string                 `protobuf:"bytes,2,opt,name=pull_request_name,json=pullRequestName,proto3" json:"pull_request_name,omitempty"`
+	AuthorId        string                 `protobuf:"bytes,3,opt,name=author_id,json=authorId,proto3" json:"author_id,omitempty"`
+	Labels          []string               `protobuf:"bytes,4,rep,name=labels,proto3" json:"labels,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *CreatePullRequestRequest) Reset() {
+	*x = CreatePullRequestRequest{}
+	mi := &file_reviewer_v1_pull_request_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreatePullRequestRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreatePullRequestRequest) ProtoMessage() {}
+
+func (x *CreatePullRequestRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_reviewer_v1_pull_request_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreatePullRequestRequest.ProtoReflect.Descriptor instead.
+func (*CreatePullRequestRequest) Descriptor() ([]byte, []int) {
+	return file_reviewer_v1_pull_request_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *CreatePullRequestRequest) GetPullRequestId() string {
+	if x != nil {
+		return x.PullRequestId
+	}
+	return ""
+}
+
+func (x *CreatePullRequestRequest) GetPullRequestName() string {
+	if x != nil {
+		return x.PullRequestName
+	}
+	return ""
+}
+
+func (x *CreatePullRequestRequest) GetAuthorId() string {
+	if x != nil {
+		return x.AuthorId
+	}
+	return ""
+}
+
+func (x *CreatePullRequestRequest) GetLabels() []string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+// AssignmentReport сообщает, сколько ревьюеров требовалось и сколько удалось назначить
+type AssignmentReport struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Required          int32                  `protobuf:"varint,1,opt,name=required,proto3" json:"required,omitempty"`
+	Assigned          int32                  `protobuf:"varint,2,opt,name=assigned,proto3" json:"assigned,omitempty"`
+	SkippedAtCapacity int32                  `protobuf:"varint,3,opt,name=skipped_at_capacity,json=skippedAtCapacity,proto3" json:"skipped_at_capacity,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *AssignmentReport) Reset() {
+	*x = AssignmentReport{}
+	mi := &file_reviewer_v1_pull_request_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AssignmentReport) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AssignmentReport) ProtoMessage() {}
+
+func (x *AssignmentReport) ProtoReflect() protoreflect.Message {
+	mi := &file_reviewer_v1_pull_request_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AssignmentReport.ProtoReflect.Descriptor instead.
+func (*AssignmentReport) Descriptor() ([]byte, []int) {
+	return file_reviewer_v1_pull_request_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *AssignmentReport) GetRequired() int32 {
+	if x != nil {
+		return x.Required
+	}
+	return 0
+}
+
+func (x *AssignmentReport) GetAssigned() int32 {
+	if x != nil {
+		return x.Assigned
+	}
+	return 0
+}
+
+func (x *AssignmentReport) GetSkippedAtCapacity() int32 {
+	if x != nil {
+		return x.SkippedAtCapacity
+	}
+	return 0
+}
+
+type CreatePullRequestResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PullRequest   *PullRequest           `protobuf:"bytes,1,opt,name=pull_request,json=pullRequest,proto3" json:"pull_request,omitempty"`
+	Assignment    *AssignmentReport      `protobuf:"bytes,2,opt,name=assignment,proto3" json:"assignment,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreatePullRequestResponse) Reset() {
+	*x = CreatePullRequestResponse{}
+	mi := &file_reviewer_v1_pull_request_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreatePullRequestResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreatePullRequestResponse) ProtoMessage() {}
+
+func (x *CreatePullRequestResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_reviewer_v1_pull_request_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreatePullRequestResponse.ProtoReflect.Descriptor instead.
+func (*CreatePullRequestResponse) Descriptor() ([]byte, []int) {
+	return file_reviewer_v1_pull_request_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *CreatePullRequestResponse) GetPullRequest() *PullRequest {
+	if x != nil {
+		return x.PullRequest
+	}
+	return nil
+}
+
+func (x *CreatePullRequestResponse) GetAssignment() *AssignmentReport {
+	if x != nil {
+		return x.Assignment
+	}
+	return nil
+}
+
+type GetPullRequestRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PullRequestId string                 `protobuf:"bytes,1,opt,name=pull_request_id,json=pullRequestId,proto3" json:"pull_request_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPullRequestRequest) Reset() {
+	*x = GetPullRequestRequest{}
+	mi := &file_reviewer_v1_pull_request_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPullRequestRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPullRequestRequest) ProtoMessage() {}
+
+func (x *GetPullRequestRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_reviewer_v1_pull_request_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPullRequestRequest.ProtoReflect.Descriptor instead.
+func (*GetPullRequestRequest) Descriptor() ([]byte, []int) {
+	return file_reviewer_v1_pull_request_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetPullRequestRequest) GetPullRequestId() string {
+	if x != nil {
+		return x.PullRequestId
+	}
+	return ""
+}
+
+// ListPullRequestsRequest - фильтр выгрузки. Период [from, to) применяется ко времени создания PR
+type ListPullRequestsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TeamName      string                 `protobuf:"bytes,1,opt,name=team_name,json=teamName,proto3" json:"team_name,omitempty"`
+	AuthorId      string                 `protobuf:"bytes,2,opt,name=author_id,json=authorId,proto3" json:"author_id,omitempty"`
+	Status        PullRequestStatus      `protobuf:"varint,3,opt,name=status,proto3,enum=reviewer.v1.PullRequestStatus" json:"status,omitempty"`
+	From          *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=from,proto3" json:"from,omitempty"`
+	To            *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=to,proto3" json:"to,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPullRequestsRequest) Reset() {
+	*x = ListPullRequestsRequest{}
+	mi := &file_reviewer_v1_pull_request_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPullRequestsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPullRequestsRequest) ProtoMessage() {}
+
+func (x *ListPullRequestsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_reviewer_v1_pull_request_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPullRequestsRequest.ProtoReflect.Descriptor instead.
+func (*ListPullRequestsRequest) Descriptor() ([]byte, []int) {
+	return file_reviewer_v1_pull_request_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ListPullRequestsRequest) GetTeamName() string {
+	if x != nil {
+		return x.TeamName
+	}
+	return ""
+}
+
+func (x *ListPullRequestsRequest) GetAuthorId() string {
+	if x != nil {
+		return x.AuthorId
+	}
+	return ""
+}
+
+func (x *ListPullRequestsRequest) GetStatus() PullRequestStatus {
+	if x != nil {
+		return x.Status
+	}
+	return PullRequestStatus_PULL_REQUEST_STATUS_UNSPECIFIED
+}
+
+func (x *ListPullRequestsRequest) GetFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.From
+	}
+	return nil
+}
+
+func (x *ListPullRequestsRequest) GetTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+type MergePullRequestRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PullRequestId string                 `protobuf:"bytes,1,opt,name=pull_request_id,json=pullRequestId,proto3" json:"pull_request_id,omitempty"`
+	// Мерж выполняется, только если PR имеет эту версию
+	ExpectedVersion *int64 `protobuf:"varint,2,opt,name=expected_version,json=expectedVersion,proto3,oneof" json:"expected_version,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *MergePullRequestRequest) Reset() {
+	*x = MergePullRequestRequest{}
+	mi := &file_reviewer_v1_pull_request_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MergePullRequestRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MergePullRequestRequest) ProtoMessage() {}
+
+func (x *MergePullRequestRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_reviewer_v1_pull_request_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MergePullRequestRequest.ProtoReflect.Descriptor instead.
+func (*MergePullRequestRequest) Descriptor() ([]byte, []int) {
+	return file_reviewer_v1_pull_request_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *MergePullRequestRequest) GetPullRequestId() string {
+	if x != nil {
+		return x.PullRequestId
+	}
+	return ""
+}
+
+func (x *MergePullRequestRequest) GetExpectedVersion() int64 {
+	if x != nil && x.ExpectedVersion != nil {
+		return *x.ExpectedVersion
+	}
+	return 0
+}
+
+type ReassignReviewerRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	PullRequestId   string                 `protobuf:"bytes,1,opt,name=pull_request_id,json=pullRequestId,proto3" json:"pull_request_id,omitempty"`
+	OldReviewerId   string                 `protobuf:"bytes,2,opt,name=old_reviewer_id,json=oldReviewerId,proto3" json:"old_reviewer_id,omitempty"`
+	ExpectedVersion *int64                 `protobuf:"varint,3,opt,name=expected_version,json=expectedVersion,proto3,oneof" json:"expected_version,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ReassignReviewerRequest) Reset() {
+	*x = ReassignReviewerRequest{}
+	mi := &file_reviewer_v1_pull_request_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReassignReviewerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReassignReviewerRequest) ProtoMessage() {}
+
+func (x *ReassignReviewerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_reviewer_v1_pull_request_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReassignReviewerRequest.ProtoReflect.Descriptor instead.
+func (*ReassignReviewerRequest) Descriptor() ([]byte, []int) {
+	return file_reviewer_v1_pull_request_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ReassignReviewerRequest) GetPullRequestId() string {
+	if x != nil {
+		return x.PullRequestId
+	}
+	return ""
+}
+
+func (x *ReassignReviewerRequest) GetOldReviewerId() string {
+	if x != nil {
+		return x.OldReviewerId
+	}
+	return ""
+}
+
+func (x *ReassignReviewerRequest) GetExpectedVersion() int64 {
+	if x != nil && x.ExpectedVersion != nil {
+		return *x.ExpectedVersion
+	}
+	return 0
+}
+
+type ReassignReviewerResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PullRequest   *PullRequest           `protobuf:"bytes,1,opt,name=pull_request,json=pullRequest,proto3" json:"pull_request,omitempty"`
+	ReplacedBy    string                 `protobuf:"bytes,2,opt,name=replaced_by,json=replacedBy,proto3" json:"replaced_by,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReassignReviewerResponse) Reset() {
+	*x = ReassignReviewerResponse{}
+	mi := &file_reviewer_v1_pull_request_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReassignReviewerResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReassignReviewerResponse) ProtoMessage() {}
+
+func (x *ReassignReviewerResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_reviewer_v1_pull_request_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReassignReviewerResponse.ProtoReflect.Descriptor instead.
+func (*ReassignReviewerResponse) Descriptor() ([]byte, []int) {
+	return file_reviewer_v1_pull_request_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ReassignReviewerResponse) GetPullRequest() *PullRequest {
+	if x != nil {
+		return x.PullRequest
+	}
+	return nil
+}
+
+func (x *ReassignReviewerResponse) GetReplacedBy() string {
+	if x != nil {
+		return x.ReplacedBy
+	}
+	return ""
+}
+
+type SubmitVerdictRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PullRequestId string                 `protobuf:"bytes,1,opt,name=pull_request_id,json=pullRequestId,proto3" json:"pull_request_id,omitempty"`
+	// Не задан - вердикт ставится от имени вызывающего пользователя
+	ReviewerId    string  `protobuf:"bytes,2,opt,name=reviewer_id,json=reviewerId,proto3" json:"reviewer_id,omitempty"`
+	Verdict       Verdict `protobuf:"varint,3,opt,name=verdict,proto3,enum=reviewer.v1.Verdict" json:"verdict,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubmitVerdictRequest) Reset() {
+	*x = SubmitVerdictRequest{}
+	mi := &file_reviewer_v1_pull_request_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubmitVerdictRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubmitVerdictRequest) ProtoMessage() {}
+
+func (x *SubmitVerdictRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_reviewer_v1_pull_request_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubmitVerdictRequest.ProtoReflect.Descriptor instead.
+func (*SubmitVerdictRequest) Descriptor() ([]byte, []int) {
+	return file_reviewer_v1_pull_request_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *SubmitVerdictRequest) GetPullRequestId() string {
+	if x != nil {
+		return x.PullRequestId
+	}
+	return ""
+}
+
+func (x *SubmitVerdictRequest) GetReviewerId() string {
+	if x != nil {
+		return x.ReviewerId
+	}
+	return ""
+}
+
+func (x *SubmitVerdictRequest) GetVerdict() Verdict {
+	if x != nil {
+		return x.Verdict
+	}
+	return Verdict_VERDICT_UNSPECIFIED
+}
+
+type SubmitVerdictResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PullRequestId string                 `protobuf:"bytes,1,opt,name=pull_request_id,json=pullRequestId,proto3" json:"pull_request_id,omitempty"`
+	ReviewerId    string                 `protobuf:"bytes,2,opt,name=reviewer_id,json=reviewerId,proto3" json:"reviewer_id,omitempty"`
+	Verdict       Verdict                `protobuf:"varint,3,opt,name=verdict,proto3,enum=reviewer.v1.Verdict" json:"verdict,omitempty"`
+	SubmittedAt   *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=submitted_at,json=submittedAt,proto3" json:"submitted_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubmitVerdictResponse) Reset() {
+	*x = SubmitVerdictResponse{}
+	mi := &file_reviewer_v1_pull_request_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubmitVerdictResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubmitVerdictResponse) ProtoMessage() {}
+
+func (x *SubmitVerdictResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_reviewer_v1_pull_request_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubmitVerdictResponse.ProtoReflect.Descriptor instead.
+func (*SubmitVerdictResponse) Descriptor() ([]byte, []int) {
+	return file_reviewer_v1_pull_request_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *SubmitVerdictResponse) GetPullRequestId() string {
+	if x != nil {
+		return x.PullRequestId
+	}
+	return ""
+}
+
+func (x *SubmitVerdictResponse) GetReviewerId() string {
+	if x != nil {
+		return x.ReviewerId
+	}
+	return ""
+}
+
+func (x *SubmitVerdictResponse) GetVerdict() Verdict {
+	if x != nil {
+		return x.Verdict
+	}
+	return Verdict_VERDICT_UNSPECIFIED
+}
+
+func (x *SubmitVerdictResponse) GetSubmittedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.SubmittedAt
+	}
+	return nil
+}
+
+// WatchReviewEventsRequest - фильтр событий. Пустые поля означают все PR и всех ревьюеров
+type WatchReviewEventsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PullRequestId string                 `protobuf:"bytes,1,opt,name=pull_request_id,json=pullRequestId,proto3" json:"pull_request_id,omitempty"`
+	// Совпадает с ревьюером события или с новым ревьюером при переназначении
+	ReviewerId    string `protobuf:"bytes,2,opt,name=reviewer_id,json=reviewerId,proto3" json:"reviewer_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchReviewEventsRequest) Reset() {
+	*x = WatchReviewEventsRequest{}
+	mi := &file_reviewer_v1_pull_request_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchReviewEventsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchReviewEventsRequest) ProtoMessage() {}
+
+func (x *WatchReviewEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_reviewer_v1_pull_request_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchReviewEventsRequest.ProtoReflect.Descriptor instead.
+func (*WatchReviewEventsRequest) Descriptor() ([]byte, []int) {
+	return file_reviewer_v1_pull_request_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *WatchReviewEventsRequest) GetPullRequestId() string {
+	if x != nil {
+		return x.PullRequestId
+	}
+	return ""
+}
+
+func (x *WatchReviewEventsRequest) GetReviewerId() string {
+	if x != nil {
+		return x.ReviewerId
+	}
+	return ""
+}
+
+type ReviewEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Type          ReviewEventType        `protobuf:"varint,1,opt,name=type,proto3,enum=reviewer.v1.ReviewEventType" json:"type,omitempty"`
+	PullRequestId string                 `protobuf:"bytes,2,opt,name=pull_request_id,json=pullRequestId,proto3" json:"pull_request_id,omitempty"`
+	ReviewerId    string                 `protobuf:"bytes,3,opt,name=reviewer_id,json=reviewerId,proto3" json:"reviewer_id,omitempty"`
+	// Заполняется, если ревью передано другому ревьюеру
+	NewReviewerId string                 `protobuf:"bytes,4,opt,name=new_reviewer_id,json=newReviewerId,proto3" json:"new_reviewer_id,omitempty"`
+	OccurredAt    *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=occurred_at,json=occurredAt,proto3" json:"occurred_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReviewEvent) Reset() {
+	*x = ReviewEvent{}
+	mi := &file_reviewer_v1_pull_request_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReviewEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReviewEvent) ProtoMessage() {}
+
+func (x *ReviewEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_reviewer_v1_pull_request_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReviewEvent.ProtoReflect.Descriptor instead.
+func (*ReviewEvent) Descriptor() ([]byte, []int) {
+	return file_reviewer_v1_pull_request_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ReviewEvent) GetType() ReviewEventType {
+	if x != nil {
+		return x.Type
+	}
+	return ReviewEventType_REVIEW_EVENT_TYPE_UNSPECIFIED
+}
+
+func (x *ReviewEvent) GetPullRequestId() string {
+	if x != nil {
+		return x.PullRequestId
+	}
+	return ""
+}
+
+func (x *ReviewEvent) GetReviewerId() string {
+	if x != nil {
+		return x.ReviewerId
+	}
+	return ""
+}
+
+func (x *ReviewEvent) GetNewReviewerId() string {
+	if x != nil {
+		return x.NewReviewerId
+	}
+	return ""
+}
+
+func (x *ReviewEvent) GetOccurredAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.OccurredAt
+	}
+	return nil
+}
+
+var File_reviewer_v1_pull_request_proto protoreflect.FileDescriptor
+
+const file_reviewer_v1_pull_request_proto_rawDesc = "" +
+	"\n" +
+	"\x1ereviewer/v1/pull_request.proto\x12\vreviewer.v1\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x18reviewer/v1/models.proto\"\xa3\x01\n" +
+	"\x18CreatePullRequestRequest\x12&\n" +
+	"\x0fpull_request_id\x18\x01 \x01(\tR\rpullRequestId\x12*\n" +
+	"\x11pull_request_name\x18\x02 \x01(\tR\x0fpullRequestName\x12\x1b\n" +
+	"\tauthor_id\x18\x03 \x01(\tR\bauthorId\x12\x16\n" +
+	"\x06labels\x18\x04 \x03(\tR\x06labels\"z\n" +
+	"\x10AssignmentReport\x12\x1a\n" +
+	"\brequired\x18\x01 \x01(\x05R\brequired\x12\x1a\n" +
+	"\bassigned\x18\x02 \x01(\x05R\bassigned\x12.\n" +
+	"\x13skipped_at_capacity\x18\x03 \x01(\x05R\x11skippedAtCapacity\"\x97\x01\n" +
+	"\x19CreatePullRequestResponse\x12;\n" +
+	"\fpull_request\x18\x01 \x01(\v2\x18.reviewer.v1.PullRequestR\vpullRequest\x12=\n" +
+	"\n" +
+	"assignment\x18\x02 \x01(\v2\x1d.reviewer.v1.AssignmentReportR\n" +
+	"assignment\"?\n" +
+	"\x15GetPullRequestRequest\x12&\n" +
+	"\x0fpull_request_id\x18\x01 \x01(\tR\rpullRequestId\"\xe7\x01\n" +
+	"\x17ListPullRequestsRequest\x12\x1b\n" +
+	"\tteam_name\x18\x01 \x01(\tR\bteamName\x12\x1b\n" +
+	"\tauthor_id\x18\x02 \x01(\tR\bauthorId\x126\n" +
+	"\x06status\x18\x03 \x01(\x0e2\x1e.reviewer.v1.PullRequestStatusR\x06status\x12.\n" +
+	"\x04from\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\x04from\x12*\n" +
+	"\x02to\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\x02to\"\x86\x01\n" +
+	"\x17MergePullRequestRequest\x12&\n" +
+	"\x0fpull_request_id\x18\x01 \x01(\tR\rpullRequestId\x12.\n" +
+	"\x10expected_version\x18\x02 \x01(\x03H\x00R\x0fexpectedVersion\x88\x01\x01B\x13\n" +
+	"\x11_expected_version\"\xae\x01\n" +
+	"\x17ReassignReviewerRequest\x12&\n" +
+	"\x0fpull_request_id\x18\x01 \x01(\tR\rpullRequestId\x12&\n" +
+	"\x0fold_reviewer_id\x18\x02 \x01(\tR\roldReviewerId\x12.\n" +
+	"\x10expected_version\x18\x03 \x01(\x03H\x00R\x0fexpectedVersion\x88\x01\x01B\x13\n" +
+	"\x11_expected_version\"x\n" +
+	"\x18ReassignReviewerResponse\x12;\n" +
+	"\fpull_request\x18\x01 \x01(\v2\x18.reviewer.v1.PullRequestR\vpullRequest\x12\x1f\n" +
+	"\vreplaced_by\x18\x02 \x01(\tR\n" +
+	"replacedBy\"\x8f\x01\n" +
+	"\x14SubmitVerdictRequest\x12&\n" +
+	"\x0fpull_request_id\x18\x01 \x01(\tR\rpullRequestId\x12\x1f\n" +
+	"\vreviewer_id\x18\x02 \x01(\tR\n" +
+	"reviewerId\x12.\n" +
+	"\averdict\x18\x03 \x01(\x0e2\x14.reviewer.v1.VerdictR\averdict\"\xcf\x01\n" +
+	"\x15SubmitVerdictResponse\x12&\n" +
+	"\x0fpull_request_id\x18\x01 \x01(\tR\rpullRequestId\x12\x1f\n" +
+	"\vreviewer_id\x18\x02 \x01(\tR\n" +
+	"reviewerId\x12.\n" +
+	"\averdict\x18\x03 \x01(\x0e2\x14.reviewer.v1.VerdictR\averdict\x12=\n" +
+	"\fsubmitted_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\vsubmittedAt\"c\n" +
+	"\x18WatchReviewEventsRequest\x12&\n" +
+	"\x0fpull_request_id\x18\x01 \x01(\tR\rpullRequestId\x12\x1f\n" +
+	"\vreviewer_id\x18\x02 \x01(\tR\n" +
+	"reviewerId\"\xed\x01\n" +
+	"\vReviewEvent\x120\n" +
+	"\x04type\x18\x01 \x01(\x0e2\x1c.reviewer.v1.ReviewEventTypeR\x04type\x12&\n" +
+	"\x0fpull_request_id\x18\x02 \x01(\tR\rpullRequestId\x12\x1f\n" +
+	"\vreviewer_id\x18\x03 \x01(\tR\n" +
+	"reviewerId\x12&\n" +
+	"\x0fnew_reviewer_id\x18\x04 \x01(\tR\rnewReviewerId\x12;\n" +
+	"\voccurred_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"occurredAt*W\n" +
+	"\aVerdict\x12\x17\n" +
+	"\x13VERDICT_UNSPECIFIED\x10\x00\x12\x14\n" +
+	"\x10VERDICT_APPROVED\x10\x01\x12\x1d\n" +
+	"\x19VERDICT_CHANGES_REQUESTED\x10\x02*\xd7\x01\n" +
+	"\x0fReviewEventType\x12!\n" +
+	"\x1dREVIEW_EVENT_TYPE_UNSPECIFIED\x10\x00\x12'\n" +
+	"#REVIEW_EVENT_TYPE_REVIEWER_ASSIGNED\x10\x01\x12)\n" +
+	"%REVIEW_EVENT_TYPE_REVIEWER_REASSIGNED\x10\x02\x12%\n" +
+	"!REVIEW_EVENT_TYPE_REVIEW_REMINDER\x10\x03\x12&\n" +
+	"\"REVIEW_EVENT_TYPE_REVIEW_ESCALATED\x10\x042\x83\x05\n" +
+	"\x12PullRequestService\x12b\n" +
+	"\x11CreatePullRequest\x12%.reviewer.v1.CreatePullRequestRequest\x1a&.reviewer.v1.CreatePullRequestResponse\x12N\n" +
+	"\x0eGetPullRequest\x12\".reviewer.v1.GetPullRequestRequest\x1a\x18.reviewer.v1.PullRequest\x12T\n" +
+	"\x10ListPullRequests\x12$.reviewer.v1.ListPullRequestsRequest\x1a\x18.reviewer.v1.PullRequest0\x01\x12R\n" +
+	"\x10MergePullRequest\x12$.reviewer.v1.MergePullRequestRequest\x1a\x18.reviewer.v1.PullRequest\x12_\n" +
+	"\x10ReassignReviewer\x12$.reviewer.v1.ReassignReviewerRequest\x1a%.reviewer.v1.ReassignReviewerResponse\x12V\n" +
+	"\rSubmitVerdict\x12!.reviewer.v1.SubmitVerdictRequest\x1a\".reviewer.v1.SubmitVerdictResponse\x12V\n" +
+	"\x11WatchReviewEvents\x12%.reviewer.v1.WatchReviewEventsRequest\x1a\x18.reviewer.v1.ReviewEvent0\x01B9Z7avito/internal/transport/grpc/pb/reviewer/v1;reviewerv1b\x06proto3"
+
+var (
+	file_reviewer_v1_pull_request_proto_rawDescOnce sync.Once
+	file_reviewer_v1_pull_request_proto_rawDescData []byte
+)
+
+func file_reviewer_v1_pull_request_proto_rawDescGZIP() []byte {
+	file_reviewer_v1_pull_request_proto_rawDescOnce.Do(func() {
+		file_reviewer_v1_pull_request_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_reviewer_v1_pull_request_proto_rawDesc), len(file_reviewer_v1_pull_request_proto_rawDesc)))
+	})
+	return file_reviewer_v1_pull_request_proto_rawDescData
+}
+
+var file_reviewer_v1_pull_request_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_reviewer_v1_pull_request_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
+var file_reviewer_v1_pull_request_proto_goTypes = []any{
+	(Verdict)(0),                      // 0: reviewer.v1.Verdict
+	(ReviewEventType)(0),              // 1: reviewer.v1.ReviewEventType
+	(*CreatePullRequestRequest)(nil),  // 2: reviewer.v1.CreatePullRequestRequest
+	(*AssignmentReport)(nil),          // 3: reviewer.v1.AssignmentReport
+	(*CreatePullRequestResponse)(nil), // 4: reviewer.v1.CreatePullRequestResponse
+	(*GetPullRequestRequest)(nil),     // 5: reviewer.v1.GetPullRequestRequest
+	(*ListPullRequestsRequest)(nil),   // 6: reviewer.v1.ListPullRequestsRequest
+	(*MergePullRequestRequest)(nil),   // 7: reviewer.v1.MergePullRequestRequest
+	(*ReassignReviewerRequest)(nil),   // 8: reviewer.v1.ReassignReviewerRequest
+	(*ReassignReviewerResponse)(nil),  // 9: reviewer.v1.ReassignReviewerResponse
+	(*SubmitVerdictRequest)(nil),      // 10: reviewer.v1.SubmitVerdictRequest
+	(*SubmitVerdictResponse)(nil),     // 11: reviewer.v1.SubmitVerdictResponse
+	(*WatchReviewEventsRequest)(nil),  // 12: reviewer.v1.WatchReviewEventsRequest
+	(*ReviewEvent)(nil),               // 13: reviewer.v1.ReviewEvent
+	(*PullRequest)(nil),               // 14: reviewer.v1.PullRequest
+	(PullRequestStatus)(0),            // 15: reviewer.v1.PullRequestStatus
+	(*timestamppb.Timestamp)(nil),     // 16: google.protobuf.Timestamp
+}
+var file_reviewer_v1_pull_request_proto_depIdxs = []int32{
+	14, // 0: reviewer.v1.CreatePullRequestResponse.pull_request:type_name -> reviewer.v1.PullRequest
+	3,  // 1: reviewer.v1.CreatePullRequestResponse.assignment:type_name -> reviewer.v1.AssignmentReport
+	15, // 2: reviewer.v1.ListPullRequestsRequest.status:type_name -> reviewer.v1.PullRequestStatus
+	16, // 3: reviewer.v1.ListPullRequestsRequest.from:type_name -> google.protobuf.Timestamp
+	16, // 4: reviewer.v1.ListPullRequestsRequest.to:type_name -> google.protobuf.Timestamp
+	14, // 5: reviewer.v1.ReassignReviewerResponse.pull_request:type_name -> reviewer.v1.PullRequest
+	0,  // 6: reviewer.v1.SubmitVerdictRequest.verdict:type_name -> reviewer.v1.Verdict
+	0,  // 7: reviewer.v1.SubmitVerdictResponse.verdict:type_name -> reviewer.v1.Verdict
+	16, // 8: reviewer.v1.SubmitVerdictResponse.submitted_at:type_name -> google.protobuf.Timestamp
+	1,  // 9: reviewer.v1.ReviewEvent.type:type_name -> reviewer.v1.ReviewEventType
+	16, // 10: reviewer.v1.ReviewEvent.occurred_at:type_name -> google.protobuf.Timestamp
+	2,  // 11: reviewer.v1.PullRequestService.CreatePullRequest:input_type -> reviewer.v1.CreatePullRequestRequest
+	5,  // 12: reviewer.v1.PullRequestService.GetPullRequest:input_type -> reviewer.v1.GetPullRequestRequest
+	6,  // 13: reviewer.v1.PullRequestService.ListPullRequests:input_type -> reviewer.v1.ListPullRequestsRequest
+	7,  // 14: reviewer.v1.PullRequestService.MergePullRequest:input_type -> reviewer.v1.MergePullRequestRequest
+	8,  // 15: reviewer.v1.PullRequestService.ReassignReviewer:input_type -> reviewer.v1.ReassignReviewerRequest
+	10, // 16: reviewer.v1.PullRequestService.SubmitVerdict:input_type -> reviewer.v1.SubmitVerdictRequest
+	12, // 17: reviewer.v1.PullRequestService.WatchReviewEvents:input_type -> reviewer.v1.WatchReviewEventsRequest
+	4,  // 18: reviewer.v1.PullRequestService.CreatePullRequest:output_type -> reviewer.v1.CreatePullRequestResponse
+	14, // 19: reviewer.v1.PullRequestService.GetPullRequest:output_type -> reviewer.v1.PullRequest
+	14, // 20: reviewer.v1.PullRequestService.ListPullRequests:output_type -> reviewer.v1.PullRequest
+	14, // 21: reviewer.v1.PullRequestService.MergePullRequest:output_type -> reviewer.v1.PullRequest
+	9,  // 22: reviewer.v1.PullRequestService.ReassignReviewer:output_type -> reviewer.v1.ReassignReviewerResponse
+	11, // 23: reviewer.v1.PullRequestService.SubmitVerdict:output_type -> reviewer.v1.SubmitVerdictResponse
+	13, // 24: reviewer.v1.PullRequestService.WatchReviewEvents:output_type -> reviewer.v1.ReviewEvent
+	18, // [18:25] is the sub-list for method output_type
+	11, // [11:18] is the sub-list for method input_type
+	11, // [11:11] is the sub-list for extension type_name
+	11, // [11:11] is the sub-list for extension extendee
+	0,  // [0:11] is the sub-list for field type_name
+}
+
+func init() { file_reviewer_v1_pull_request_proto_init() }
+func file_reviewer_v1_pull_request_proto_init() {
+	if File_reviewer_v1_pull_request_proto != nil {
+		return
+	}
+	file_reviewer_v1_models_proto_init()
+	file_reviewer_v1_pull_request_proto_msgTypes[5].OneofWrappers = []any{}
+	file_reviewer_v1_pull_request_proto_msgTypes[6].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_reviewer_v1_pull_request_proto_rawDesc), len(file_reviewer_v1_pull_request_proto_rawDesc)),
+			NumEnums:      2,
+			NumMessages:   12,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_reviewer_v1_pull_request_proto_goTypes,
+		DependencyIndexes: file_reviewer_v1_pull_request_proto_depIdxs,
+		EnumInfos:         file_reviewer_v1_pull_request_proto_enumTypes,
+		MessageInfos:      file_reviewer_v1_pull_request_proto_msgTypes,
+	}.Build()
+	File_reviewer_v1_pull_request_proto = out.File
+	file_reviewer_v1_pull_request_proto_goTypes = nil
+	file_reviewer_v1_pull_request_proto_depIdxs = nil
+}
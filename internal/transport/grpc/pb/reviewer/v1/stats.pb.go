@@ -0,0 +1,459 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.9
+// 	protoc        (unknown)
+// source: reviewer/v1/stats.proto
+
+package reviewerv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// StatsRequest - фильтр статистики. Пустые поля означают отсутствие фильтра, период [from, to)
+type StatsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	From          *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
+	To            *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`
+	TeamName      string                 `protobuf:"bytes,3,opt,name=team_name,json=teamName,proto3" json:"team_name,omitempty"`
+	Status        PullRequestStatus      `protobuf:"varint,4,opt,name=status,proto3,enum=reviewer.v1.PullRequestStatus" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StatsRequest) Reset() {
+	*x = StatsRequest{}
+	mi := &file_reviewer_v1_stats_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StatsRequest) ProtoMessage() {}
+
+func (x *StatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_reviewer_v1_stats_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StatsRequest.ProtoReflect.Descriptor instead.
+func (*StatsRequest) Descriptor() ([]byte, []int) {
+	return file_reviewer_v1_stats_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *StatsRequest) GetFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.From
+	}
+	return nil
+}
+
+func (x *StatsRequest) GetTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+func (x *StatsRequest) GetTeamName() string {
+	if x != nil {
+		return x.TeamName
+	}
+	return ""
+}
+
+func (x *StatsRequest) GetStatus() PullRequestStatus {
+	if x != nil {
+		return x.Status
+	}
+	return PullRequestStatus_PULL_REQUEST_STATUS_UNSPECIFIED
+}
+
+type UserReviewStat struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	UserId          string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Username        string                 `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	IsActive        bool                   `protobuf:"varint,3,opt,name=is_active,json=isActive,proto3" json:"is_active,omitempty"`
+	ReviewCount     int32                  `protobuf:"varint,4,opt,name=review_count,json=reviewCount,proto3" json:"review_count,omitempty"`
+	OpenReviewCount int32                  `protobuf:"varint,5,opt,name=open_review_count,json=openReviewCount,proto3" json:"open_review_count,omitempty"`
+	ReviewCapacity  *int32                 `protobuf:"varint,6,opt,name=review_capacity,json=reviewCapacity,proto3,oneof" json:"review_capacity,omitempty"`
+	// Доля занятого лимита открытых ревью, не задана для пользователей без лимита
+	Utilization   *float64 `protobuf:"fixed64,7,opt,name=utilization,proto3,oneof" json:"utilization,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UserReviewStat) Reset() {
+	*x = UserReviewStat{}
+	mi := &file_reviewer_v1_stats_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserReviewStat) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserReviewStat) ProtoMessage() {}
+
+func (x *UserReviewStat) ProtoReflect() protoreflect.Message {
+	mi := &file_reviewer_v1_stats_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserReviewStat.ProtoReflect.Descriptor instead.
+func (*UserReviewStat) Descriptor() ([]byte, []int) {
+	return file_reviewer_v1_stats_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *UserReviewStat) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *UserReviewStat) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *UserReviewStat) GetIsActive() bool {
+	if x != nil {
+		return x.IsActive
+	}
+	return false
+}
+
+func (x *UserReviewStat) GetReviewCount() int32 {
+	if x != nil {
+		return x.ReviewCount
+	}
+	return 0
+}
+
+func (x *UserReviewStat) GetOpenReviewCount() int32 {
+	if x != nil {
+		return x.OpenReviewCount
+	}
+	return 0
+}
+
+func (x *UserReviewStat) GetReviewCapacity() int32 {
+	if x != nil && x.ReviewCapacity != nil {
+		return *x.ReviewCapacity
+	}
+	return 0
+}
+
+func (x *UserReviewStat) GetUtilization() float64 {
+	if x != nil && x.Utilization != nil {
+		return *x.Utilization
+	}
+	return 0
+}
+
+type UserReviewStatsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Stats         []*UserReviewStat      `protobuf:"bytes,1,rep,name=stats,proto3" json:"stats,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UserReviewStatsResponse) Reset() {
+	*x = UserReviewStatsResponse{}
+	mi := &file_reviewer_v1_stats_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserReviewStatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserReviewStatsResponse) ProtoMessage() {}
+
+func (x *UserReviewStatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_reviewer_v1_stats_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserReviewStatsResponse.ProtoReflect.Descriptor instead.
+func (*UserReviewStatsResponse) Descriptor() ([]byte, []int) {
+	return file_reviewer_v1_stats_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *UserReviewStatsResponse) GetStats() []*UserReviewStat {
+	if x != nil {
+		return x.Stats
+	}
+	return nil
+}
+
+type TeamReviewStat struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	TeamName            string                 `protobuf:"bytes,1,opt,name=team_name,json=teamName,proto3" json:"team_name,omitempty"`
+	PrsOpened           int32                  `protobuf:"varint,2,opt,name=prs_opened,json=prsOpened,proto3" json:"prs_opened,omitempty"`
+	PrsMerged           int32                  `protobuf:"varint,3,opt,name=prs_merged,json=prsMerged,proto3" json:"prs_merged,omitempty"`
+	AvgReviewersPerPr   float64                `protobuf:"fixed64,4,opt,name=avg_reviewers_per_pr,json=avgReviewersPerPr,proto3" json:"avg_reviewers_per_pr,omitempty"`
+	PrsWithoutReviewers int32                  `protobuf:"varint,5,opt,name=prs_without_reviewers,json=prsWithoutReviewers,proto3" json:"prs_without_reviewers,omitempty"`
+	ZeroReviewersShare  float64                `protobuf:"fixed64,6,opt,name=zero_reviewers_share,json=zeroReviewersShare,proto3" json:"zero_reviewers_share,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *TeamReviewStat) Reset() {
+	*x = TeamReviewStat{}
+	mi := &file_reviewer_v1_stats_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TeamReviewStat) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TeamReviewStat) ProtoMessage() {}
+
+func (x *TeamReviewStat) ProtoReflect() protoreflect.Message {
+	mi := &file_reviewer_v1_stats_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TeamReviewStat.ProtoReflect.Descriptor instead.
+func (*TeamReviewStat) Descriptor() ([]byte, []int) {
+	return file_reviewer_v1_stats_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *TeamReviewStat) GetTeamName() string {
+	if x != nil {
+		return x.TeamName
+	}
+	return ""
+}
+
+func (x *TeamReviewStat) GetPrsOpened() int32 {
+	if x != nil {
+		return x.PrsOpened
+	}
+	return 0
+}
+
+func (x *TeamReviewStat) GetPrsMerged() int32 {
+	if x != nil {
+		return x.PrsMerged
+	}
+	return 0
+}
+
+func (x *TeamReviewStat) GetAvgReviewersPerPr() float64 {
+	if x != nil {
+		return x.AvgReviewersPerPr
+	}
+	return 0
+}
+
+func (x *TeamReviewStat) GetPrsWithoutReviewers() int32 {
+	if x != nil {
+		return x.PrsWithoutReviewers
+	}
+	return 0
+}
+
+func (x *TeamReviewStat) GetZeroReviewersShare() float64 {
+	if x != nil {
+		return x.ZeroReviewersShare
+	}
+	return 0
+}
+
+type TeamReviewStatsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Stats         []*TeamReviewStat      `protobuf:"bytes,1,rep,name=stats,proto3" json:"stats,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TeamReviewStatsResponse) Reset() {
+	*x = TeamReviewStatsResponse{}
+	mi := &file_reviewer_v1_stats_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TeamReviewStatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TeamReviewStatsResponse) ProtoMessage() {}
+
+func (x *TeamReviewStatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_reviewer_v1_stats_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TeamReviewStatsResponse.ProtoReflect.Descriptor instead.
+func (*TeamReviewStatsResponse) Descriptor() ([]byte, []int) {
+	return file_reviewer_v1_stats_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *TeamReviewStatsResponse) GetStats() []*TeamReviewStat {
+	if x != nil {
+		return x.Stats
+	}
+	return nil
+}
+
+var File_reviewer_v1_stats_proto protoreflect.FileDescriptor
+
+const file_reviewer_v1_stats_proto_rawDesc = "" +
+	"\n" +
+	"\x17reviewer/v1/stats.proto\x12\vreviewer.v1\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x18reviewer/v1/models.proto\"\xbf\x01\n" +
+	"\fStatsRequest\x12.\n" +
+	"\x04from\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x04from\x12*\n" +
+	"\x02to\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x02to\x12\x1b\n" +
+	"\tteam_name\x18\x03 \x01(\tR\bteamName\x126\n" +
+	"\x06status\x18\x04 \x01(\x0e2\x1e.reviewer.v1.PullRequestStatusR\x06status\"\xaa\x02\n" +
+	"\x0eUserReviewStat\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1a\n" +
+	"\busername\x18\x02 \x01(\tR\busername\x12\x1b\n" +
+	"\tis_active\x18\x03 \x01(\bR\bisActive\x12!\n" +
+	"\freview_count\x18\x04 \x01(\x05R\vreviewCount\x12*\n" +
+	"\x11open_review_count\x18\x05 \x01(\x05R\x0fopenReviewCount\x12,\n" +
+	"\x0freview_capacity\x18\x06 \x01(\x05H\x00R\x0ereviewCapacity\x88\x01\x01\x12%\n" +
+	"\vutilization\x18\a \x01(\x01H\x01R\vutilization\x88\x01\x01B\x12\n" +
+	"\x10_review_capacityB\x0e\n" +
+	"\f_utilization\"L\n" +
+	"\x17UserReviewStatsResponse\x121\n" +
+	"\x05stats\x18\x01 \x03(\v2\x1b.reviewer.v1.UserReviewStatR\x05stats\"\x82\x02\n" +
+	"\x0eTeamReviewStat\x12\x1b\n" +
+	"\tteam_name\x18\x01 \x01(\tR\bteamName\x12\x1d\n" +
+	"\n" +
+	"prs_opened\x18\x02 \x01(\x05R\tprsOpened\x12\x1d\n" +
+	"\n" +
+	"prs_merged\x18\x03 \x01(\x05R\tprsMerged\x12/\n" +
+	"\x14avg_reviewers_per_pr\x18\x04 \x01(\x01R\x11avgReviewersPerPr\x122\n" +
+	"\x15prs_without_reviewers\x18\x05 \x01(\x05R\x13prsWithoutReviewers\x120\n" +
+	"\x14zero_reviewers_share\x18\x06 \x01(\x01R\x12zeroReviewersShare\"L\n" +
+	"\x17TeamReviewStatsResponse\x121\n" +
+	"\x05stats\x18\x01 \x03(\v2\x1b.reviewer.v1.TeamReviewStatR\x05stats2\xbc\x01\n" +
+	"\fStatsService\x12U\n" +
+	"\x12GetUserReviewStats\x12\x19.reviewer.v1.StatsRequest\x1a$.reviewer.v1.UserReviewStatsResponse\x12U\n" +
+	"\x12GetTeamReviewStats\x12\x19.reviewer.v1.StatsRequest\x1a$.reviewer.v1.TeamReviewStatsResponseB9Z7avito/internal/transport/grpc/pb/reviewer/v1;reviewerv1b\x06proto3"
+
+var (
+	file_reviewer_v1_stats_proto_rawDescOnce sync.Once
+	file_reviewer_v1_stats_proto_rawDescData []byte
+)
+
+func file_reviewer_v1_stats_proto_rawDescGZIP() []byte {
+	file_reviewer_v1_stats_proto_rawDescOnce.Do(func() {
+		file_reviewer_v1_stats_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_reviewer_v1_stats_proto_rawDesc), len(file_reviewer_v1_stats_proto_rawDesc)))
+	})
+	return file_reviewer_v1_stats_proto_rawDescData
+}
+
+var file_reviewer_v1_stats_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_reviewer_v1_stats_proto_goTypes = []any{
+	(*StatsRequest)(nil),            // 0: reviewer.v1.StatsRequest
+	(*UserReviewStat)(nil),          // 1: reviewer.v1.UserReviewStat
+	(*UserReviewStatsResponse)(nil), // 2: reviewer.v1.UserReviewStatsResponse
+	(*TeamReviewStat)(nil),          // 3: reviewer.v1.TeamReviewStat
+	(*TeamReviewStatsResponse)(nil), // 4: reviewer.v1.TeamReviewStatsResponse
+	(*timestamppb.Timestamp)(nil),   // 5: google.protobuf.Timestamp
+	(PullRequestStatus)(0),          // 6: reviewer.v1.PullRequestStatus
+}
+var file_reviewer_v1_stats_proto_depIdxs = []int32{
+	5, // 0: reviewer.v1.StatsRequest.from:type_name -> google.protobuf.Timestamp
+	5, // 1: reviewer.v1.StatsRequest.to:type_name -> google.protobuf.Timestamp
+	6, // 2: reviewer.v1.StatsRequest.status:type_name -> reviewer.v1.PullRequestStatus
+	1, // 3: reviewer.v1.UserReviewStatsResponse.stats:type_name -> reviewer.v1.UserReviewStat
+	3, // 4: reviewer.v1.TeamReviewStatsResponse.stats:type_name -> reviewer.v1.TeamReviewStat
+	0, // 5: reviewer.v1.StatsService.GetUserReviewStats:input_type -> reviewer.v1.StatsRequest
+	0, // 6: reviewer.v1.StatsService.GetTeamReviewStats:input_type -> reviewer.v1.StatsRequest
+	2, // 7: reviewer.v1.StatsService.GetUserReviewStats:output_type -> reviewer.v1.UserReviewStatsResponse
+	4, // 8: reviewer.v1.StatsService.GetTeamReviewStats:output_type -> reviewer.v1.TeamReviewStatsResponse
+	7, // [7:9] is the sub-list for method output_type
+	5, // [5:7] is the sub-list for method input_type
+	5, // [5:5] is the sub-list for extension type_name
+	5, // [5:5] is the sub-list for extension extendee
+	0, // [0:5] is the sub-list for field type_name
+}
+
+func init() { file_reviewer_v1_stats_proto_init() }
+func file_reviewer_v1_stats_proto_init() {
+	if File_reviewer_v1_stats_proto != nil {
+		return
+	}
+	file_reviewer_v1_models_proto_init()
+	file_reviewer_v1_stats_proto_msgTypes[1].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_reviewer_v1_stats_proto_rawDesc), len(file_reviewer_v1_stats_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   5,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_reviewer_v1_stats_proto_goTypes,
+		DependencyIndexes: file_reviewer_v1_stats_proto_depIdxs,
+		MessageInfos:      file_reviewer_v1_stats_proto_msgTypes,
+	}.Build()
+	File_reviewer_v1_stats_proto = out.File
+	file_reviewer_v1_stats_proto_goTypes = nil
+	file_reviewer_v1_stats_proto_depIdxs = nil
+}
@@ -0,0 +1,376 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.9
+// 	protoc        (unknown)
+// source: reviewer/v1/team.proto
+
+package reviewerv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type CreateTeamRequest struct {
+	state                 protoimpl.MessageState `protogen:"open.v1"`
+	TeamName              string                 `protobuf:"bytes,1,opt,name=team_name,json=teamName,proto3" json:"team_name,omitempty"`
+	Members               []*TeamMember          `protobuf:"bytes,2,rep,name=members,proto3" json:"members,omitempty"`
+	DefaultReviewCapacity *int32                 `protobuf:"varint,3,opt,name=default_review_capacity,json=defaultReviewCapacity,proto3,oneof" json:"default_review_capacity,omitempty"`
+	ReviewSlaMinutes      *int32                 `protobuf:"varint,4,opt,name=review_sla_minutes,json=reviewSlaMinutes,proto3,oneof" json:"review_sla_minutes,omitempty"`
+	EscalationMinutes     *int32                 `protobuf:"varint,5,opt,name=escalation_minutes,json=escalationMinutes,proto3,oneof" json:"escalation_minutes,omitempty"`
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *CreateTeamRequest) Reset() {
+	*x = CreateTeamRequest{}
+	mi := &file_reviewer_v1_team_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateTeamRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateTeamRequest) ProtoMessage() {}
+
+func (x *CreateTeamRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_reviewer_v1_team_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateTeamRequest.ProtoReflect.Descriptor instead.
+func (*CreateTeamRequest) Descriptor() ([]byte, []int) {
+	return file_reviewer_v1_team_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *CreateTeamRequest) GetTeamName() string {
+	if x != nil {
+		return x.TeamName
+	}
+	return ""
+}
+
+func (x *CreateTeamRequest) GetMembers() []*TeamMember {
+	if x != nil {
+		return x.Members
+	}
+	return nil
+}
+
+func (x *CreateTeamRequest) GetDefaultReviewCapacity() int32 {
+	if x != nil && x.DefaultReviewCapacity != nil {
+		return *x.DefaultReviewCapacity
+	}
+	return 0
+}
+
+func (x *CreateTeamRequest) GetReviewSlaMinutes() int32 {
+	if x != nil && x.ReviewSlaMinutes != nil {
+		return *x.ReviewSlaMinutes
+	}
+	return 0
+}
+
+func (x *CreateTeamRequest) GetEscalationMinutes() int32 {
+	if x != nil && x.EscalationMinutes != nil {
+		return *x.EscalationMinutes
+	}
+	return 0
+}
+
+type GetTeamRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TeamName      string                 `protobuf:"bytes,1,opt,name=team_name,json=teamName,proto3" json:"team_name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTeamRequest) Reset() {
+	*x = GetTeamRequest{}
+	mi := &file_reviewer_v1_team_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTeamRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTeamRequest) ProtoMessage() {}
+
+func (x *GetTeamRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_reviewer_v1_team_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTeamRequest.ProtoReflect.Descriptor instead.
+func (*GetTeamRequest) Descriptor() ([]byte, []int) {
+	return file_reviewer_v1_team_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetTeamRequest) GetTeamName() string {
+	if x != nil {
+		return x.TeamName
+	}
+	return ""
+}
+
+type SetTeamReviewCapacityRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	TeamName string                 `protobuf:"bytes,1,opt,name=team_name,json=teamName,proto3" json:"team_name,omitempty"`
+	// Не задан - лимит снимается
+	DefaultReviewCapacity *int32 `protobuf:"varint,2,opt,name=default_review_capacity,json=defaultReviewCapacity,proto3,oneof" json:"default_review_capacity,omitempty"`
+	// Изменение применяется, только если команда имеет эту версию
+	ExpectedVersion *int64 `protobuf:"varint,3,opt,name=expected_version,json=expectedVersion,proto3,oneof" json:"expected_version,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *SetTeamReviewCapacityRequest) Reset() {
+	*x = SetTeamReviewCapacityRequest{}
+	mi := &file_reviewer_v1_team_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetTeamReviewCapacityRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetTeamReviewCapacityRequest) ProtoMessage() {}
+
+func (x *SetTeamReviewCapacityRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_reviewer_v1_team_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetTeamReviewCapacityRequest.ProtoReflect.Descriptor instead.
+func (*SetTeamReviewCapacityRequest) Descriptor() ([]byte, []int) {
+	return file_reviewer_v1_team_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *SetTeamReviewCapacityRequest) GetTeamName() string {
+	if x != nil {
+		return x.TeamName
+	}
+	return ""
+}
+
+func (x *SetTeamReviewCapacityRequest) GetDefaultReviewCapacity() int32 {
+	if x != nil && x.DefaultReviewCapacity != nil {
+		return *x.DefaultReviewCapacity
+	}
+	return 0
+}
+
+func (x *SetTeamReviewCapacityRequest) GetExpectedVersion() int64 {
+	if x != nil && x.ExpectedVersion != nil {
+		return *x.ExpectedVersion
+	}
+	return 0
+}
+
+type SetTeamReviewSLARequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	TeamName string                 `protobuf:"bytes,1,opt,name=team_name,json=teamName,proto3" json:"team_name,omitempty"`
+	// Не заданные пороги берутся из настроек сервиса
+	ReviewSlaMinutes  *int32 `protobuf:"varint,2,opt,name=review_sla_minutes,json=reviewSlaMinutes,proto3,oneof" json:"review_sla_minutes,omitempty"`
+	EscalationMinutes *int32 `protobuf:"varint,3,opt,name=escalation_minutes,json=escalationMinutes,proto3,oneof" json:"escalation_minutes,omitempty"`
+	ExpectedVersion   *int64 `protobuf:"varint,4,opt,name=expected_version,json=expectedVersion,proto3,oneof" json:"expected_version,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *SetTeamReviewSLARequest) Reset() {
+	*x = SetTeamReviewSLARequest{}
+	mi := &file_reviewer_v1_team_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetTeamReviewSLARequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetTeamReviewSLARequest) ProtoMessage() {}
+
+func (x *SetTeamReviewSLARequest) ProtoReflect() protoreflect.Message {
+	mi := &file_reviewer_v1_team_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetTeamReviewSLARequest.ProtoReflect.Descriptor instead.
+func (*SetTeamReviewSLARequest) Descriptor() ([]byte, []int) {
+	return file_reviewer_v1_team_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *SetTeamReviewSLARequest) GetTeamName() string {
+	if x != nil {
+		return x.TeamName
+	}
+	return ""
+}
+
+func (x *SetTeamReviewSLARequest) GetReviewSlaMinutes() int32 {
+	if x != nil && x.ReviewSlaMinutes != nil {
+		return *x.ReviewSlaMinutes
+	}
+	return 0
+}
+
+func (x *SetTeamReviewSLARequest) GetEscalationMinutes() int32 {
+	if x != nil && x.EscalationMinutes != nil {
+		return *x.EscalationMinutes
+	}
+	return 0
+}
+
+func (x *SetTeamReviewSLARequest) GetExpectedVersion() int64 {
+	if x != nil && x.ExpectedVersion != nil {
+		return *x.ExpectedVersion
+	}
+	return 0
+}
+
+var File_reviewer_v1_team_proto protoreflect.FileDescriptor
+
+const file_reviewer_v1_team_proto_rawDesc = "" +
+	"\n" +
+	"\x16reviewer/v1/team.proto\x12\vreviewer.v1\x1a\x18reviewer/v1/models.proto\"\xd1\x02\n" +
+	"\x11CreateTeamRequest\x12\x1b\n" +
+	"\tteam_name\x18\x01 \x01(\tR\bteamName\x121\n" +
+	"\amembers\x18\x02 \x03(\v2\x17.reviewer.v1.TeamMemberR\amembers\x12;\n" +
+	"\x17default_review_capacity\x18\x03 \x01(\x05H\x00R\x15defaultReviewCapacity\x88\x01\x01\x121\n" +
+	"\x12review_sla_minutes\x18\x04 \x01(\x05H\x01R\x10reviewSlaMinutes\x88\x01\x01\x122\n" +
+	"\x12escalation_minutes\x18\x05 \x01(\x05H\x02R\x11escalationMinutes\x88\x01\x01B\x1a\n" +
+	"\x18_default_review_capacityB\x15\n" +
+	"\x13_review_sla_minutesB\x15\n" +
+	"\x13_escalation_minutes\"-\n" +
+	"\x0eGetTeamRequest\x12\x1b\n" +
+	"\tteam_name\x18\x01 \x01(\tR\bteamName\"\xd9\x01\n" +
+	"\x1cSetTeamReviewCapacityRequest\x12\x1b\n" +
+	"\tteam_name\x18\x01 \x01(\tR\bteamName\x12;\n" +
+	"\x17default_review_capacity\x18\x02 \x01(\x05H\x00R\x15defaultReviewCapacity\x88\x01\x01\x12.\n" +
+	"\x10expected_version\x18\x03 \x01(\x03H\x01R\x0fexpectedVersion\x88\x01\x01B\x1a\n" +
+	"\x18_default_review_capacityB\x13\n" +
+	"\x11_expected_version\"\x90\x02\n" +
+	"\x17SetTeamReviewSLARequest\x12\x1b\n" +
+	"\tteam_name\x18\x01 \x01(\tR\bteamName\x121\n" +
+	"\x12review_sla_minutes\x18\x02 \x01(\x05H\x00R\x10reviewSlaMinutes\x88\x01\x01\x122\n" +
+	"\x12escalation_minutes\x18\x03 \x01(\x05H\x01R\x11escalationMinutes\x88\x01\x01\x12.\n" +
+	"\x10expected_version\x18\x04 \x01(\x03H\x02R\x0fexpectedVersion\x88\x01\x01B\x15\n" +
+	"\x13_review_sla_minutesB\x15\n" +
+	"\x13_escalation_minutesB\x13\n" +
+	"\x11_expected_version2\xad\x02\n" +
+	"\vTeamService\x12?\n" +
+	"\n" +
+	"CreateTeam\x12\x1e.reviewer.v1.CreateTeamRequest\x1a\x11.reviewer.v1.Team\x129\n" +
+	"\aGetTeam\x12\x1b.reviewer.v1.GetTeamRequest\x1a\x11.reviewer.v1.Team\x12U\n" +
+	"\x15SetTeamReviewCapacity\x12).reviewer.v1.SetTeamReviewCapacityRequest\x1a\x11.reviewer.v1.Team\x12K\n" +
+	"\x10SetTeamReviewSLA\x12$.reviewer.v1.SetTeamReviewSLARequest\x1a\x11.reviewer.v1.TeamB9Z7avito/internal/transport/grpc/pb/reviewer/v1;reviewerv1b\x06proto3"
+
+var (
+	file_reviewer_v1_team_proto_rawDescOnce sync.Once
+	file_reviewer_v1_team_proto_rawDescData []byte
+)
+
+func file_reviewer_v1_team_proto_rawDescGZIP() []byte {
+	file_reviewer_v1_team_proto_rawDescOnce.Do(func() {
+		file_reviewer_v1_team_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_reviewer_v1_team_proto_rawDesc), len(file_reviewer_v1_team_proto_rawDesc)))
+	})
+	return file_reviewer_v1_team_proto_rawDescData
+}
+
+var file_reviewer_v1_team_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_reviewer_v1_team_proto_goTypes = []any{
+	(*CreateTeamRequest)(nil),            // 0: reviewer.v1.CreateTeamRequest
+	(*GetTeamRequest)(nil),               // 1: reviewer.v1.GetTeamRequest
+	(*SetTeamReviewCapacityRequest)(nil), // 2: reviewer.v1.SetTeamReviewCapacityRequest
+	(*SetTeamReviewSLARequest)(nil),      // 3: reviewer.v1.SetTeamReviewSLARequest
+	(*TeamMember)(nil),                   // 4: reviewer.v1.TeamMember
+	(*Team)(nil),                         // 5: reviewer.v1.Team
+}
+var file_reviewer_v1_team_proto_depIdxs = []int32{
+	4, // 0: reviewer.v1.CreateTeamRequest.members:type_name -> reviewer.v1.TeamMember
+	0, // 1: reviewer.v1.TeamService.CreateTeam:input_type -> reviewer.v1.CreateTeamRequest
+	1, // 2: reviewer.v1.TeamService.GetTeam:input_type -> reviewer.v1.GetTeamRequest
+	2, // 3: reviewer.v1.TeamService.SetTeamReviewCapacity:input_type -> reviewer.v1.SetTeamReviewCapacityRequest
+	3, // 4: reviewer.v1.TeamService.SetTeamReviewSLA:input_type -> reviewer.v1.SetTeamReviewSLARequest
+	5, // 5: reviewer.v1.TeamService.CreateTeam:output_type -> reviewer.v1.Team
+	5, // 6: reviewer.v1.TeamService.GetTeam:output_type -> reviewer.v1.Team
+	5, // 7: reviewer.v1.TeamService.SetTeamReviewCapacity:output_type -> reviewer.v1.Team
+	5, // 8: reviewer.v1.TeamService.SetTeamReviewSLA:output_type -> reviewer.v1.Team
+	5, // [5:9] is the sub-list for method output_type
+	1, // [1:5] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_reviewer_v1_team_proto_init() }
+func file_reviewer_v1_team_proto_init() {
+	if File_reviewer_v1_team_proto != nil {
+		return
+	}
+	file_reviewer_v1_models_proto_init()
+	file_reviewer_v1_team_proto_msgTypes[0].OneofWrappers = []any{}
+	file_reviewer_v1_team_proto_msgTypes[2].OneofWrappers = []any{}
+	file_reviewer_v1_team_proto_msgTypes[3].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_reviewer_v1_team_proto_rawDesc), len(file_reviewer_v1_team_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   4,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_reviewer_v1_team_proto_goTypes,
+		DependencyIndexes: file_reviewer_v1_team_proto_depIdxs,
+		MessageInfos:      file_reviewer_v1_team_proto_msgTypes,
+	}.Build()
+	File_reviewer_v1_team_proto = out.File
+	file_reviewer_v1_team_proto_goTypes = nil
+	file_reviewer_v1_team_proto_depIdxs = nil
+}
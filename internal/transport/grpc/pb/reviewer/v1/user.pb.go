@@ -0,0 +1,307 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.9
+// 	protoc        (unknown)
+// source: reviewer/v1/user.proto
+
+package reviewerv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type GetUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUserRequest) Reset() {
+	*x = GetUserRequest{}
+	mi := &file_reviewer_v1_user_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUserRequest) ProtoMessage() {}
+
+func (x *GetUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_reviewer_v1_user_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUserRequest.ProtoReflect.Descriptor instead.
+func (*GetUserRequest) Descriptor() ([]byte, []int) {
+	return file_reviewer_v1_user_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *GetUserRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type SetUserActiveRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	UserId   string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	IsActive bool                   `protobuf:"varint,2,opt,name=is_active,json=isActive,proto3" json:"is_active,omitempty"`
+	// Изменение применяется, только если команда пользователя имеет эту версию
+	ExpectedTeamVersion *int64 `protobuf:"varint,3,opt,name=expected_team_version,json=expectedTeamVersion,proto3,oneof" json:"expected_team_version,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *SetUserActiveRequest) Reset() {
+	*x = SetUserActiveRequest{}
+	mi := &file_reviewer_v1_user_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetUserActiveRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetUserActiveRequest) ProtoMessage() {}
+
+func (x *SetUserActiveRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_reviewer_v1_user_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetUserActiveRequest.ProtoReflect.Descriptor instead.
+func (*SetUserActiveRequest) Descriptor() ([]byte, []int) {
+	return file_reviewer_v1_user_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *SetUserActiveRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *SetUserActiveRequest) GetIsActive() bool {
+	if x != nil {
+		return x.IsActive
+	}
+	return false
+}
+
+func (x *SetUserActiveRequest) GetExpectedTeamVersion() int64 {
+	if x != nil && x.ExpectedTeamVersion != nil {
+		return *x.ExpectedTeamVersion
+	}
+	return 0
+}
+
+type ListUserReviewsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListUserReviewsRequest) Reset() {
+	*x = ListUserReviewsRequest{}
+	mi := &file_reviewer_v1_user_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListUserReviewsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListUserReviewsRequest) ProtoMessage() {}
+
+func (x *ListUserReviewsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_reviewer_v1_user_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListUserReviewsRequest.ProtoReflect.Descriptor instead.
+func (*ListUserReviewsRequest) Descriptor() ([]byte, []int) {
+	return file_reviewer_v1_user_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ListUserReviewsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type ListUserReviewsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	PullRequests  []*PullRequest         `protobuf:"bytes,2,rep,name=pull_requests,json=pullRequests,proto3" json:"pull_requests,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListUserReviewsResponse) Reset() {
+	*x = ListUserReviewsResponse{}
+	mi := &file_reviewer_v1_user_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListUserReviewsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListUserReviewsResponse) ProtoMessage() {}
+
+func (x *ListUserReviewsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_reviewer_v1_user_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListUserReviewsResponse.ProtoReflect.Descriptor instead.
+func (*ListUserReviewsResponse) Descriptor() ([]byte, []int) {
+	return file_reviewer_v1_user_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ListUserReviewsResponse) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ListUserReviewsResponse) GetPullRequests() []*PullRequest {
+	if x != nil {
+		return x.PullRequests
+	}
+	return nil
+}
+
+var File_reviewer_v1_user_proto protoreflect.FileDescriptor
+
+const file_reviewer_v1_user_proto_rawDesc = "" +
+	"\n" +
+	"\x16reviewer/v1/user.proto\x12\vreviewer.v1\x1a\x18reviewer/v1/models.proto\")\n" +
+	"\x0eGetUserRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"\x9f\x01\n" +
+	"\x14SetUserActiveRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1b\n" +
+	"\tis_active\x18\x02 \x01(\bR\bisActive\x127\n" +
+	"\x15expected_team_version\x18\x03 \x01(\x03H\x00R\x13expectedTeamVersion\x88\x01\x01B\x18\n" +
+	"\x16_expected_team_version\"1\n" +
+	"\x16ListUserReviewsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"q\n" +
+	"\x17ListUserReviewsResponse\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12=\n" +
+	"\rpull_requests\x18\x02 \x03(\v2\x18.reviewer.v1.PullRequestR\fpullRequests2\xed\x01\n" +
+	"\vUserService\x129\n" +
+	"\aGetUser\x12\x1b.reviewer.v1.GetUserRequest\x1a\x11.reviewer.v1.User\x12E\n" +
+	"\rSetUserActive\x12!.reviewer.v1.SetUserActiveRequest\x1a\x11.reviewer.v1.User\x12\\\n" +
+	"\x0fListUserReviews\x12#.reviewer.v1.ListUserReviewsRequest\x1a$.reviewer.v1.ListUserReviewsResponseB9Z7avito/internal/transport/grpc/pb/reviewer/v1;reviewerv1b\x06proto3"
+
+var (
+	file_reviewer_v1_user_proto_rawDescOnce sync.Once
+	file_reviewer_v1_user_proto_rawDescData []byte
+)
+
+func file_reviewer_v1_user_proto_rawDescGZIP() []byte {
+	file_reviewer_v1_user_proto_rawDescOnce.Do(func() {
+		file_reviewer_v1_user_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_reviewer_v1_user_proto_rawDesc), len(file_reviewer_v1_user_proto_rawDesc)))
+	})
+	return file_reviewer_v1_user_proto_rawDescData
+}
+
+var file_reviewer_v1_user_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_reviewer_v1_user_proto_goTypes = []any{
+	(*GetUserRequest)(nil),          // 0: reviewer.v1.GetUserRequest
+	(*SetUserActiveRequest)(nil),    // 1: reviewer.v1.SetUserActiveRequest
+	(*ListUserReviewsRequest)(nil),  // 2: reviewer.v1.ListUserReviewsRequest
+	(*ListUserReviewsResponse)(nil), // 3: reviewer.v1.ListUserReviewsResponse
+	(*PullRequest)(nil),             // 4: reviewer.v1.PullRequest
+	(*User)(nil),                    // 5: reviewer.v1.User
+}
+var file_reviewer_v1_user_proto_depIdxs = []int32{
+	4, // 0: reviewer.v1.ListUserReviewsResponse.pull_requests:type_name -> reviewer.v1.PullRequest
+	0, // 1: reviewer.v1.UserService.GetUser:input_type -> reviewer.v1.GetUserRequest
+	1, // 2: reviewer.v1.UserService.SetUserActive:input_type -> reviewer.v1.SetUserActiveRequest
+	2, // 3: reviewer.v1.UserService.ListUserReviews:input_type -> reviewer.v1.ListUserReviewsRequest
+	5, // 4: reviewer.v1.UserService.GetUser:output_type -> reviewer.v1.User
+	5, // 5: reviewer.v1.UserService.SetUserActive:output_type -> reviewer.v1.User
+	3, // 6: reviewer.v1.UserService.ListUserReviews:output_type -> reviewer.v1.ListUserReviewsResponse
+	4, // [4:7] is the sub-list for method output_type
+	1, // [1:4] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_reviewer_v1_user_proto_init() }
+func file_reviewer_v1_user_proto_init() {
+	if File_reviewer_v1_user_proto != nil {
+		return
+	}
+	file_reviewer_v1_models_proto_init()
+	file_reviewer_v1_user_proto_msgTypes[1].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_reviewer_v1_user_proto_rawDesc), len(file_reviewer_v1_user_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   4,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_reviewer_v1_user_proto_goTypes,
+		DependencyIndexes: file_reviewer_v1_user_proto_depIdxs,
+		MessageInfos:      file_reviewer_v1_user_proto_msgTypes,
+	}.Build()
+	File_reviewer_v1_user_proto = out.File
+	file_reviewer_v1_user_proto_goTypes = nil
+	file_reviewer_v1_user_proto_depIdxs = nil
+}
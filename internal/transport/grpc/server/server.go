@@ -0,0 +1,168 @@
+// Package server запускает gRPC-сервер рядом с HTTP-сервером и корректно останавливает его:
+// новые вызовы перестают приниматься, подписки на события закрываются, а начатые вызовы дорабатывают до таймаута.
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go.uber.org/zap"
+	"net"
+	"time"
+
+	"avito/internal/config"
+	"avito/internal/domain"
+	"avito/internal/transport/grpc/handler"
+	"avito/internal/transport/grpc/interceptor"
+	pb "avito/internal/transport/grpc/pb/reviewer/v1"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// methodRules - права методов gRPC API, те же, что у соответствующих маршрутов HTTP API
+var methodRules = map[string]interceptor.MethodRule{
+	pb.TeamService_CreateTeam_FullMethodName:            {Scope: domain.ScopeTeamsWrite, Mutating: true},
+	pb.TeamService_GetTeam_FullMethodName:               {Scope: domain.ScopeTeamsRead},
+	pb.TeamService_SetTeamReviewCapacity_FullMethodName: {Scope: domain.ScopeTeamsWrite, Mutating: true},
+	pb.TeamService_SetTeamReviewSLA_FullMethodName:      {Scope: domain.ScopeTeamsWrite, Mutating: true},
+
+	pb.UserService_GetUser_FullMethodName: {Scope: domain.ScopeUsersRead},
+	// Свою активность пользователь меняет без права, поэтому роль и право users:write для чужой активности проверяет сервис
+	pb.UserService_SetUserActive_FullMethodName:   {Mutating: true},
+	pb.UserService_ListUserReviews_FullMethodName: {Scope: domain.ScopeUsersRead},
+
+	pb.PullRequestService_CreatePullRequest_FullMethodName: {Scope: domain.ScopePRsWrite, Mutating: true},
+	pb.PullRequestService_GetPullRequest_FullMethodName:    {Scope: domain.ScopePRsRead},
+	pb.PullRequestService_ListPullRequests_FullMethodName:  {Scope: domain.ScopePRsRead},
+	pb.PullRequestService_MergePullRequest_FullMethodName:  {Scope: domain.ScopePRsWrite, Mutating: true},
+	pb.PullRequestService_ReassignReviewer_FullMethodName:  {Scope: domain.ScopePRsWrite, Mutating: true},
+	pb.PullRequestService_SubmitVerdict_FullMethodName:     {Scope: domain.ScopePRsWrite, Mutating: true},
+	pb.PullRequestService_WatchReviewEvents_FullMethodName: {Scope: domain.ScopePRsRead},
+
+	pb.StatsService_GetUserReviewStats_FullMethodName: {Scope: domain.ScopeStatsRead},
+	pb.StatsService_GetTeamReviewStats_FullMethodName: {Scope: domain.ScopeStatsRead},
+}
+
+// subscriptions - бесконечные потоки, которые закрываются в начале остановки, а не ждут ее таймаута
+var subscriptions = map[string]bool{
+	pb.PullRequestService_WatchReviewEvents_FullMethodName: true,
+}
+
+type Server struct {
+	srv             *grpc.Server
+	addr            string
+	shutdownTimeout time.Duration
+	// stopping закрывается в начале остановки и завершает подписки
+	stopping chan struct{}
+	log      *zap.Logger
+}
+
+func New(cfg config.GRPCConfig, shutdownTimeout time.Duration, services handler.Services, security interceptor.Security, log *zap.Logger) *Server {
+	s := &Server{
+		addr:            cfg.Addr,
+		shutdownTimeout: shutdownTimeout,
+		stopping:        make(chan struct{}),
+		log:             log.Named("GRPCServer"),
+	}
+	s.srv = grpc.NewServer(
+		grpc.ChainUnaryInterceptor(
+			interceptor.LoggingUnary(log),
+			interceptor.AuthUnary(security, methodRules),
+			interceptor.ErrorsUnary(),
+		),
+		grpc.ChainStreamInterceptor(
+			interceptor.LoggingStream(log),
+			s.closeSubscriptions,
+			interceptor.AuthStream(security, methodRules),
+			interceptor.ErrorsStream(),
+		),
+	)
+	handler.Register(s.srv, services)
+	return s
+}
+
+// Run слушает адрес сервера и обслуживает вызовы до отмены ctx, после чего останавливает сервер
+func (s *Server) Run(ctx context.Context) error {
+	ln, err := net.Listen("tcp", s.addr)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", s.addr, err)
+	}
+	return s.Serve(ctx, ln)
+}
+
+// Serve обслуживает вызовы на ln до отмены ctx. После отмены сервер перестает принимать вызовы,
+// закрывает подписки и ждет завершения начатых вызовов не дольше shutdownTimeout.
+// Возвращает nil, если все вызовы успели завершиться
+func (s *Server) Serve(ctx context.Context, ln net.Listener) error {
+	serveErr := make(chan error, 1)
+	go func() {
+		s.log.Info("Starting server", zap.String("addr", ln.Addr().String()))
+		serveErr <- s.srv.Serve(ln)
+	}()
+
+	select {
+	case err := <-serveErr:
+		return fmt.Errorf("server stopped unexpectedly: %w", err)
+	case <-ctx.Done():
+	}
+
+	s.log.Info("Shutting down server, draining in-flight calls", zap.Duration("timeout", s.shutdownTimeout))
+	close(s.stopping)
+	stopped := make(chan struct{})
+	go func() {
+		s.srv.GracefulStop()
+		close(stopped)
+	}()
+
+	timer := time.NewTimer(s.shutdownTimeout)
+	defer timer.Stop()
+	select {
+	case <-stopped:
+	case <-timer.C:
+		// Вызовы не уложились в таймаут: обрываем оставшиеся соединения
+		s.srv.Stop()
+		<-stopped
+		return errors.New("failed to drain in-flight calls before shutdown timeout")
+	}
+	if err := <-serveErr; err != nil && !errors.Is(err, grpc.ErrServerStopped) {
+		return fmt.Errorf("server stopped with error: %w", err)
+	}
+	s.log.Info("Server stopped")
+	return nil
+}
+
+// closeSubscriptions отменяет контекст подписок в начале остановки. Клиент получает Unavailable
+// и может переподключиться к другому экземпляру
+func (s *Server) closeSubscriptions(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if !subscriptions[info.FullMethod] {
+		return handler(srv, ss)
+	}
+	ctx, cancel := context.WithCancel(ss.Context())
+	defer cancel()
+	go func() {
+		select {
+		case <-s.stopping:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	err := handler(srv, &serverStream{ServerStream: ss, ctx: ctx})
+	select {
+	case <-s.stopping:
+		return status.Error(codes.Unavailable, "server is shutting down")
+	default:
+		return err
+	}
+}
+
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,167 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"avito/internal/config"
+	"avito/internal/domain"
+	"avito/internal/events"
+	"avito/internal/transport/grpc/handler"
+	"avito/internal/transport/grpc/interceptor"
+	pb "avito/internal/transport/grpc/pb/reviewer/v1"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+)
+
+type testServer struct {
+	bus      *events.Bus
+	conn     *grpc.ClientConn
+	cancel   context.CancelFunc
+	serveErr chan error
+}
+
+// startServer запускает сервер без сервисов предметной области: тесты проверяют только вызовы,
+// которые отклоняются или обслуживаются до обращения к сервисам
+func startServer(t *testing.T, security interceptor.Security) *testServer {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	bus := events.NewBus(zap.NewNop())
+	srv := New(config.GRPCConfig{Addr: ln.Addr().String()}, 5*time.Second, handler.Services{Events: bus}, security, zap.NewNop())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	ts := &testServer{bus: bus, cancel: cancel, serveErr: make(chan error, 1)}
+	go func() { ts.serveErr <- srv.Serve(ctx, ln) }()
+
+	ts.conn, err = grpc.NewClient(ln.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	t.Cleanup(func() {
+		cancel()
+		_ = ts.conn.Close()
+	})
+	return ts
+}
+
+func TestCallsWithoutCredentialsAreRejected(t *testing.T) {
+	ts := startServer(t, interceptor.Security{Enabled: true})
+
+	_, err := pb.NewTeamServiceClient(ts.conn).GetTeam(context.Background(), &pb.GetTeamRequest{TeamName: "backend"})
+	if status.Code(err) != codes.Unauthenticated {
+		t.Fatalf("got %v, want Unauthenticated", err)
+	}
+}
+
+func TestInvalidArgumentNamesField(t *testing.T) {
+	ts := startServer(t, interceptor.Security{})
+
+	_, err := pb.NewUserServiceClient(ts.conn).GetUser(context.Background(), &pb.GetUserRequest{UserId: "not-a-uuid"})
+	st := status.Convert(err)
+	if st.Code() != codes.InvalidArgument {
+		t.Fatalf("got %v, want InvalidArgument", err)
+	}
+	for _, detail := range st.Details() {
+		if badRequest, ok := detail.(*errdetails.BadRequest); ok {
+			violations := badRequest.GetFieldViolations()
+			if len(violations) != 1 || violations[0].GetField() != "user_id" {
+				t.Fatalf("got field violations %v, want user_id", violations)
+			}
+			return
+		}
+	}
+	t.Fatalf("status has no BadRequest details: %v", st.Details())
+}
+
+func TestWatchReviewEventsStreamsMatchingEvents(t *testing.T) {
+	ts := startServer(t, interceptor.Security{})
+	reviewerID := uuid.New()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	stream, err := pb.NewPullRequestServiceClient(ts.conn).WatchReviewEvents(ctx, &pb.WatchReviewEventsRequest{ReviewerId: reviewerID.String()})
+	if err != nil {
+		t.Fatalf("failed to open stream: %v", err)
+	}
+
+	// События до подписки не доставляются, поэтому публикуем, пока поток не получит первое событие
+	received := make(chan *pb.ReviewEvent, 1)
+	recvErr := make(chan error, 1)
+	go func() {
+		event, err := stream.Recv()
+		if err != nil {
+			recvErr <- err
+			return
+		}
+		received <- event
+	}()
+	ticker := time.NewTicker(20 * time.Millisecond)
+	defer ticker.Stop()
+	for {
+		ts.bus.Publish(ctx, domain.ReviewEvent{Type: domain.EventReviewerAssigned, PullRequestID: "pr-other", ReviewerID: uuid.New(), OccurredAt: time.Now()})
+		ts.bus.Publish(ctx, domain.ReviewEvent{Type: domain.EventReviewerAssigned, PullRequestID: "pr-1", ReviewerID: reviewerID, OccurredAt: time.Now()})
+		select {
+		case event := <-received:
+			if event.GetPullRequestId() != "pr-1" || event.GetType() != pb.ReviewEventType_REVIEW_EVENT_TYPE_REVIEWER_ASSIGNED {
+				t.Fatalf("got event %v, want REVIEWER_ASSIGNED for pr-1", event)
+			}
+			return
+		case err := <-recvErr:
+			t.Fatalf("stream failed: %v", err)
+		case <-ctx.Done():
+			t.Fatal("no event received")
+		case <-ticker.C:
+		}
+	}
+}
+
+func TestShutdownClosesSubscriptions(t *testing.T) {
+	ts := startServer(t, interceptor.Security{})
+
+	stream, err := pb.NewPullRequestServiceClient(ts.conn).WatchReviewEvents(context.Background(), &pb.WatchReviewEventsRequest{})
+	if err != nil {
+		t.Fatalf("failed to open stream: %v", err)
+	}
+	// Заголовки приходят после того, как сервер принял вызов и подписался
+	if _, err := stream.Header(); err != nil {
+		t.Fatalf("failed to receive headers: %v", err)
+	}
+	ts.cancel()
+
+	if _, err := stream.Recv(); status.Code(err) != codes.Unavailable {
+		t.Fatalf("got %v, want Unavailable", err)
+	}
+	select {
+	case err := <-ts.serveErr:
+		if err != nil {
+			t.Fatalf("Serve returned error after graceful shutdown: %v", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Serve waited for the subscription instead of closing it")
+	}
+}
+
+func TestServeReturnsErrorWhenListenerFails(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	_ = ln.Close()
+	srv := New(config.GRPCConfig{Addr: ln.Addr().String()}, time.Second, handler.Services{}, interceptor.Security{}, zap.NewNop())
+
+	if err := srv.Serve(context.Background(), ln); err == nil || errors.Is(err, grpc.ErrServerStopped) {
+		t.Fatalf("got %v, want listener error", err)
+	}
+}
@@ -1,6 +1,8 @@
 package dto
 
 import (
+	"strconv"
+	"strings"
 	"time"
 
 	"avito/internal/domain"
@@ -9,30 +11,69 @@ import (
 )
 
 type ErrorResponse struct {
-	Error ErrorBody `json:"error"`
+	Error     ErrorBody `json:"error"`
+	RequestID string    `json:"request_id,omitempty"`
 }
 
 type ErrorBody struct {
 	Code    string `json:"code"`
 	Message string `json:"message"`
+	// Details перечисляет поля запроса, не прошедшие проверку
+	Details []FieldError `json:"details,omitempty"`
+}
+
+// FieldError - поле запроса и причина, по которой оно не прошло проверку. Field - путь в терминах JSON,
+// например members[1].user_id
+type FieldError struct {
+	Field  string `json:"field"`
+	Reason string `json:"reason"`
 }
 
 type UserRequest struct {
-	UserID   uuid.UUID `json:"user_id"`
-	Username string    `json:"username"`
-	IsActive bool      `json:"is_active"`
+	UserID         uuid.UUID `json:"user_id" binding:"required"`
+	Username       string    `json:"username" binding:"notblank"`
+	IsActive       bool      `json:"is_active"`
+	ReviewCapacity *int      `json:"review_capacity,omitempty"`
+	// Role - роль пользователя. При создании команды необязательна, по умолчанию member
+	Role domain.Role `json:"role,omitempty"`
 }
 
 type CreateTeamDTO struct {
-	Name    string        `json:"team_name"`
-	Members []UserRequest `json:"members"`
+	Name                  string        `json:"team_name" binding:"notblank"`
+	Members               []UserRequest `json:"members" binding:"required,min=1,unique=UserID,dive"`
+	DefaultReviewCapacity *int          `json:"default_review_capacity,omitempty"`
+	ReviewSLAMinutes      *int          `json:"review_sla_minutes,omitempty"`
+	EscalationMinutes     *int          `json:"escalation_minutes,omitempty"`
+	// Version возвращается в ответах и дублируется в заголовке ETag, в запросе игнорируется
+	Version int64 `json:"version,omitempty"`
+}
+
+type SetTeamReviewSLARequest struct {
+	TeamName          string `json:"team_name" binding:"notblank"`
+	ReviewSLAMinutes  *int   `json:"review_sla_minutes"`
+	EscalationMinutes *int   `json:"escalation_minutes"`
+}
+
+type SetTeamReviewCapacityRequest struct {
+	TeamName              string `json:"team_name" binding:"notblank"`
+	DefaultReviewCapacity *int   `json:"default_review_capacity"`
+}
+
+type SetUserReviewCapacityRequest struct {
+	UserID         string `json:"user_id" binding:"required,uuid_rfc4122"`
+	ReviewCapacity *int   `json:"review_capacity"`
 }
 
 type SetUserActiveStatusRequest struct {
-	UserID   string `json:"user_id"`
+	UserID   string `json:"user_id" binding:"required,uuid_rfc4122"`
 	IsActive bool   `json:"is_active"`
 }
 
+type SetUserRoleRequest struct {
+	UserID string      `json:"user_id" binding:"required,uuid_rfc4122"`
+	Role   domain.Role `json:"role"`
+}
+
 type PullRequestShort struct {
 	PullRequestID   string          `json:"pull_request_id"`
 	PullRequestName string          `json:"pull_request_name"`
@@ -46,9 +87,10 @@ type ReviewUserResponse struct {
 }
 
 type CreatePullRequest struct {
-	PullRequestID   string `json:"pull_request_id"`
-	PullRequestName string `json:"pull_request_name"`
-	AuthorID        string `json:"author_id"`
+	PullRequestID   string   `json:"pull_request_id" binding:"notblank"`
+	PullRequestName string   `json:"pull_request_name" binding:"notblank"`
+	AuthorID        string   `json:"author_id" binding:"required,uuid_rfc4122"`
+	Labels          []string `json:"labels"`
 }
 
 type PullRequestResponse struct {
@@ -57,17 +99,45 @@ type PullRequestResponse struct {
 	Status            string      `json:"status"`
 	AuthorID          uuid.UUID   `json:"author_id"`
 	AssignedReviewers []uuid.UUID `json:"assigned_reviewers"`
+	Labels            []string    `json:"labels,omitempty"`
 	CreatedAt         time.Time   `json:"created_at"`
 	MergedAt          *time.Time  `json:"merged_at,omitempty"`
+	Version           int64       `json:"version"`
+}
+
+type AssignmentDTO struct {
+	Required          int  `json:"required"`
+	Assigned          int  `json:"assigned"`
+	SkippedAtCapacity int  `json:"skipped_at_capacity"`
+	LimitedByCapacity bool `json:"limited_by_capacity"`
+}
+
+type CreatePullRequestResponse struct {
+	PullRequestResponse
+	Assignment AssignmentDTO `json:"assignment"`
 }
 
 type SetMergeRequest struct {
-	PullRequestID string `json:"pull_request_id"`
+	PullRequestID string `json:"pull_request_id" binding:"notblank"`
 }
 
 type ReassignRequest struct {
-	PullRequestID string `json:"pull_request_id"`
-	OldUserID     string `json:"old_user_id"`
+	PullRequestID string `json:"pull_request_id" binding:"notblank"`
+	OldUserID     string `json:"old_user_id" binding:"required,uuid_rfc4122"`
+}
+
+// SubmitVerdictRequest - вердикт ревьюера. Без reviewer_id вердикт ставится от имени вызывающего
+type SubmitVerdictRequest struct {
+	PullRequestID string         `json:"pull_request_id" binding:"notblank"`
+	ReviewerID    string         `json:"reviewer_id,omitempty" binding:"omitempty,uuid_rfc4122"`
+	Verdict       domain.Verdict `json:"verdict" binding:"required"`
+}
+
+type VerdictResponse struct {
+	PullRequestID string         `json:"pull_request_id"`
+	ReviewerID    uuid.UUID      `json:"reviewer_id"`
+	Verdict       domain.Verdict `json:"verdict"`
+	SubmittedAt   time.Time      `json:"submitted_at"`
 }
 
 type ReassignResponse struct {
@@ -75,65 +145,409 @@ type ReassignResponse struct {
 	ReplacedBy  string              `json:"replaced_by"`
 }
 
+// Тела запросов API v2: идентификатор ресурса передается в пути, а не в теле
+
+type ReviewCapacityBody struct {
+	DefaultReviewCapacity *int `json:"default_review_capacity"`
+}
+
+type ReviewSLABody struct {
+	ReviewSLAMinutes  *int `json:"review_sla_minutes"`
+	EscalationMinutes *int `json:"escalation_minutes"`
+}
+
+type ActiveStatusBody struct {
+	IsActive bool `json:"is_active"`
+}
+
+type VerdictBody struct {
+	Verdict domain.Verdict `json:"verdict" binding:"required"`
+}
+
+type PullRequestReviewersResponse struct {
+	PullRequestID string      `json:"pull_request_id"`
+	Reviewers     []uuid.UUID `json:"reviewers"`
+	Version       int64       `json:"version"`
+}
+
+type UserTagsRequest struct {
+	UserID string   `json:"user_id" binding:"required,uuid_rfc4122"`
+	Tags   []string `json:"tags"`
+}
+
+type RemoveUserTagRequest struct {
+	UserID string `json:"user_id" binding:"required,uuid_rfc4122"`
+	Tag    string `json:"tag" binding:"notblank"`
+}
+
+type UserTagsResponse struct {
+	UserID string   `json:"user_id"`
+	Tags   []string `json:"tags"`
+}
+
+type AddUnavailabilityRequest struct {
+	UserID   string    `json:"user_id" binding:"required,uuid_rfc4122"`
+	StartsAt time.Time `json:"starts_at" binding:"required"`
+	EndsAt   time.Time `json:"ends_at" binding:"required"`
+	Reason   string    `json:"reason"`
+}
+
+type DeleteUnavailabilityRequest struct {
+	ID string `json:"id" binding:"required,uuid_rfc4122"`
+}
+
+type UnavailabilityDTO struct {
+	ID                  string     `json:"id"`
+	UserID              string     `json:"user_id"`
+	StartsAt            time.Time  `json:"starts_at"`
+	EndsAt              time.Time  `json:"ends_at"`
+	Reason              string     `json:"reason"`
+	UID                 string     `json:"uid,omitempty"`
+	ReviewsReassignedAt *time.Time `json:"reviews_reassigned_at,omitempty"`
+}
+
+type UserUnavailabilityResponse struct {
+	UserID  string              `json:"user_id"`
+	Periods []UnavailabilityDTO `json:"periods"`
+}
+
+type JobRunDTO struct {
+	ID         string         `json:"id"`
+	JobName    string         `json:"job_name"`
+	StartedAt  time.Time      `json:"started_at"`
+	FinishedAt time.Time      `json:"finished_at"`
+	Status     string         `json:"status"`
+	Details    map[string]any `json:"details"`
+	Error      string         `json:"error,omitempty"`
+}
+
+type JobRunsResponse struct {
+	Runs []JobRunDTO `json:"runs"`
+}
+
+type CreateAPIKeyRequest struct {
+	Name      string     `json:"name" binding:"notblank"`
+	Scopes    []string   `json:"scopes" binding:"required,min=1,unique"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+type RevokeAPIKeyRequest struct {
+	ID string `json:"id" binding:"required,uuid_rfc4122"`
+}
+
+type APIKeyDTO struct {
+	ID         string     `json:"id"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+	Scopes     []string   `json:"scopes"`
+	CreatedBy  string     `json:"created_by,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+}
+
+// CreateAPIKeyResponse содержит открытый ключ, он показывается только один раз
+type CreateAPIKeyResponse struct {
+	APIKey APIKeyDTO `json:"api_key"`
+	Key    string    `json:"key"`
+}
+
+type APIKeysResponse struct {
+	APIKeys []APIKeyDTO `json:"api_keys"`
+}
+
+type AuditEventDTO struct {
+	ID            string         `json:"id"`
+	OccurredAt    time.Time      `json:"occurred_at"`
+	PrincipalType string         `json:"principal_type"`
+	PrincipalID   string         `json:"principal_id"`
+	PrincipalName string         `json:"principal_name,omitempty"`
+	Action        string         `json:"action"`
+	Status        int            `json:"status"`
+	RequestID     string         `json:"request_id,omitempty"`
+	Details       map[string]any `json:"details"`
+}
+
+type AuditEventsResponse struct {
+	Events []AuditEventDTO `json:"events"`
+}
+
+type HealthResponse struct {
+	Status string `json:"status"`
+}
+
+type HealthCheckDTO struct {
+	Name      string         `json:"name"`
+	Status    string         `json:"status"`
+	LatencyMs float64        `json:"latency_ms"`
+	Error     string         `json:"error,omitempty"`
+	Details   map[string]any `json:"details,omitempty"`
+}
+
+type ReadinessResponse struct {
+	Status string           `json:"status"`
+	Checks []HealthCheckDTO `json:"checks"`
+}
+
 type UserStatDTO struct {
-	UserID      string `json:"user_id"`
-	Username    string `json:"username"`
-	IsActive    bool   `json:"is_active"`
-	ReviewCount int    `json:"review_assignments_count"`
+	UserID          string   `json:"user_id"`
+	Username        string   `json:"username"`
+	IsActive        bool     `json:"is_active"`
+	ReviewCount     int      `json:"review_assignments_count"`
+	OpenReviewCount int      `json:"open_reviews_count"`
+	ReviewCapacity  *int     `json:"review_capacity"`
+	Utilization     *float64 `json:"utilization"`
 }
 
 type StatsResponseDTO struct {
 	Stats []UserStatDTO `json:"stats"`
 }
 
+// StatsFilterQuery - query-параметры эндпоинтов статистики
+type StatsFilterQuery struct {
+	From     string `form:"from"`
+	To       string `form:"to"`
+	TeamName string `form:"team_name"`
+	Status   string `form:"status" binding:"omitempty,oneof=OPEN MERGED"`
+}
+
+type TeamStatDTO struct {
+	TeamName            string  `json:"team_name"`
+	PRsOpened           int     `json:"prs_opened"`
+	PRsMerged           int     `json:"prs_merged"`
+	AvgReviewersPerPR   float64 `json:"avg_reviewers_per_pr"`
+	PRsWithoutReviewers int     `json:"prs_without_reviewers"`
+	ZeroReviewersShare  float64 `json:"zero_reviewers_share"`
+}
+
+type TeamStatsResponseDTO struct {
+	Teams []TeamStatDTO `json:"teams"`
+}
+
+// LatencyQuery - query-параметры эндпоинта аналитики задержек
+type LatencyQuery struct {
+	From     string `form:"from"`
+	To       string `form:"to"`
+	TeamName string `form:"team_name"`
+	GroupBy  string `form:"group_by"`
+	Bucket   string `form:"bucket"`
+}
+
+// PercentilesDTO - перцентили длительности в секундах
+type PercentilesDTO struct {
+	Count      int     `json:"count"`
+	P50Seconds float64 `json:"p50_seconds"`
+	P90Seconds float64 `json:"p90_seconds"`
+	P99Seconds float64 `json:"p99_seconds"`
+}
+
+type LatencyBucketDTO struct {
+	Start time.Time `json:"start"`
+	PercentilesDTO
+}
+
+// LatencySeriesDTO - задержки одной группы. Для команд и авторов заполняется TimeToMerge,
+// для ревьюеров - TimeToFirstReview
+type LatencySeriesDTO struct {
+	Key               string             `json:"key"`
+	Name              string             `json:"name"`
+	TimeToMerge       *PercentilesDTO    `json:"time_to_merge,omitempty"`
+	TimeToFirstReview *PercentilesDTO    `json:"time_to_first_review,omitempty"`
+	Buckets           []LatencyBucketDTO `json:"buckets,omitempty"`
+}
+
+type LatencyResponse struct {
+	GroupBy string             `json:"group_by"`
+	Bucket  string             `json:"bucket,omitempty"`
+	Series  []LatencySeriesDTO `json:"series"`
+}
+
+// PullRequestListQuery - query-параметры выгрузки PR
+type PullRequestListQuery struct {
+	From     string `form:"from"`
+	To       string `form:"to"`
+	TeamName string `form:"team_name"`
+	AuthorID string `form:"author_id" binding:"omitempty,uuid_rfc4122"`
+	Status   string `form:"status" binding:"omitempty,oneof=OPEN MERGED"`
+}
+
+type PullRequestListResponse struct {
+	PullRequests []PullRequestResponse `json:"pull_requests"`
+}
+
+// PullRequestCSVHeader - заголовок CSV-выгрузки PR
+var PullRequestCSVHeader = []string{
+	"pull_request_id", "pull_request_name", "status", "author_id", "assigned_reviewers", "labels", "created_at", "merged_at",
+}
+
+// CSVRecord возвращает строку CSV в порядке PullRequestCSVHeader. Списки разделяются символом ';'
+func (p PullRequestResponse) CSVRecord() []string {
+	reviewers := make([]string, 0, len(p.AssignedReviewers))
+	for _, id := range p.AssignedReviewers {
+		reviewers = append(reviewers, id.String())
+	}
+	mergedAt := ""
+	if p.MergedAt != nil {
+		mergedAt = p.MergedAt.UTC().Format(time.RFC3339)
+	}
+	return []string{
+		p.PullRequestID,
+		p.PullRequestName,
+		p.Status,
+		p.AuthorID.String(),
+		strings.Join(reviewers, ";"),
+		strings.Join(p.Labels, ";"),
+		p.CreatedAt.UTC().Format(time.RFC3339),
+		mergedAt,
+	}
+}
+
+type MemberFairnessDTO struct {
+	UserID        string  `json:"user_id"`
+	Username      string  `json:"username"`
+	IsActive      bool    `json:"is_active"`
+	Assignments   int     `json:"assignments"`
+	ActiveDays    float64 `json:"active_days"`
+	Share         float64 `json:"share"`
+	ExpectedShare float64 `json:"expected_share"`
+	Deviation     float64 `json:"deviation"`
+}
+
+type TeamFairnessDTO struct {
+	TeamName         string              `json:"team_name"`
+	TotalAssignments int                 `json:"total_assignments"`
+	Gini             float64             `json:"gini"`
+	Members          []MemberFairnessDTO `json:"members"`
+}
+
+type FairnessResponse struct {
+	Teams []TeamFairnessDTO `json:"teams"`
+}
+
 // FromUserReviewStats преобразует срез доменных моделей в DTO для ответа.
 func FromUserReviewStats(stats []*domain.UserReviewStat) StatsResponseDTO {
 	statsDTO := make([]UserStatDTO, 0, len(stats))
 	for _, stat := range stats {
-		statsDTO = append(statsDTO, UserStatDTO{
-			UserID:      stat.UserID.String(),
-			Username:    stat.Username,
-			IsActive:    stat.IsActive,
-			ReviewCount: stat.ReviewCount,
-		})
+		statsDTO = append(statsDTO, ToUserStatDTO(stat))
 	}
 	return StatsResponseDTO{Stats: statsDTO}
 }
+
+func ToUserStatDTO(stat *domain.UserReviewStat) UserStatDTO {
+	return UserStatDTO{
+		UserID:          stat.UserID.String(),
+		Username:        stat.Username,
+		IsActive:        stat.IsActive,
+		ReviewCount:     stat.ReviewCount,
+		OpenReviewCount: stat.OpenReviewCount,
+		ReviewCapacity:  stat.ReviewCapacity,
+		Utilization:     stat.Utilization(),
+	}
+}
+
+// UserStatCSVHeader - заголовок CSV-выгрузки статистики пользователей
+var UserStatCSVHeader = []string{
+	"user_id", "username", "is_active", "review_assignments_count", "open_reviews_count", "review_capacity", "utilization",
+}
+
+// CSVRecord возвращает строку CSV в порядке UserStatCSVHeader. Отсутствующие значения выгружаются пустыми
+func (s UserStatDTO) CSVRecord() []string {
+	return []string{
+		s.UserID,
+		s.Username,
+		strconv.FormatBool(s.IsActive),
+		strconv.Itoa(s.ReviewCount),
+		strconv.Itoa(s.OpenReviewCount),
+		formatOptionalInt(s.ReviewCapacity),
+		formatOptionalFloat(s.Utilization),
+	}
+}
+
+// FromTeamReviewStats преобразует агрегаты по командам в DTO для ответа.
+func FromTeamReviewStats(stats []*domain.TeamReviewStat) TeamStatsResponseDTO {
+	teams := make([]TeamStatDTO, 0, len(stats))
+	for _, stat := range stats {
+		teams = append(teams, TeamStatDTO{
+			TeamName:            stat.TeamName,
+			PRsOpened:           stat.PRsOpened,
+			PRsMerged:           stat.PRsMerged,
+			AvgReviewersPerPR:   stat.AvgReviewersPerPR,
+			PRsWithoutReviewers: stat.PRsWithoutReviewers,
+			ZeroReviewersShare:  stat.ZeroReviewersShare(),
+		})
+	}
+	return TeamStatsResponseDTO{Teams: teams}
+}
 func FromUserDomain(user *domain.User) UserRequest {
 	return UserRequest{
-		UserID:   user.ID,
-		Username: user.Username,
-		IsActive: user.IsActive,
+		UserID:         user.ID,
+		Username:       user.Username,
+		IsActive:       user.IsActive,
+		ReviewCapacity: user.ReviewCapacity,
+		Role:           user.Role,
 	}
 }
 func ToTeamDomain(tr CreateTeamDTO) domain.Team {
 	members := make([]domain.User, 0, len(tr.Members))
 	for _, member := range tr.Members {
 		members = append(members, domain.User{
-			ID:       member.UserID,
-			Username: member.Username,
-			IsActive: member.IsActive,
+			ID:             member.UserID,
+			Username:       member.Username,
+			IsActive:       member.IsActive,
+			ReviewCapacity: member.ReviewCapacity,
+			Role:           member.Role,
 		})
 	}
 	return domain.Team{
-		Name:    tr.Name,
-		Members: members,
+		Name:                  tr.Name,
+		Members:               members,
+		DefaultReviewCapacity: tr.DefaultReviewCapacity,
+		ReviewSLAMinutes:      tr.ReviewSLAMinutes,
+		EscalationMinutes:     tr.EscalationMinutes,
 	}
 }
 func FromTeamDomain(team domain.Team) *CreateTeamDTO {
 	members := make([]UserRequest, 0, len(team.Members))
 	for _, member := range team.Members {
 		members = append(members, UserRequest{
-			UserID:   member.ID,
-			Username: member.Username,
-			IsActive: member.IsActive,
+			UserID:         member.ID,
+			Username:       member.Username,
+			IsActive:       member.IsActive,
+			ReviewCapacity: member.ReviewCapacity,
+			Role:           member.Role,
 		})
 	}
 	return &CreateTeamDTO{
-		Name:    team.Name,
-		Members: members,
+		Name:                  team.Name,
+		Members:               members,
+		DefaultReviewCapacity: team.DefaultReviewCapacity,
+		ReviewSLAMinutes:      team.ReviewSLAMinutes,
+		EscalationMinutes:     team.EscalationMinutes,
+		Version:               team.Version,
+	}
+}
+func ToVerdictResponse(verdict *domain.ReviewVerdict) VerdictResponse {
+	return VerdictResponse{
+		PullRequestID: verdict.PullRequestID,
+		ReviewerID:    verdict.ReviewerID,
+		Verdict:       verdict.Verdict,
+		SubmittedAt:   verdict.SubmittedAt,
 	}
 }
+
+func ToPullRequestReviewersResponse(pr *domain.PullRequest) PullRequestReviewersResponse {
+	reviewers := pr.AssignedReviewers
+	if reviewers == nil {
+		reviewers = []uuid.UUID{}
+	}
+	return PullRequestReviewersResponse{
+		PullRequestID: pr.ID,
+		Reviewers:     reviewers,
+		Version:       pr.Version,
+	}
+}
+
 func ToReviewUserResponse(pr []*domain.PullRequest, userID uuid.UUID) ReviewUserResponse {
 	var prs []*PullRequestShort
 	for _, pullRequest := range pr {
@@ -159,16 +573,217 @@ func ToPullRequestResponse(pr *domain.PullRequest) *PullRequestResponse {
 		Status:            string(pr.Status),
 		AuthorID:          pr.AuthorID,
 		AssignedReviewers: pr.AssignedReviewers,
+		Labels:            pr.Labels,
 		CreatedAt:         pr.CreatedAt,
+		Version:           pr.Version,
 	}
 	if pr.MergedAt != nil {
 		response.MergedAt = pr.MergedAt
 	}
 	return response
 }
+func ToUserTagsResponse(userID uuid.UUID, tags []string) UserTagsResponse {
+	return UserTagsResponse{
+		UserID: userID.String(),
+		Tags:   tags,
+	}
+}
+func ToCreatePullRequestResponse(pr *domain.PullRequest, report *domain.AssignmentReport) CreatePullRequestResponse {
+	return CreatePullRequestResponse{
+		PullRequestResponse: *ToPullRequestResponse(pr),
+		Assignment: AssignmentDTO{
+			Required:          report.Required,
+			Assigned:          report.Assigned,
+			SkippedAtCapacity: report.SkippedAtCapacity,
+			LimitedByCapacity: report.LimitedByCapacity(),
+		},
+	}
+}
 func ToReassignResponse(pr *domain.PullRequest, userID string) ReassignResponse {
 	return ReassignResponse{
 		PullRequest: *ToPullRequestResponse(pr),
 		ReplacedBy:  userID,
 	}
 }
+func ToUnavailabilityDTO(period *domain.Unavailability) UnavailabilityDTO {
+	return UnavailabilityDTO{
+		ID:                  period.ID.String(),
+		UserID:              period.UserID.String(),
+		StartsAt:            period.StartsAt,
+		EndsAt:              period.EndsAt,
+		Reason:              period.Reason,
+		UID:                 period.UID,
+		ReviewsReassignedAt: period.ReviewsReassignedAt,
+	}
+}
+func ToUserUnavailabilityResponse(userID uuid.UUID, periods []domain.Unavailability) UserUnavailabilityResponse {
+	periodsDTO := make([]UnavailabilityDTO, 0, len(periods))
+	for i := range periods {
+		periodsDTO = append(periodsDTO, ToUnavailabilityDTO(&periods[i]))
+	}
+	return UserUnavailabilityResponse{
+		UserID:  userID.String(),
+		Periods: periodsDTO,
+	}
+}
+func ToJobRunsResponse(runs []domain.JobRun) JobRunsResponse {
+	runsDTO := make([]JobRunDTO, 0, len(runs))
+	for _, run := range runs {
+		runsDTO = append(runsDTO, JobRunDTO{
+			ID:         run.ID.String(),
+			JobName:    run.JobName,
+			StartedAt:  run.StartedAt,
+			FinishedAt: run.FinishedAt,
+			Status:     string(run.Status),
+			Details:    run.Details,
+			Error:      run.Error,
+		})
+	}
+	return JobRunsResponse{Runs: runsDTO}
+}
+
+// ToLatencyResponse преобразует серии задержек в DTO для ответа.
+func ToLatencyResponse(filter domain.LatencyFilter, series []*domain.LatencySeries) LatencyResponse {
+	groupBy := filter.GroupBy
+	if groupBy == "" {
+		groupBy = domain.LatencyByTeam
+	}
+	response := LatencyResponse{
+		GroupBy: string(groupBy),
+		Bucket:  string(filter.Bucket),
+		Series:  make([]LatencySeriesDTO, 0, len(series)),
+	}
+	for _, s := range series {
+		seriesDTO := LatencySeriesDTO{Key: s.Key, Name: s.Name}
+		overall := toPercentilesDTO(s.Overall)
+		if groupBy == domain.LatencyByReviewer {
+			seriesDTO.TimeToFirstReview = &overall
+		} else {
+			seriesDTO.TimeToMerge = &overall
+		}
+		for _, bucket := range s.Buckets {
+			seriesDTO.Buckets = append(seriesDTO.Buckets, LatencyBucketDTO{
+				Start:          bucket.Start,
+				PercentilesDTO: toPercentilesDTO(bucket.LatencyPercentiles),
+			})
+		}
+		response.Series = append(response.Series, seriesDTO)
+	}
+	return response
+}
+
+func toPercentilesDTO(p domain.LatencyPercentiles) PercentilesDTO {
+	return PercentilesDTO{
+		Count:      p.Count,
+		P50Seconds: p.P50.Seconds(),
+		P90Seconds: p.P90.Seconds(),
+		P99Seconds: p.P99.Seconds(),
+	}
+}
+
+// ToFairnessResponse преобразует отчеты о равномерности назначений в DTO для ответа.
+func ToFairnessResponse(reports []*domain.TeamFairness) FairnessResponse {
+	teams := make([]TeamFairnessDTO, 0, len(reports))
+	for _, report := range reports {
+		members := make([]MemberFairnessDTO, 0, len(report.Members))
+		for _, m := range report.Members {
+			members = append(members, MemberFairnessDTO{
+				UserID:        m.UserID.String(),
+				Username:      m.Username,
+				IsActive:      m.IsActive,
+				Assignments:   m.Assignments,
+				ActiveDays:    m.ActiveDays,
+				Share:         m.Share,
+				ExpectedShare: m.ExpectedShare,
+				Deviation:     m.Deviation,
+			})
+		}
+		teams = append(teams, TeamFairnessDTO{
+			TeamName:         report.TeamName,
+			TotalAssignments: report.TotalAssignments,
+			Gini:             report.Gini,
+			Members:          members,
+		})
+	}
+	return FairnessResponse{Teams: teams}
+}
+
+func formatOptionalInt(v *int) string {
+	if v == nil {
+		return ""
+	}
+	return strconv.Itoa(*v)
+}
+
+func formatOptionalFloat(v *float64) string {
+	if v == nil {
+		return ""
+	}
+	return strconv.FormatFloat(*v, 'f', -1, 64)
+}
+
+func ToReadinessResponse(report domain.ReadinessReport) ReadinessResponse {
+	checks := make([]HealthCheckDTO, 0, len(report.Checks))
+	for _, check := range report.Checks {
+		checks = append(checks, HealthCheckDTO{
+			Name:      check.Name,
+			Status:    string(check.Status),
+			LatencyMs: float64(check.Latency.Microseconds()) / 1000,
+			Error:     check.Error,
+			Details:   check.Details,
+		})
+	}
+	return ReadinessResponse{Status: string(report.Status), Checks: checks}
+}
+
+func ToScopes(scopes []string) []domain.Scope {
+	result := make([]domain.Scope, 0, len(scopes))
+	for _, scope := range scopes {
+		result = append(result, domain.Scope(scope))
+	}
+	return result
+}
+
+func ToAPIKeyDTO(key domain.APIKey) APIKeyDTO {
+	scopes := make([]string, 0, len(key.Scopes))
+	for _, scope := range key.Scopes {
+		scopes = append(scopes, string(scope))
+	}
+	return APIKeyDTO{
+		ID:         key.ID.String(),
+		Name:       key.Name,
+		Prefix:     key.Prefix,
+		Scopes:     scopes,
+		CreatedBy:  key.CreatedBy,
+		CreatedAt:  key.CreatedAt,
+		ExpiresAt:  key.ExpiresAt,
+		RevokedAt:  key.RevokedAt,
+		LastUsedAt: key.LastUsedAt,
+	}
+}
+
+func ToAPIKeysResponse(keys []domain.APIKey) APIKeysResponse {
+	keysDTO := make([]APIKeyDTO, 0, len(keys))
+	for _, key := range keys {
+		keysDTO = append(keysDTO, ToAPIKeyDTO(key))
+	}
+	return APIKeysResponse{APIKeys: keysDTO}
+}
+
+func ToAuditEventsResponse(events []domain.AuditEvent) AuditEventsResponse {
+	eventsDTO := make([]AuditEventDTO, 0, len(events))
+	for _, event := range events {
+		eventsDTO = append(eventsDTO, AuditEventDTO{
+			ID:            event.ID.String(),
+			OccurredAt:    event.OccurredAt,
+			PrincipalType: string(event.PrincipalType),
+			PrincipalID:   event.PrincipalID,
+			PrincipalName: event.PrincipalName,
+			Action:        event.Action,
+			Status:        event.Status,
+			RequestID:     event.RequestID,
+			Details:       event.Details,
+		})
+	}
+	return AuditEventsResponse{Events: eventsDTO}
+}
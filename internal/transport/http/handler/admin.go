@@ -0,0 +1,76 @@
+package handler
+
+import (
+	"go.uber.org/zap"
+	"net/http"
+	"strconv"
+
+	"avito/internal/transport/http/dto"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (h *Handler) CreateAPIKey(c *gin.Context) {
+	log := c.MustGet("logger").(*zap.Logger)
+	var req dto.CreateAPIKeyRequest
+	if !h.bindJSON(c, log, &req) {
+		return
+	}
+
+	key, plaintext, err := h.apiKeyService.CreateAPIKey(c.Request.Context(), req.Name, dto.ToScopes(req.Scopes), req.ExpiresAt)
+	if err != nil {
+		h.fail(c, log, err, "create API key")
+		return
+	}
+	c.JSON(http.StatusCreated, dto.CreateAPIKeyResponse{APIKey: dto.ToAPIKeyDTO(*key), Key: plaintext})
+}
+
+func (h *Handler) ListAPIKeys(c *gin.Context) {
+	log := c.MustGet("logger").(*zap.Logger)
+	keys, err := h.apiKeyService.GetAPIKeys(c.Request.Context())
+	if err != nil {
+		h.fail(c, log, err, "get API keys")
+		return
+	}
+	c.JSON(http.StatusOK, dto.ToAPIKeysResponse(keys))
+}
+
+func (h *Handler) RevokeAPIKey(c *gin.Context) {
+	log := c.MustGet("logger").(*zap.Logger)
+	var req dto.RevokeAPIKeyRequest
+	if !h.bindJSON(c, log, &req) {
+		return
+	}
+	id, ok := h.parseUUID(c, log, "id", req.ID)
+	if !ok {
+		return
+	}
+
+	key, err := h.apiKeyService.RevokeAPIKey(c.Request.Context(), id)
+	if err != nil {
+		h.fail(c, log, err, "revoke API key")
+		return
+	}
+	c.JSON(http.StatusOK, dto.ToAPIKeyDTO(*key))
+}
+
+func (h *Handler) GetAuditEvents(c *gin.Context) {
+	log := c.MustGet("logger").(*zap.Logger)
+	limit := 0
+	if limitStr := c.Query("limit"); limitStr != "" {
+		parsed, err := strconv.Atoi(limitStr)
+		if err != nil {
+			log.Warn("Invalid limit query parameter", zap.String("limit", limitStr), zap.Error(err))
+			h.invalidFields(c, dto.FieldError{Field: "limit", Reason: "must be an integer"})
+			return
+		}
+		limit = parsed
+	}
+
+	events, err := h.auditService.GetAuditEvents(c.Request.Context(), c.Query("principal_id"), limit)
+	if err != nil {
+		h.fail(c, log, err, "get audit events")
+		return
+	}
+	c.JSON(http.StatusOK, dto.ToAuditEventsResponse(events))
+}
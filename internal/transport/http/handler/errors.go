@@ -0,0 +1,200 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"go.uber.org/zap"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"avito/internal/domain"
+	"avito/internal/transport/http/dto"
+	"avito/internal/transport/http/middleware"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+)
+
+// errorStatuses переводит коды ошибок предметной области в статусы HTTP.
+// Код без записи считается ошибкой запроса и получает 400
+var errorStatuses = map[domain.ErrorCode]int{
+	domain.CodeNotFound:        http.StatusNotFound,
+	domain.CodeTeamExists:      http.StatusConflict,
+	domain.CodePRExists:        http.StatusConflict,
+	domain.CodePRMerged:        http.StatusConflict,
+	domain.CodeNoCandidate:     http.StatusConflict,
+	domain.CodeNotAssigned:     http.StatusConflict,
+	domain.CodeAuthorInactive:  http.StatusForbidden,
+	domain.CodeForbidden:       http.StatusForbidden,
+	domain.CodeUnauthorized:    http.StatusUnauthorized,
+	domain.CodeVersionConflict: http.StatusPreconditionFailed,
+}
+
+// Имена JSON-полей в деталях ошибок берутся из тегов json или form, а notblank отклоняет строки из пробелов
+func init() {
+	v, ok := binding.Validator.Engine().(*validator.Validate)
+	if !ok {
+		return
+	}
+	v.RegisterTagNameFunc(func(field reflect.StructField) string {
+		for _, tag := range []string{"json", "form"} {
+			name, _, _ := strings.Cut(field.Tag.Get(tag), ",")
+			if name == "-" {
+				return ""
+			}
+			if name != "" {
+				return name
+			}
+		}
+		return field.Name
+	})
+	_ = v.RegisterValidation("notblank", func(fl validator.FieldLevel) bool {
+		return strings.TrimSpace(fl.Field().String()) != ""
+	})
+}
+
+// fail отвечает на ошибку сервиса. Ошибка предметной области отдается с ее кодом и сообщением,
+// остальные ошибки логируются как внутренние, а клиент получает 500 с описанием операции op
+func (h *Handler) fail(c *gin.Context, log *zap.Logger, err error, op string) {
+	domainErr, ok := domain.AsError(err)
+	if !ok {
+		log.Error("Failed to "+op, zap.Error(err))
+		h.responseError(c, http.StatusInternalServerError, codeInternalError, "failed to "+op)
+		return
+	}
+	status, ok := errorStatuses[domainErr.Code]
+	if !ok {
+		status = http.StatusBadRequest
+	}
+	log.Warn("Request rejected", zap.String("operation", op), zap.String("code", string(domainErr.Code)), zap.Error(err))
+	h.responseError(c, status, string(domainErr.Code), domainMessage(err, domainErr))
+}
+
+// domainMessage оставляет уточнение, которое сервис добавил после ошибки ("%w: причина"),
+// и отбрасывает контекст вызовов перед ней ("failed to ...: %w"), чтобы он не попадал к клиенту
+func domainMessage(err error, domainErr *domain.Error) string {
+	if message := err.Error(); strings.HasPrefix(message, domainErr.Message) {
+		return message
+	}
+	return domainErr.Message
+}
+
+// bindJSON разбирает и проверяет тело запроса. Если тело не подошло, ответ с ошибкой уже отправлен
+func (h *Handler) bindJSON(c *gin.Context, log *zap.Logger, req any) bool {
+	if err := c.ShouldBindJSON(req); err != nil {
+		log.Warn("Failed to decode request body", zap.Error(err))
+		h.responseBindError(c, err, "invalid request body")
+		return false
+	}
+	return true
+}
+
+// bindQuery разбирает и проверяет query-параметры. Если параметры не подошли, ответ с ошибкой уже отправлен
+func (h *Handler) bindQuery(c *gin.Context, log *zap.Logger, query any) bool {
+	if err := c.ShouldBindQuery(query); err != nil {
+		log.Warn("Failed to bind query parameters", zap.Error(err))
+		h.responseBindError(c, err, "invalid query parameters")
+		return false
+	}
+	return true
+}
+
+// parseUUID разбирает идентификатор из поля field запроса. Если он не разобран, ответ с ошибкой уже отправлен
+func (h *Handler) parseUUID(c *gin.Context, log *zap.Logger, field, value string) (uuid.UUID, bool) {
+	id, err := uuid.Parse(value)
+	if err != nil {
+		log.Warn("Failed to parse UUID", zap.String("field", field), zap.String("value", value), zap.Error(err))
+		h.invalidFields(c, dto.FieldError{Field: field, Reason: "must be a UUID"})
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+// invalidFields отвечает 400 со списком полей, не прошедших проверку
+func (h *Handler) invalidFields(c *gin.Context, fields ...dto.FieldError) {
+	h.responseErrorDetails(c, http.StatusBadRequest, codeValidationFailed, "request validation failed", fields)
+}
+
+// responseBindError отвечает на ошибку разбора: нарушения тегов binding и несовпадения типов JSON
+// перечисляются по полям, синтаксически неверное тело получает INVALID_BODY
+func (h *Handler) responseBindError(c *gin.Context, err error, message string) {
+	var validationErrs validator.ValidationErrors
+	if errors.As(err, &validationErrs) {
+		fields := make([]dto.FieldError, 0, len(validationErrs))
+		for _, fieldErr := range validationErrs {
+			fields = append(fields, dto.FieldError{Field: fieldPath(fieldErr), Reason: fieldReason(fieldErr)})
+		}
+		h.invalidFields(c, fields...)
+		return
+	}
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) && typeErr.Field != "" {
+		h.invalidFields(c, dto.FieldError{Field: typeErr.Field, Reason: "must be " + jsonTypeName(typeErr.Type)})
+		return
+	}
+	h.responseError(c, http.StatusBadRequest, codeInvalidBody, message)
+}
+
+// fieldPath возвращает путь поля без имени корневой структуры: members[1].user_id
+func fieldPath(fieldErr validator.FieldError) string {
+	_, path, found := strings.Cut(fieldErr.Namespace(), ".")
+	if !found {
+		return fieldErr.Field()
+	}
+	return path
+}
+
+func fieldReason(fieldErr validator.FieldError) string {
+	switch fieldErr.Tag() {
+	case "required":
+		return "is required"
+	case "notblank":
+		return "must not be blank"
+	case "uuid_rfc4122":
+		return "must be a UUID"
+	case "unique":
+		return "must not contain duplicates"
+	case "oneof":
+		return "must be one of " + strings.ReplaceAll(fieldErr.Param(), " ", ", ")
+	case "min":
+		return "must contain at least " + fieldErr.Param() + " item(s)"
+	default:
+		return "failed " + fieldErr.Tag() + " check"
+	}
+}
+
+func jsonTypeName(t reflect.Type) string {
+	switch t.Kind() {
+	case reflect.String:
+		return "a string"
+	case reflect.Bool:
+		return "a boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "an integer"
+	case reflect.Float32, reflect.Float64:
+		return "a number"
+	case reflect.Slice, reflect.Array:
+		return "an array"
+	default:
+		return "an object"
+	}
+}
+
+func (h *Handler) responseError(c *gin.Context, status int, code, message string) {
+	h.responseErrorDetails(c, status, code, message, nil)
+}
+
+func (h *Handler) responseErrorDetails(c *gin.Context, status int, code, message string, details []dto.FieldError) {
+	c.JSON(status, dto.ErrorResponse{
+		Error: dto.ErrorBody{
+			Code:    code,
+			Message: message,
+			Details: details,
+		},
+		RequestID: c.GetString(middleware.RequestIDKey),
+	})
+}
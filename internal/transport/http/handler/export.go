@@ -0,0 +1,133 @@
+package handler
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"go.uber.org/zap"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+type exportFormat string
+
+const (
+	formatJSON   exportFormat = "json"
+	formatCSV    exportFormat = "csv"
+	formatNDJSON exportFormat = "ndjson"
+
+	contentTypeCSV    = "text/csv; charset=utf-8"
+	contentTypeNDJSON = "application/x-ndjson"
+)
+
+var errUnknownFormat = errors.New("unknown export format")
+
+// negotiateFormat выбирает формат ответа. Параметр format важнее заголовка Accept,
+// без них ответ отдается в JSON.
+func negotiateFormat(c *gin.Context) (exportFormat, error) {
+	if format := strings.ToLower(c.Query("format")); format != "" {
+		switch exportFormat(format) {
+		case formatJSON, formatCSV, formatNDJSON:
+			return exportFormat(format), nil
+		}
+		return "", errUnknownFormat
+	}
+
+	accept := c.GetHeader("Accept")
+	switch {
+	case strings.Contains(accept, "text/csv"):
+		return formatCSV, nil
+	case strings.Contains(accept, "application/x-ndjson"):
+		return formatNDJSON, nil
+	}
+	return formatJSON, nil
+}
+
+// streamWriter построчно пишет ответ в CSV или NDJSON. Заголовки ответа отправляются
+// при записи первой строки, поэтому до нее еще можно ответить ошибкой в JSON.
+type streamWriter struct {
+	c         *gin.Context
+	format    exportFormat
+	filename  string
+	csvHeader []string
+	csv       *csv.Writer
+	json      *json.Encoder
+	started   bool
+}
+
+func newStreamWriter(c *gin.Context, format exportFormat, filename string, csvHeader []string) *streamWriter {
+	return &streamWriter{
+		c:         c,
+		format:    format,
+		filename:  filename,
+		csvHeader: csvHeader,
+	}
+}
+
+func (w *streamWriter) start() error {
+	if w.started {
+		return nil
+	}
+	w.started = true
+
+	header := w.c.Writer.Header()
+	switch w.format {
+	case formatCSV:
+		header.Set("Content-Type", contentTypeCSV)
+		header.Set("Content-Disposition", `attachment; filename="`+w.filename+`.csv"`)
+		w.c.Status(http.StatusOK)
+		w.csv = csv.NewWriter(w.c.Writer)
+		return w.csv.Write(w.csvHeader)
+	default:
+		header.Set("Content-Type", contentTypeNDJSON)
+		w.c.Status(http.StatusOK)
+		w.json = json.NewEncoder(w.c.Writer)
+		return nil
+	}
+}
+
+// Write записывает одну строку: record для CSV или value для NDJSON
+func (w *streamWriter) Write(record []string, value any) error {
+	if err := w.start(); err != nil {
+		return err
+	}
+	if w.format == formatCSV {
+		if err := w.csv.Write(record); err != nil {
+			return err
+		}
+		// Сбрасываем буфер построчно, чтобы клиент получал данные по мере чтения из базы
+		w.csv.Flush()
+		if err := w.csv.Error(); err != nil {
+			return err
+		}
+	} else if err := w.json.Encode(value); err != nil {
+		return err
+	}
+	w.c.Writer.Flush()
+	return nil
+}
+
+// Started сообщает, были ли уже отправлены заголовки ответа
+func (w *streamWriter) Started() bool {
+	return w.started
+}
+
+// Close завершает выгрузку. Пустая выгрузка все равно отдает заголовки (и строку заголовка CSV)
+func (w *streamWriter) Close() error {
+	if err := w.start(); err != nil {
+		return err
+	}
+	if w.csv != nil {
+		w.csv.Flush()
+		return w.csv.Error()
+	}
+	return nil
+}
+
+// abortStream обрабатывает ошибку посреди выгрузки: статус уже отправлен, поэтому ответ просто обрывается
+func abortStream(c *gin.Context, log *zap.Logger, err error) {
+	log.Error("Export stream interrupted", zap.Error(err))
+	c.Abort()
+}
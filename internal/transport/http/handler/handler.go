@@ -1,9 +1,10 @@
 package handler
 
 import (
-	"errors"
+	"context"
 	"go.uber.org/zap"
 	"net/http"
+	"strconv"
 
 	"avito/internal/domain"
 	"avito/internal/service"
@@ -13,59 +14,53 @@ import (
 	"github.com/google/uuid"
 )
 
+// Коды ошибок транспорта. Коды ошибок предметной области задаются в domain и отдаются через fail
 const (
 	codeInternalError       = "INTERNAL_ERROR"
 	codeInvalidBody         = "INVALID_BODY"
-	codeParametersIncorrect = "PARAMETERS_INCORRECT"
-	codeTeamExists          = "TEAM_EXISTS"
-	codeNotFound            = "NOT_FOUND"
-	codePRExists            = "PR_EXISTS"
-	codePRMerged            = "PR_MERGED"
-	codeNoCandidate         = "NO_CANDIDATE"
-	codeNotAssigned         = "NOT_ASSIGNED"
-	codeAuthorInactive      = "AUTHOR_INACTIVE"
+	codeValidationFailed    = "VALIDATION_FAILED"
+	codeParametersIncorrect = string(domain.CodeInvalidArgument)
+	codePreconditionFailed  = string(domain.CodeVersionConflict)
 )
 
 type Handler struct {
-	teamService  service.TeamService
-	userService  service.UserService
-	statsService service.StatsService
-	prService    service.PullRequestService
+	teamService           service.TeamService
+	userService           service.UserService
+	statsService          service.StatsService
+	prService             service.PullRequestService
+	unavailabilityService service.UnavailabilityService
+	jobRunService         service.JobRunService
+	healthService         service.HealthService
+	apiKeyService         service.APIKeyService
+	auditService          service.AuditService
 }
 
-func NewHandler(teamService service.TeamService, userService service.UserService, statsService service.StatsService, prService service.PullRequestService) *Handler {
+func NewHandler(teamService service.TeamService, userService service.UserService, statsService service.StatsService, prService service.PullRequestService, unavailabilityService service.UnavailabilityService, jobRunService service.JobRunService, healthService service.HealthService, apiKeyService service.APIKeyService, auditService service.AuditService) *Handler {
 	return &Handler{
-		teamService:  teamService,
-		userService:  userService,
-		statsService: statsService,
-		prService:    prService,
+		teamService:           teamService,
+		userService:           userService,
+		statsService:          statsService,
+		prService:             prService,
+		unavailabilityService: unavailabilityService,
+		jobRunService:         jobRunService,
+		healthService:         healthService,
+		apiKeyService:         apiKeyService,
+		auditService:          auditService,
 	}
 }
 
 func (h *Handler) CreateTeam(c *gin.Context) {
 	log := c.MustGet("logger").(*zap.Logger)
 	var req dto.CreateTeamDTO
-	if err := c.ShouldBindJSON(&req); err != nil {
-		log.Warn("Failed to decode request body", zap.Error(err))
-		h.responseError(c, http.StatusBadRequest, codeInvalidBody, "invalid request body")
+	if !h.bindJSON(c, log, &req) {
 		return
 	}
 	team, err := h.teamService.CreateTeamWithMembers(c.Request.Context(), dto.ToTeamDomain(req))
 	if err != nil {
-		if errors.Is(err, domain.ErrOneOfParametersNil) {
-			log.Warn("One of the parameters is nil", zap.Error(err))
-			h.responseError(c, http.StatusBadRequest, codeParametersIncorrect, "one of the parameters is incorrect")
-			return
-		}
-		if errors.Is(err, domain.ErrTeamExists) {
-			log.Warn("Team already exists", zap.Error(err))
-			h.responseError(c, http.StatusConflict, codeTeamExists, "team already exists")
-			return
-		}
-		log.Error("Failed to create team", zap.Error(err))
-		h.responseError(c, http.StatusInternalServerError, codeInternalError, "failed to create team")
+		h.fail(c, log, err, "create team")
 		return
 	}
+	setETag(c, team.Version)
 	c.JSON(http.StatusCreated, dto.FromTeamDomain(*team))
 }
 
@@ -74,77 +69,147 @@ func (h *Handler) GetTeam(c *gin.Context) {
 	teamName := c.Query("team_name")
 	if teamName == "" {
 		log.Warn("team_name query parameter is missing")
-		h.responseError(c, http.StatusBadRequest, codeParametersIncorrect, "team_name query parameter is required")
+		h.invalidFields(c, dto.FieldError{Field: "team_name", Reason: "is required"})
 		return
 	}
+	h.getTeam(c, log, teamName)
+}
+
+func (h *Handler) getTeam(c *gin.Context, log *zap.Logger, teamName string) {
 	team, err := h.teamService.GetTeamByName(c.Request.Context(), teamName)
 	if err != nil {
-		if errors.Is(err, domain.ErrNotFound) {
-			log.Warn("Team not found", zap.String("team_name", teamName))
-			h.responseError(c, http.StatusNotFound, codeNotFound, "team not found")
-			return
-		}
-		log.Error("Failed to get team", zap.String("team_name", teamName), zap.Error(err))
-		h.responseError(c, http.StatusInternalServerError, codeInternalError, "failed to get team")
+		h.fail(c, log, err, "get team")
 		return
 	}
 
+	setETag(c, team.Version)
 	c.JSON(http.StatusOK, dto.FromTeamDomain(*team))
 }
 
+func (h *Handler) SetTeamReviewCapacity(c *gin.Context) {
+	log := c.MustGet("logger").(*zap.Logger)
+	var req dto.SetTeamReviewCapacityRequest
+	if !h.bindJSON(c, log, &req) {
+		return
+	}
+	h.setTeamReviewCapacity(c, log, req.TeamName, req.DefaultReviewCapacity)
+}
+
+func (h *Handler) setTeamReviewCapacity(c *gin.Context, log *zap.Logger, teamName string, capacity *int) {
+	expectedVersion, ok := h.ifMatchVersion(c, log)
+	if !ok {
+		return
+	}
+	team, err := h.teamService.SetDefaultReviewCapacity(c.Request.Context(), teamName, capacity, expectedVersion)
+	if err != nil {
+		h.fail(c, log, err, "set review capacity")
+		return
+	}
+	setETag(c, team.Version)
+	c.JSON(http.StatusOK, dto.FromTeamDomain(*team))
+}
+
+func (h *Handler) SetTeamReviewSLA(c *gin.Context) {
+	log := c.MustGet("logger").(*zap.Logger)
+	var req dto.SetTeamReviewSLARequest
+	if !h.bindJSON(c, log, &req) {
+		return
+	}
+	h.setTeamReviewSLA(c, log, req.TeamName, req.ReviewSLAMinutes, req.EscalationMinutes)
+}
+
+func (h *Handler) setTeamReviewSLA(c *gin.Context, log *zap.Logger, teamName string, slaMinutes, escalationMinutes *int) {
+	expectedVersion, ok := h.ifMatchVersion(c, log)
+	if !ok {
+		return
+	}
+	team, err := h.teamService.SetReviewSLA(c.Request.Context(), teamName, slaMinutes, escalationMinutes, expectedVersion)
+	if err != nil {
+		h.fail(c, log, err, "set team review SLA")
+		return
+	}
+	setETag(c, team.Version)
+	c.JSON(http.StatusOK, dto.FromTeamDomain(*team))
+}
+
+func (h *Handler) SetUserReviewCapacity(c *gin.Context) {
+	log := c.MustGet("logger").(*zap.Logger)
+	var req dto.SetUserReviewCapacityRequest
+	if !h.bindJSON(c, log, &req) {
+		return
+	}
+	userID, ok := h.parseUUID(c, log, "user_id", req.UserID)
+	if !ok {
+		return
+	}
+	user, err := h.userService.SetReviewCapacity(c.Request.Context(), userID, req.ReviewCapacity)
+	if err != nil {
+		h.fail(c, log, err, "set review capacity")
+		return
+	}
+	c.JSON(http.StatusOK, dto.FromUserDomain(user))
+}
+
 func (h *Handler) SetUserActiveStatus(c *gin.Context) {
 	log := c.MustGet("logger").(*zap.Logger)
 	var req dto.SetUserActiveStatusRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		log.Warn("Failed to decode request body", zap.Error(err))
-		h.responseError(c, http.StatusBadRequest, codeInvalidBody, "invalid request body")
+	if !h.bindJSON(c, log, &req) {
 		return
 	}
-	userID, err := uuid.Parse(req.UserID)
-	if err != nil {
-		log.Warn("Failed to parse user ID", zap.String("user_id", req.UserID), zap.Error(err))
-		h.responseError(c, http.StatusBadRequest, codeInvalidBody, "invalid user ID")
+	userID, ok := h.parseUUID(c, log, "user_id", req.UserID)
+	if !ok {
+		return
+	}
+	h.setUserActive(c, log, userID, req.IsActive)
+}
+
+func (h *Handler) setUserActive(c *gin.Context, log *zap.Logger, userID uuid.UUID, isActive bool) {
+	// If-Match сравнивается с версией команды пользователя: активность меняет ее состав
+	expectedTeamVersion, ok := h.ifMatchVersion(c, log)
+	if !ok {
 		return
 	}
-	user, err := h.userService.SetIsActive(c.Request.Context(), userID, req.IsActive)
+	user, err := h.userService.SetIsActive(c.Request.Context(), userID, isActive, expectedTeamVersion)
 	if err != nil {
-		if errors.Is(err, domain.ErrOneOfParametersNil) {
-			log.Warn("One of the parameters is nil", zap.Error(err))
-			h.responseError(c, http.StatusBadRequest, codeParametersIncorrect, "one of the parameters is incorrect")
-			return
-		}
-		if errors.Is(err, domain.ErrNotFound) {
-			log.Warn("User not found", zap.String("user_id", req.UserID))
-			h.responseError(c, http.StatusNotFound, codeNotFound, "user not found")
-			return
-		}
-		log.Error("Failed to set user active status", zap.String("user_id", req.UserID), zap.Error(err))
-		h.responseError(c, http.StatusInternalServerError, codeInternalError, "failed to set user active status")
+		h.fail(c, log, err, "set user active status")
 		return
 	}
 
 	c.JSON(http.StatusOK, dto.FromUserDomain(user))
 }
 
+func (h *Handler) SetUserRole(c *gin.Context) {
+	log := c.MustGet("logger").(*zap.Logger)
+	var req dto.SetUserRoleRequest
+	if !h.bindJSON(c, log, &req) {
+		return
+	}
+	userID, ok := h.parseUUID(c, log, "user_id", req.UserID)
+	if !ok {
+		return
+	}
+	user, err := h.userService.SetRole(c.Request.Context(), userID, req.Role)
+	if err != nil {
+		h.fail(c, log, err, "set user role")
+		return
+	}
+	c.JSON(http.StatusOK, dto.FromUserDomain(user))
+}
+
 func (h *Handler) GetUserReview(c *gin.Context) {
 	log := c.MustGet("logger").(*zap.Logger)
 	userIDStr := c.Query("user_id")
-	userID, err := uuid.Parse(userIDStr)
-	if err != nil {
-		log.Warn("Invalid user_id query parameter", zap.String("user_id", userIDStr), zap.Error(err))
-		h.responseError(c, http.StatusBadRequest, codeInvalidBody, "invalid user_id query parameter")
+	userID, ok := h.parseUUID(c, log, "user_id", userIDStr)
+	if !ok {
 		return
 	}
+	h.getUserReview(c, log, userID)
+}
 
+func (h *Handler) getUserReview(c *gin.Context, log *zap.Logger, userID uuid.UUID) {
 	reviews, err := h.userService.GetReviewsForUser(c.Request.Context(), userID)
 	if err != nil {
-		if errors.Is(err, domain.ErrNotFound) {
-			log.Warn("user not found", zap.String("user_id", userID.String()))
-			h.responseError(c, http.StatusNotFound, codeNotFound, "user not found")
-			return
-		}
-		log.Error("Failed to get reviews for user", zap.String("user_id", userID.String()), zap.Error(err))
-		h.responseError(c, http.StatusInternalServerError, codeInternalError, "failed to get reviews for user")
+		h.fail(c, log, err, "get reviews for user")
 		return
 	}
 
@@ -152,136 +217,263 @@ func (h *Handler) GetUserReview(c *gin.Context) {
 	c.JSON(http.StatusOK, dto.ToReviewUserResponse(reviews, userID))
 }
 
+func (h *Handler) GetUserTags(c *gin.Context) {
+	log := c.MustGet("logger").(*zap.Logger)
+	userIDStr := c.Query("user_id")
+	userID, ok := h.parseUUID(c, log, "user_id", userIDStr)
+	if !ok {
+		return
+	}
+
+	tags, err := h.userService.GetUserTags(c.Request.Context(), userID)
+	if err != nil {
+		h.fail(c, log, err, "process user tags")
+		return
+	}
+	c.JSON(http.StatusOK, dto.ToUserTagsResponse(userID, tags))
+}
+
+func (h *Handler) AddUserTags(c *gin.Context) {
+	h.changeUserTags(c, h.userService.AddUserTags)
+}
+
+func (h *Handler) SetUserTags(c *gin.Context) {
+	h.changeUserTags(c, h.userService.SetUserTags)
+}
+
+func (h *Handler) RemoveUserTag(c *gin.Context) {
+	log := c.MustGet("logger").(*zap.Logger)
+	var req dto.RemoveUserTagRequest
+	if !h.bindJSON(c, log, &req) {
+		return
+	}
+	userID, ok := h.parseUUID(c, log, "user_id", req.UserID)
+	if !ok {
+		return
+	}
+
+	tags, err := h.userService.RemoveUserTag(c.Request.Context(), userID, req.Tag)
+	if err != nil {
+		h.fail(c, log, err, "process user tags")
+		return
+	}
+	c.JSON(http.StatusOK, dto.ToUserTagsResponse(userID, tags))
+}
+
+// changeUserTags обрабатывает запросы, изменяющие набор тегов пользователя
+func (h *Handler) changeUserTags(c *gin.Context, change func(ctx context.Context, userID uuid.UUID, tags []string) ([]string, error)) {
+	log := c.MustGet("logger").(*zap.Logger)
+	var req dto.UserTagsRequest
+	if !h.bindJSON(c, log, &req) {
+		return
+	}
+	userID, ok := h.parseUUID(c, log, "user_id", req.UserID)
+	if !ok {
+		return
+	}
+
+	tags, err := change(c.Request.Context(), userID, req.Tags)
+	if err != nil {
+		h.fail(c, log, err, "process user tags")
+		return
+	}
+	c.JSON(http.StatusOK, dto.ToUserTagsResponse(userID, tags))
+}
+
 func (h *Handler) CreatePR(c *gin.Context) {
 	log := c.MustGet("logger").(*zap.Logger)
 	var req dto.CreatePullRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		log.Warn("Failed to decode request body", zap.Error(err))
-		h.responseError(c, http.StatusBadRequest, codeInvalidBody, "invalid request body")
+	if !h.bindJSON(c, log, &req) {
 		return
 	}
-	authorID, err := uuid.Parse(req.AuthorID)
-	if err != nil {
-		log.Warn("Invalid author ID query parameter", zap.String("author_id", req.AuthorID), zap.Error(err))
-		h.responseError(c, http.StatusBadRequest, codeInvalidBody, "invalid author ID query parameter")
+	authorID, ok := h.parseUUID(c, log, "author_id", req.AuthorID)
+	if !ok {
 		return
 	}
 
-	pullRequest, err := h.prService.CreatePR(c.Request.Context(), req.PullRequestID, req.PullRequestName, authorID)
+	pullRequest, report, err := h.prService.CreatePR(c.Request.Context(), req.PullRequestID, req.PullRequestName, authorID, req.Labels)
 	if err != nil {
-		if errors.Is(err, domain.ErrPRExists) {
-			log.Warn("Pull request already exists")
-			h.responseError(c, http.StatusConflict, codePRExists, "PR id already exists")
-			return
-		}
-		if errors.Is(err, domain.ErrNotFound) {
-			log.Warn("Author not found", zap.String("author_id", req.AuthorID))
-			h.responseError(c, http.StatusNotFound, codeNotFound, "author not found")
-			return
-		}
-		if errors.Is(err, domain.ErrAuthorIsInactive) {
-			log.Warn("Author is inactive", zap.String("author_id", req.AuthorID))
-			h.responseError(c, http.StatusForbidden, codeAuthorInactive, "author is inactive and cannot create pull requests")
-			return
-		}
-		log.Error("Failed to create pull request", zap.Error(err))
-		h.responseError(c, http.StatusInternalServerError, codeInternalError, "failed to create pull request")
+		h.fail(c, log, err, "create pull request")
 		return
 	}
-	c.JSON(http.StatusCreated, dto.ToPullRequestResponse(pullRequest))
+	setETag(c, pullRequest.Version)
+	c.JSON(http.StatusCreated, dto.ToCreatePullRequestResponse(pullRequest, report))
 
 }
 
 func (h *Handler) SetMerge(c *gin.Context) {
 	log := c.MustGet("logger").(*zap.Logger)
 	var req dto.SetMergeRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		log.Warn("Failed to decode request body", zap.Error(err))
-		h.responseError(c, http.StatusBadRequest, codeInvalidBody, "invalid request body")
+	if !h.bindJSON(c, log, &req) {
 		return
 	}
-	pr, err := h.prService.SetMerge(c.Request.Context(), req.PullRequestID)
+	h.mergePullRequest(c, log, req.PullRequestID)
+}
+
+func (h *Handler) mergePullRequest(c *gin.Context, log *zap.Logger, prID string) {
+	expectedVersion, ok := h.ifMatchVersion(c, log)
+	if !ok {
+		return
+	}
+	pr, err := h.prService.SetMerge(c.Request.Context(), prID, expectedVersion)
 	if err != nil {
-		if errors.Is(err, domain.ErrNotFound) {
-			log.Warn("Pull request not found", zap.String("pull_request_id", req.PullRequestID))
-			h.responseError(c, http.StatusNotFound, codeNotFound, "pull request not found")
-			return
-		}
-		log.Error("Failed to set merge pull request", zap.Error(err))
-		h.responseError(c, http.StatusInternalServerError, codeInternalError, "failed to set merge pull request")
+		h.fail(c, log, err, "set merge pull request")
 		return
 	}
+	setETag(c, pr.Version)
 	c.JSON(http.StatusOK, dto.ToPullRequestResponse(pr))
 }
 
 func (h *Handler) Reassign(c *gin.Context) {
 	log := c.MustGet("logger").(*zap.Logger)
 	var req dto.ReassignRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		log.Warn("Failed to decode request body", zap.Error(err))
-		h.responseError(c, http.StatusBadRequest, codeInvalidBody, "invalid request body")
+	if !h.bindJSON(c, log, &req) {
 		return
 	}
-	oldUserID, err := uuid.Parse(req.OldUserID)
-	if err != nil {
-		log.Warn("Failed to parse old user id", zap.String("old_user_id", req.OldUserID), zap.Error(err))
-		h.responseError(c, http.StatusBadRequest, codeInvalidBody, "invalid old user id")
+	oldUserID, ok := h.parseUUID(c, log, "old_user_id", req.OldUserID)
+	if !ok {
+		return
+	}
+	h.reassignReviewer(c, log, req.PullRequestID, oldUserID)
+}
+
+func (h *Handler) reassignReviewer(c *gin.Context, log *zap.Logger, prID string, oldUserID uuid.UUID) {
+	expectedVersion, ok := h.ifMatchVersion(c, log)
+	if !ok {
+		return
 	}
-	pr, newUserID, err := h.prService.ReassignmentReviewers(c.Request.Context(), req.PullRequestID, oldUserID)
+	pr, newUserID, err := h.prService.ReassignmentReviewers(c.Request.Context(), prID, oldUserID, expectedVersion)
 	if err != nil {
-		if errors.Is(err, domain.ErrPRNotExist) {
-			log.Warn("Pull request not found", zap.String("pull_request_id", req.PullRequestID))
-			h.responseError(c, http.StatusNotFound, codeNotFound, "pull request not found")
-			return
-		}
-		if errors.Is(err, domain.ErrPRMerged) {
-			log.Warn("Pull request is merged", zap.Error(err))
-			h.responseError(c, http.StatusConflict, codePRMerged, "cannot reassign on merged PR")
+		h.fail(c, log, err, "reassign pull request")
+		return
+	}
+
+	setETag(c, pr.Version)
+	c.JSON(http.StatusOK, dto.ToReassignResponse(pr, newUserID))
+}
+
+func (h *Handler) SubmitVerdict(c *gin.Context) {
+	log := c.MustGet("logger").(*zap.Logger)
+	var req dto.SubmitVerdictRequest
+	if !h.bindJSON(c, log, &req) {
+		return
+	}
+	var reviewerID uuid.UUID
+	if req.ReviewerID != "" {
+		parsed, ok := h.parseUUID(c, log, "reviewer_id", req.ReviewerID)
+		if !ok {
 			return
 		}
-		if errors.Is(err, domain.ErrNotFound) {
-			log.Warn("Author not found on merged PR", zap.String("pull_request_id", req.PullRequestID))
-			h.responseError(c, http.StatusNotFound, codeNotFound, "author pull request not found")
+		reviewerID = parsed
+	}
+	h.submitVerdict(c, log, req.PullRequestID, reviewerID, req.Verdict)
+}
+
+func (h *Handler) submitVerdict(c *gin.Context, log *zap.Logger, prID string, reviewerID uuid.UUID, verdict domain.Verdict) {
+	submitted, err := h.prService.SubmitVerdict(c.Request.Context(), prID, reviewerID, verdict)
+	if err != nil {
+		h.fail(c, log, err, "submit verdict")
+		return
+	}
+	c.JSON(http.StatusOK, dto.ToVerdictResponse(submitted))
+}
+
+func (h *Handler) GetPullRequest(c *gin.Context) {
+	log := c.MustGet("logger").(*zap.Logger)
+	pr, ok := h.loadPullRequest(c, log, c.Query("pull_request_id"))
+	if !ok {
+		return
+	}
+	setETag(c, pr.Version)
+	c.JSON(http.StatusOK, dto.ToPullRequestResponse(pr))
+}
+
+// loadPullRequest читает PR для обработчиков чтения. Если PR не получен, ответ с ошибкой уже отправлен
+func (h *Handler) loadPullRequest(c *gin.Context, log *zap.Logger, prID string) (*domain.PullRequest, bool) {
+	pr, err := h.prService.GetPullRequest(c.Request.Context(), prID)
+	if err != nil {
+		h.fail(c, log, err, "get pull request")
+		return nil, false
+	}
+	return pr, true
+}
+
+func (h *Handler) ListPullRequests(c *gin.Context) {
+	log := c.MustGet("logger").(*zap.Logger)
+	format, err := negotiateFormat(c)
+	if err != nil {
+		log.Warn("Unknown export format", zap.String("format", c.Query("format")))
+		h.invalidFields(c, dto.FieldError{Field: "format", Reason: "must be one of json, csv, ndjson"})
+		return
+	}
+
+	var query dto.PullRequestListQuery
+	if !h.bindQuery(c, log, &query) {
+		return
+	}
+	from, to, ok := h.parseStatsPeriod(c, log, query.From, query.To)
+	if !ok {
+		return
+	}
+	filter := domain.PullRequestFilter{From: from, To: to, TeamName: query.TeamName}
+	if query.AuthorID != "" {
+		authorID, ok := h.parseUUID(c, log, "author_id", query.AuthorID)
+		if !ok {
 			return
 		}
-		if errors.Is(err, domain.ErrNoCandidate) {
-			log.Warn("Candidate not found on merged PR", zap.String("pull_request_id", req.PullRequestID))
-			h.responseError(c, http.StatusConflict, codeNoCandidate, "no active replacement candidate in team")
-			return
+		filter.AuthorID = &authorID
+	}
+	if query.Status != "" {
+		status := domain.StatusPR(query.Status)
+		filter.Status = &status
+	}
+
+	var w *streamWriter
+	prs := make([]dto.PullRequestResponse, 0)
+	if format != formatJSON {
+		w = newStreamWriter(c, format, "pull_requests", dto.PullRequestCSVHeader)
+	}
+	err = h.prService.StreamPullRequests(c.Request.Context(), filter, func(pr *domain.PullRequest) error {
+		row := dto.ToPullRequestResponse(pr)
+		if w == nil {
+			prs = append(prs, *row)
+			return nil
 		}
-		if errors.Is(err, domain.ErrUserNotAssigned) {
-			log.Warn("The user was not assigned as a reviewer", zap.String("pull_request_id", req.PullRequestID), zap.String("id", req.OldUserID))
-			h.responseError(c, http.StatusConflict, codeNotAssigned, "reviewer is not assigned to this PR")
+		return w.Write(row.CSVRecord(), row)
+	})
+	if err == nil && w != nil {
+		err = w.Close()
+	}
+	if err != nil {
+		if w != nil && w.Started() {
+			abortStream(c, log, err)
 			return
 		}
-		log.Error("Failed to reassign pull request", zap.Error(err))
-		h.responseError(c, http.StatusInternalServerError, codeInternalError, "failed to reassign pull request")
+		h.fail(c, log, err, "list pull requests")
 		return
 	}
-
-	c.JSON(http.StatusOK, dto.ToReassignResponse(pr, newUserID))
+	if w == nil {
+		c.JSON(http.StatusOK, dto.PullRequestListResponse{PullRequests: prs})
+	}
 }
 
-func (h *Handler) GetStats(c *gin.Context) {
+func (h *Handler) GetJobRuns(c *gin.Context) {
 	log := c.MustGet("logger").(*zap.Logger)
-	log.Info("Handling get statistics request")
+	limit := 0
+	if limitStr := c.Query("limit"); limitStr != "" {
+		parsed, err := strconv.Atoi(limitStr)
+		if err != nil {
+			log.Warn("Invalid limit query parameter", zap.String("limit", limitStr), zap.Error(err))
+			h.invalidFields(c, dto.FieldError{Field: "limit", Reason: "must be an integer"})
+			return
+		}
+		limit = parsed
+	}
 
-	stats, err := h.statsService.GetUserReviewStats(c.Request.Context())
+	runs, err := h.jobRunService.GetJobRuns(c.Request.Context(), c.Query("job_name"), limit)
 	if err != nil {
-		log.Error("Failed to get stats from service", zap.Error(err))
-		h.responseError(c, http.StatusInternalServerError, codeInternalError, "could not retrieve statistics")
+		h.fail(c, log, err, "get job runs")
 		return
 	}
-
-	response := dto.FromUserReviewStats(stats)
-	c.JSON(http.StatusOK, response)
-}
-
-func (h *Handler) responseError(c *gin.Context, status int, code, message string) {
-	c.JSON(status, dto.ErrorResponse{
-		Error: dto.ErrorBody{
-			Code:    code,
-			Message: message,
-		},
-	})
+	c.JSON(http.StatusOK, dto.ToJobRunsResponse(runs))
 }
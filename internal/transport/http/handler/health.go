@@ -0,0 +1,29 @@
+package handler
+
+import (
+	"go.uber.org/zap"
+	"net/http"
+
+	"avito/internal/domain"
+	"avito/internal/transport/http/dto"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Healthz - проверка живости: процесс запущен и обрабатывает запросы, зависимости не проверяются
+func (h *Handler) Healthz(c *gin.Context) {
+	c.JSON(http.StatusOK, dto.HealthResponse{Status: string(domain.HealthOK)})
+}
+
+// Readyz - проверка готовности: база доступна, схема актуальна, фоновые задачи запущены.
+// При провале любой проверки отвечает 503, чтобы балансировщик не направлял трафик
+func (h *Handler) Readyz(c *gin.Context) {
+	log := c.MustGet("logger").(*zap.Logger)
+	report := h.healthService.Readiness(c.Request.Context())
+	status := http.StatusOK
+	if report.Status != domain.HealthOK {
+		log.Warn("Service is not ready")
+		status = http.StatusServiceUnavailable
+	}
+	c.JSON(status, dto.ToReadinessResponse(report))
+}
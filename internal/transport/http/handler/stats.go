@@ -0,0 +1,182 @@
+package handler
+
+import (
+	"go.uber.org/zap"
+	"net/http"
+	"time"
+
+	"avito/internal/domain"
+	"avito/internal/transport/http/dto"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (h *Handler) GetStats(c *gin.Context) {
+	log := c.MustGet("logger").(*zap.Logger)
+	log.Info("Handling get statistics request")
+
+	format, err := negotiateFormat(c)
+	if err != nil {
+		log.Warn("Unknown export format", zap.String("format", c.Query("format")))
+		h.invalidFields(c, dto.FieldError{Field: "format", Reason: "must be one of json, csv, ndjson"})
+		return
+	}
+	filter, ok := h.parseStatsFilter(c, log)
+	if !ok {
+		return
+	}
+	if format != formatJSON {
+		h.streamStats(c, log, format, filter)
+		return
+	}
+
+	stats, err := h.statsService.GetUserReviewStats(c.Request.Context(), filter)
+	if err != nil {
+		h.fail(c, log, err, "get statistics")
+		return
+	}
+
+	response := dto.FromUserReviewStats(stats)
+	c.JSON(http.StatusOK, response)
+}
+
+// streamStats выгружает статистику пользователей в CSV или NDJSON построчно
+func (h *Handler) streamStats(c *gin.Context, log *zap.Logger, format exportFormat, filter domain.StatsFilter) {
+	w := newStreamWriter(c, format, "stats", dto.UserStatCSVHeader)
+	err := h.statsService.StreamUserReviewStats(c.Request.Context(), filter, func(stat *domain.UserReviewStat) error {
+		row := dto.ToUserStatDTO(stat)
+		return w.Write(row.CSVRecord(), row)
+	})
+	if err == nil {
+		err = w.Close()
+	}
+	if err != nil {
+		if w.Started() {
+			abortStream(c, log, err)
+			return
+		}
+		h.fail(c, log, err, "get statistics")
+	}
+}
+
+func (h *Handler) GetTeamStats(c *gin.Context) {
+	log := c.MustGet("logger").(*zap.Logger)
+	log.Info("Handling get team statistics request")
+
+	filter, ok := h.parseStatsFilter(c, log)
+	if !ok {
+		return
+	}
+
+	stats, err := h.statsService.GetTeamReviewStats(c.Request.Context(), filter)
+	if err != nil {
+		h.fail(c, log, err, "get statistics")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.FromTeamReviewStats(stats))
+}
+
+func (h *Handler) GetLatencyStats(c *gin.Context) {
+	log := c.MustGet("logger").(*zap.Logger)
+	log.Info("Handling get latency statistics request")
+
+	var query dto.LatencyQuery
+	if !h.bindQuery(c, log, &query) {
+		return
+	}
+	from, to, ok := h.parseStatsPeriod(c, log, query.From, query.To)
+	if !ok {
+		return
+	}
+
+	filter := domain.LatencyFilter{
+		From:     from,
+		To:       to,
+		TeamName: query.TeamName,
+		GroupBy:  domain.LatencyGroupBy(query.GroupBy),
+		Bucket:   domain.LatencyBucket(query.Bucket),
+	}
+	series, err := h.statsService.GetLatency(c.Request.Context(), filter)
+	if err != nil {
+		h.fail(c, log, err, "get statistics")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToLatencyResponse(filter, series))
+}
+
+func (h *Handler) GetFairnessStats(c *gin.Context) {
+	log := c.MustGet("logger").(*zap.Logger)
+	log.Info("Handling get fairness statistics request")
+
+	from, to, ok := h.parseStatsPeriod(c, log, c.Query("from"), c.Query("to"))
+	if !ok {
+		return
+	}
+	filter := domain.FairnessFilter{TeamName: c.Query("team_name")}
+	if from != nil {
+		filter.From = *from
+	}
+	if to != nil {
+		filter.To = *to
+	}
+
+	reports, err := h.statsService.GetFairness(c.Request.Context(), filter)
+	if err != nil {
+		h.fail(c, log, err, "get statistics")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToFairnessResponse(reports))
+}
+
+// parseStatsFilter разбирает query-параметры from, to (RFC 3339), team_name и status.
+// При ошибке ответ уже отправлен и возвращается false.
+func (h *Handler) parseStatsFilter(c *gin.Context, log *zap.Logger) (domain.StatsFilter, bool) {
+	var filter dto.StatsFilterQuery
+	if !h.bindQuery(c, log, &filter) {
+		return domain.StatsFilter{}, false
+	}
+
+	from, to, ok := h.parseStatsPeriod(c, log, filter.From, filter.To)
+	if !ok {
+		return domain.StatsFilter{}, false
+	}
+
+	result := domain.StatsFilter{From: from, To: to, TeamName: filter.TeamName}
+	if filter.Status != "" {
+		status := domain.StatusPR(filter.Status)
+		result.Status = &status
+	}
+	return result, true
+}
+
+// parseStatsPeriod разбирает границы периода в формате RFC 3339. При ошибке ответ уже отправлен и возвращается false.
+func (h *Handler) parseStatsPeriod(c *gin.Context, log *zap.Logger, fromStr, toStr string) (*time.Time, *time.Time, bool) {
+	from, err := parseOptionalTime(fromStr)
+	if err != nil {
+		log.Warn("Invalid from query parameter", zap.String("from", fromStr), zap.Error(err))
+		h.invalidFields(c, dto.FieldError{Field: "from", Reason: "must be an RFC 3339 timestamp"})
+		return nil, nil, false
+	}
+	to, err := parseOptionalTime(toStr)
+	if err != nil {
+		log.Warn("Invalid to query parameter", zap.String("to", toStr), zap.Error(err))
+		h.invalidFields(c, dto.FieldError{Field: "to", Reason: "must be an RFC 3339 timestamp"})
+		return nil, nil, false
+	}
+	return from, to, true
+}
+
+func parseOptionalTime(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, err
+	}
+	t = t.UTC()
+	return &t, nil
+}
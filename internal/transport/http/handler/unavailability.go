@@ -0,0 +1,85 @@
+package handler
+
+import (
+	"go.uber.org/zap"
+	"net/http"
+
+	"avito/internal/transport/http/dto"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maxCalendarSize ограничивает размер импортируемого файла .ics
+const maxCalendarSize = 1 << 20
+
+func (h *Handler) AddUnavailability(c *gin.Context) {
+	log := c.MustGet("logger").(*zap.Logger)
+	var req dto.AddUnavailabilityRequest
+	if !h.bindJSON(c, log, &req) {
+		return
+	}
+	userID, ok := h.parseUUID(c, log, "user_id", req.UserID)
+	if !ok {
+		return
+	}
+
+	period, err := h.unavailabilityService.AddUnavailability(c.Request.Context(), userID, req.StartsAt, req.EndsAt, req.Reason)
+	if err != nil {
+		h.fail(c, log, err, "process unavailability")
+		return
+	}
+	c.JSON(http.StatusCreated, dto.ToUnavailabilityDTO(period))
+}
+
+func (h *Handler) GetUnavailability(c *gin.Context) {
+	log := c.MustGet("logger").(*zap.Logger)
+	userIDStr := c.Query("user_id")
+	userID, ok := h.parseUUID(c, log, "user_id", userIDStr)
+	if !ok {
+		return
+	}
+
+	periods, err := h.unavailabilityService.GetUnavailability(c.Request.Context(), userID)
+	if err != nil {
+		h.fail(c, log, err, "process unavailability")
+		return
+	}
+	c.JSON(http.StatusOK, dto.ToUserUnavailabilityResponse(userID, periods))
+}
+
+func (h *Handler) DeleteUnavailability(c *gin.Context) {
+	log := c.MustGet("logger").(*zap.Logger)
+	var req dto.DeleteUnavailabilityRequest
+	if !h.bindJSON(c, log, &req) {
+		return
+	}
+	id, ok := h.parseUUID(c, log, "id", req.ID)
+	if !ok {
+		return
+	}
+
+	period, err := h.unavailabilityService.DeleteUnavailability(c.Request.Context(), id)
+	if err != nil {
+		h.fail(c, log, err, "process unavailability")
+		return
+	}
+	c.JSON(http.StatusOK, dto.ToUnavailabilityDTO(period))
+}
+
+// ImportUnavailability принимает файл iCalendar в теле запроса и создает периоды из его событий
+func (h *Handler) ImportUnavailability(c *gin.Context) {
+	log := c.MustGet("logger").(*zap.Logger)
+	userIDStr := c.Query("user_id")
+	userID, ok := h.parseUUID(c, log, "user_id", userIDStr)
+	if !ok {
+		return
+	}
+
+	body := http.MaxBytesReader(c.Writer, c.Request.Body, maxCalendarSize)
+	periods, err := h.unavailabilityService.ImportICS(c.Request.Context(), userID, body)
+	if err != nil {
+		h.fail(c, log, err, "process unavailability")
+		return
+	}
+	c.JSON(http.StatusCreated, dto.ToUserUnavailabilityResponse(userID, periods))
+}
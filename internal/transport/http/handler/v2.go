@@ -0,0 +1,115 @@
+package handler
+
+import (
+	"go.uber.org/zap"
+	"net/http"
+
+	"avito/internal/transport/http/dto"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// Обработчики API v2. Ресурс адресуется путем (/teams/{name}, /pull-requests/{id}), а вызовы сервисов
+// и ответы на ошибки общие с v1, поэтому версии различаются только формой запроса
+
+func (h *Handler) GetTeamByName(c *gin.Context) {
+	log := c.MustGet("logger").(*zap.Logger)
+	h.getTeam(c, log, c.Param("name"))
+}
+
+func (h *Handler) PutTeamReviewCapacity(c *gin.Context) {
+	log := c.MustGet("logger").(*zap.Logger)
+	var req dto.ReviewCapacityBody
+	if !h.bindJSON(c, log, &req) {
+		return
+	}
+	h.setTeamReviewCapacity(c, log, c.Param("name"), req.DefaultReviewCapacity)
+}
+
+func (h *Handler) PutTeamReviewSLA(c *gin.Context) {
+	log := c.MustGet("logger").(*zap.Logger)
+	var req dto.ReviewSLABody
+	if !h.bindJSON(c, log, &req) {
+		return
+	}
+	h.setTeamReviewSLA(c, log, c.Param("name"), req.ReviewSLAMinutes, req.EscalationMinutes)
+}
+
+func (h *Handler) GetPullRequestByID(c *gin.Context) {
+	log := c.MustGet("logger").(*zap.Logger)
+	pr, ok := h.loadPullRequest(c, log, c.Param("id"))
+	if !ok {
+		return
+	}
+	setETag(c, pr.Version)
+	c.JSON(http.StatusOK, dto.ToPullRequestResponse(pr))
+}
+
+func (h *Handler) MergePullRequest(c *gin.Context) {
+	log := c.MustGet("logger").(*zap.Logger)
+	h.mergePullRequest(c, log, c.Param("id"))
+}
+
+func (h *Handler) ListPullRequestReviewers(c *gin.Context) {
+	log := c.MustGet("logger").(*zap.Logger)
+	pr, ok := h.loadPullRequest(c, log, c.Param("id"))
+	if !ok {
+		return
+	}
+	setETag(c, pr.Version)
+	c.JSON(http.StatusOK, dto.ToPullRequestReviewersResponse(pr))
+}
+
+func (h *Handler) ReassignPullRequestReviewer(c *gin.Context) {
+	log := c.MustGet("logger").(*zap.Logger)
+	reviewerID, ok := h.pathUserID(c, log)
+	if !ok {
+		return
+	}
+	h.reassignReviewer(c, log, c.Param("id"), reviewerID)
+}
+
+func (h *Handler) PutReviewerVerdict(c *gin.Context) {
+	log := c.MustGet("logger").(*zap.Logger)
+	reviewerID, ok := h.pathUserID(c, log)
+	if !ok {
+		return
+	}
+	var req dto.VerdictBody
+	if !h.bindJSON(c, log, &req) {
+		return
+	}
+	h.submitVerdict(c, log, c.Param("id"), reviewerID, req.Verdict)
+}
+
+func (h *Handler) ListUserReviews(c *gin.Context) {
+	log := c.MustGet("logger").(*zap.Logger)
+	userID, ok := h.pathUserID(c, log)
+	if !ok {
+		return
+	}
+	h.getUserReview(c, log, userID)
+}
+
+func (h *Handler) PutUserActiveStatus(c *gin.Context) {
+	log := c.MustGet("logger").(*zap.Logger)
+	userID, ok := h.pathUserID(c, log)
+	if !ok {
+		return
+	}
+	var req dto.ActiveStatusBody
+	if !h.bindJSON(c, log, &req) {
+		return
+	}
+	h.setUserActive(c, log, userID, req.IsActive)
+}
+
+// pathUserID разбирает идентификатор пользователя из пути: user_id у ревьюеров PR, id у /users/{id}
+func (h *Handler) pathUserID(c *gin.Context, log *zap.Logger) (uuid.UUID, bool) {
+	param := c.Param("user_id")
+	if param == "" {
+		param = c.Param("id")
+	}
+	return h.parseUUID(c, log, "user_id", param)
+}
@@ -0,0 +1,54 @@
+package handler
+
+import (
+	"errors"
+	"go.uber.org/zap"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	headerETag    = "ETag"
+	headerIfMatch = "If-Match"
+)
+
+var errInvalidIfMatch = errors.New("invalid If-Match header")
+
+// setETag отдает версию ресурса в заголовке ETag
+func setETag(c *gin.Context, version int64) {
+	c.Header(headerETag, strconv.Quote(strconv.FormatInt(version, 10)))
+}
+
+// parseIfMatch читает ожидаемую версию из If-Match. Без заголовка или со значением "*" проверка
+// версии не выполняется и возвращается nil. Слабый ETag (W/"3") принимается наравне с сильным
+func parseIfMatch(c *gin.Context) (*int64, error) {
+	value := strings.TrimSpace(c.GetHeader(headerIfMatch))
+	if value == "" || value == "*" {
+		return nil, nil
+	}
+	value = strings.TrimPrefix(value, "W/")
+	unquoted, err := strconv.Unquote(value)
+	if err != nil {
+		return nil, errInvalidIfMatch
+	}
+	version, err := strconv.ParseInt(unquoted, 10, 64)
+	if err != nil || version <= 0 {
+		return nil, errInvalidIfMatch
+	}
+	return &version, nil
+}
+
+// ifMatchVersion возвращает ожидаемую версию из If-Match. Если заголовок не разобран, отвечает 412:
+// клиент передал ETag, который не может совпасть ни с одной версией ресурса
+func (h *Handler) ifMatchVersion(c *gin.Context, log *zap.Logger) (*int64, bool) {
+	version, err := parseIfMatch(c)
+	if err != nil {
+		log.Warn("Failed to parse If-Match header", zap.String("if_match", c.GetHeader(headerIfMatch)))
+		h.responseError(c, http.StatusPreconditionFailed, codePreconditionFailed, "If-Match must contain a version ETag returned by the server")
+		return nil, false
+	}
+	return version, true
+}
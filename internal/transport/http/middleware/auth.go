@@ -0,0 +1,180 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"go.uber.org/zap"
+	"net/http"
+	"strings"
+
+	"avito/internal/auth"
+	"avito/internal/domain"
+	"avito/internal/transport/http/dto"
+	"avito/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// APIKeyHeader - заголовок с ключом доступа к API
+	APIKeyHeader = "X-API-Key"
+	// PrincipalKey - ключ аутентифицированного вызывающего в gin.Context
+	PrincipalKey = "principal"
+
+	codeUnauthorized  = "UNAUTHORIZED"
+	codeForbidden     = "FORBIDDEN"
+	codeInternalError = "INTERNAL_ERROR"
+)
+
+// Authenticator проверяет ключ доступа и возвращает вызывающего
+type Authenticator interface {
+	Authenticate(ctx context.Context, apiKey string) (*domain.Principal, error)
+}
+
+// TokenAuthenticator проверяет bearer JWT и возвращает вызывающего
+type TokenAuthenticator interface {
+	AuthenticateToken(ctx context.Context, token string) (*domain.Principal, error)
+}
+
+// AuthMiddleware пропускает только запросы с действующим ключом в X-API-Key или JWT в Authorization: Bearer.
+// tokens может быть nil, тогда bearer-токены отклоняются.
+// Вызывающий сохраняется в gin.Context и в контексте запроса для сервисов и журнала аудита
+func AuthMiddleware(apiKeys Authenticator, tokens TokenAuthenticator) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		log := c.MustGet("logger").(*zap.Logger)
+		ctx := c.Request.Context()
+
+		var principal *domain.Principal
+		var err error
+		credential := "API key"
+		if token, ok := bearerToken(c.GetHeader("Authorization")); ok {
+			credential = "bearer token"
+			if tokens == nil {
+				log.Warn("Bearer token used while JWT authentication is disabled")
+				abortWithError(c, http.StatusUnauthorized, codeUnauthorized, "bearer tokens are not accepted")
+				return
+			}
+			principal, err = tokens.AuthenticateToken(ctx, token)
+		} else if apiKey := c.GetHeader(APIKeyHeader); apiKey != "" {
+			principal, err = apiKeys.Authenticate(ctx, apiKey)
+		} else {
+			log.Warn("Request without credentials")
+			abortWithError(c, http.StatusUnauthorized, codeUnauthorized, "missing API key or bearer token")
+			return
+		}
+
+		if err != nil {
+			if errors.Is(err, domain.ErrUnauthorized) {
+				log.Warn("Invalid credentials", zap.String("credential", credential))
+				abortWithError(c, http.StatusUnauthorized, codeUnauthorized, "invalid "+credential)
+				return
+			}
+			log.Error("Failed to authenticate request", zap.Error(err))
+			abortWithError(c, http.StatusInternalServerError, codeInternalError, "failed to authenticate request")
+			return
+		}
+
+		setPrincipal(c, *principal)
+		c.Next()
+	}
+}
+
+// bearerToken извлекает токен из заголовка Authorization со схемой Bearer
+func bearerToken(header string) (string, bool) {
+	const scheme = "bearer "
+	if len(header) <= len(scheme) || !strings.EqualFold(header[:len(scheme)], scheme) {
+		return "", false
+	}
+	token := strings.TrimSpace(header[len(scheme):])
+	return token, token != ""
+}
+
+// AnonymousMiddleware используется вместо AuthMiddleware, когда аутентификация отключена:
+// запрос выполняется от имени анонимного вызывающего со всеми правами
+func AnonymousMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		setPrincipal(c, domain.Principal{
+			Type:   domain.PrincipalAnonymous,
+			ID:     string(domain.PrincipalAnonymous),
+			Scopes: []domain.Scope{domain.ScopeAdmin},
+			Role:   domain.RoleAdmin,
+		})
+		c.Next()
+	}
+}
+
+// RequireScope отвечает 403, если у вызывающего нет права scope. Ставится после AuthMiddleware
+func RequireScope(scope domain.Scope) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		principal, ok := c.Get(PrincipalKey)
+		if !ok || !principal.(domain.Principal).HasScope(scope) {
+			log := c.MustGet("logger").(*zap.Logger)
+			log.Warn("Insufficient scope", zap.String("required_scope", string(scope)))
+			abortWithError(c, http.StatusForbidden, codeForbidden, "caller lacks required scope "+string(scope))
+			return
+		}
+		c.Next()
+	}
+}
+
+// AuditRecorder сохраняет записи журнала аудита
+type AuditRecorder interface {
+	Record(ctx context.Context, event domain.AuditEvent) error
+}
+
+// AuditMiddleware записывает в журнал аудита каждый изменяющий запрос вместе с вызывающим и итоговым статусом
+func AuditMiddleware(recorder AuditRecorder) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+
+		switch c.Request.Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions:
+			return
+		}
+
+		event := domain.AuditEvent{
+			Action:    c.Request.Method + " " + c.FullPath(),
+			Status:    c.Writer.Status(),
+			RequestID: c.GetString(RequestIDKey),
+			Details: map[string]any{
+				"path":        c.Request.URL.Path,
+				"remote_addr": c.ClientIP(),
+			},
+		}
+		if value, ok := c.Get(PrincipalKey); ok {
+			principal := value.(domain.Principal)
+			event.PrincipalType = principal.Type
+			event.PrincipalID = principal.ID
+			event.PrincipalName = principal.Name
+		}
+
+		// Запись не должна теряться, если клиент уже закрыл соединение
+		if err := recorder.Record(context.WithoutCancel(c.Request.Context()), event); err != nil {
+			log := c.MustGet("logger").(*zap.Logger)
+			log.Error("Failed to record audit event", zap.Error(err))
+		}
+	}
+}
+
+func setPrincipal(c *gin.Context, principal domain.Principal) {
+	c.Set(PrincipalKey, principal)
+	fields := []zap.Field{zap.String("principal_type", string(principal.Type)), zap.String("principal_id", principal.ID), zap.String("principal_role", string(principal.Role))}
+	ctx := auth.WithPrincipal(c.Request.Context(), principal)
+	c.Request = c.Request.WithContext(logger.WithFields(ctx, fields...))
+	c.Set("logger", c.MustGet("logger").(*zap.Logger).With(fields...))
+}
+
+func abortWithError(c *gin.Context, status int, code, message string) {
+	abortWithDetails(c, status, code, message, nil)
+}
+
+func abortWithDetails(c *gin.Context, status int, code, message string, details []dto.FieldError) {
+	c.AbortWithStatusJSON(status, dto.ErrorResponse{
+		Error: dto.ErrorBody{
+			Code:    code,
+			Message: message,
+			Details: details,
+		},
+		RequestID: c.GetString(RequestIDKey),
+	})
+}
@@ -0,0 +1,79 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"avito/internal/domain"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// fakeTokenAuthenticator отвечает на каждый токен заранее заданным результатом
+type fakeTokenAuthenticator map[string]error
+
+func (a fakeTokenAuthenticator) AuthenticateToken(_ context.Context, token string) (*domain.Principal, error) {
+	if err, ok := a[token]; ok {
+		return nil, err
+	}
+	return &domain.Principal{Type: domain.PrincipalUser, ID: token}, nil
+}
+
+// fakeAPIKeys принимает только ключ "valid-key"
+type fakeAPIKeys struct{}
+
+func (fakeAPIKeys) Authenticate(_ context.Context, apiKey string) (*domain.Principal, error) {
+	if apiKey != "valid-key" {
+		return nil, domain.ErrUnauthorized
+	}
+	return &domain.Principal{Type: domain.PrincipalAPIKey, ID: apiKey}, nil
+}
+
+func TestAuthMiddleware(t *testing.T) {
+	tokens := fakeTokenAuthenticator{
+		"unknown-user": domain.ErrUnauthorized,
+		"storage-down": errors.New("storage unavailable"),
+	}
+
+	tests := []struct {
+		name       string
+		tokens     TokenAuthenticator
+		headers    map[string]string
+		wantStatus int
+	}{
+		{"valid bearer token", tokens, map[string]string{"Authorization": "Bearer known-user"}, http.StatusOK},
+		{"bearer scheme is case-insensitive", tokens, map[string]string{"Authorization": "bearer known-user"}, http.StatusOK},
+		{"token of unknown user", tokens, map[string]string{"Authorization": "Bearer unknown-user"}, http.StatusUnauthorized},
+		{"authentication failure", tokens, map[string]string{"Authorization": "Bearer storage-down"}, http.StatusInternalServerError},
+		{"bearer token while JWT is disabled", nil, map[string]string{"Authorization": "Bearer known-user"}, http.StatusUnauthorized},
+		{"valid API key", tokens, map[string]string{APIKeyHeader: "valid-key"}, http.StatusOK},
+		{"invalid API key", tokens, map[string]string{APIKeyHeader: "wrong-key"}, http.StatusUnauthorized},
+		{"no credentials", tokens, nil, http.StatusUnauthorized},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.ReleaseMode)
+			engine := gin.New()
+			engine.Use(func(c *gin.Context) {
+				c.Set("logger", zap.NewNop())
+			})
+			engine.GET("/items", AuthMiddleware(fakeAPIKeys{}, tt.tokens), func(c *gin.Context) {
+				c.Status(http.StatusOK)
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/items", nil)
+			for name, value := range tt.headers {
+				req.Header.Set(name, value)
+			}
+			rec := httptest.NewRecorder()
+			engine.ServeHTTP(rec, req)
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("got status %d, want %d", rec.Code, tt.wantStatus)
+			}
+		})
+	}
+}
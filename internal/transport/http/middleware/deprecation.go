@@ -0,0 +1,29 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DeprecationMiddleware помечает ответы устаревших маршрутов заголовком Deprecation (RFC 9745) с датой
+// объявления устаревшим и ссылкой Link на тот же путь под successorPrefix. Заголовки ставятся до
+// остальных middleware, чтобы их получали и ответы с ошибками аутентификации или лимита.
+// Если sunset задан, добавляется заголовок Sunset (RFC 8594) с датой отключения маршрутов
+func DeprecationMiddleware(since, sunset time.Time, successorPrefix string) gin.HandlerFunc {
+	deprecation := "@" + strconv.FormatInt(since.Unix(), 10)
+	var sunsetDate string
+	if !sunset.IsZero() {
+		sunsetDate = sunset.UTC().Format(http.TimeFormat)
+	}
+	return func(c *gin.Context) {
+		c.Header("Deprecation", deprecation)
+		if sunsetDate != "" {
+			c.Header("Sunset", sunsetDate)
+		}
+		c.Header("Link", "<"+successorPrefix+c.Request.URL.Path+`>; rel="successor-version"`)
+		c.Next()
+	}
+}
@@ -0,0 +1,180 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"go.uber.org/zap"
+	"io"
+	"net/http"
+	"time"
+
+	"avito/internal/domain"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// IdempotencyKeyHeader - заголовок, по которому повтор запроса получает сохраненный ответ
+	IdempotencyKeyHeader = "Idempotency-Key"
+	// IdempotentReplayedHeader отмечает ответ, повторенный из сохраненного
+	IdempotentReplayedHeader = "Idempotent-Replayed"
+	// maxIdempotencyKeyLength ограничивает длину ключа от клиента
+	maxIdempotencyKeyLength = 255
+
+	codeInvalidBody              = "INVALID_BODY"
+	codeInvalidIdempotencyKey    = "INVALID_IDEMPOTENCY_KEY"
+	codeIdempotencyKeyReused     = "IDEMPOTENCY_KEY_REUSED"
+	codeIdempotencyKeyInProgress = "IDEMPOTENCY_KEY_IN_PROGRESS"
+)
+
+// replayedHeaders - заголовки ответа, без которых повтор не равен первому ответу: версия ресурса,
+// адрес созданного ресурса и время до повтора
+var replayedHeaders = []string{"ETag", "Location", "Retry-After"}
+
+// transientStatuses - ответы, которые зависят от момента запроса, а не от его содержания: нет прав или ключа,
+// исчерпан лимит, конфликт состояния или устаревшая версия. Проверки прав и лимиты групп выполняются после
+// этого middleware, поэтому такие ответы не сохраняются, и повтор с тем же ключом выполняется заново
+var transientStatuses = map[int]bool{
+	http.StatusUnauthorized:       true,
+	http.StatusForbidden:          true,
+	http.StatusRequestTimeout:     true,
+	http.StatusConflict:           true,
+	http.StatusPreconditionFailed: true,
+	http.StatusTooManyRequests:    true,
+}
+
+// IdempotencyStore хранит ключи идемпотентности и ответы на первые запросы
+type IdempotencyStore interface {
+	ReserveIdempotencyKey(ctx context.Context, record domain.IdempotencyRecord, ttl, lockTimeout time.Duration) (*domain.IdempotencyRecord, error)
+	CompleteIdempotencyKey(ctx context.Context, record domain.IdempotencyRecord) error
+	ReleaseIdempotencyKey(ctx context.Context, principalID, key string) error
+}
+
+// responseRecorder копирует тело ответа, чтобы сохранить его для повторов
+type responseRecorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *responseRecorder) Write(data []byte) (int, error) {
+	w.body.Write(data)
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *responseRecorder) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
+
+// IdempotencyMiddleware выполняет изменяющий запрос с заголовком Idempotency-Key один раз.
+// Повтор с тем же ключом и телом получает сохраненный ответ, с другим телом - 422, а пока первый запрос
+// выполняется - 409. Ключи хранятся ttl и принадлежат вызывающему. Сохраняются только окончательные ответы:
+// после 5xx и ответов из transientStatuses ключ освобождается, и запрос можно повторить.
+// lockTimeout - через сколько незавершенный первый запрос (например, при падении экземпляра) перестает занимать ключ
+func IdempotencyMiddleware(store IdempotencyStore, ttl, lockTimeout time.Duration) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader(IdempotencyKeyHeader)
+		if key == "" || c.Request.Method == http.MethodGet || c.Request.Method == http.MethodHead {
+			c.Next()
+			return
+		}
+		log := c.MustGet("logger").(*zap.Logger).With(zap.String("idempotency_key", key))
+		if len(key) > maxIdempotencyKeyLength || !validRequestID(key) {
+			log.Warn("Invalid idempotency key")
+			abortWithError(c, http.StatusBadRequest, codeInvalidIdempotencyKey, "Idempotency-Key must be up to 255 printable ASCII characters")
+			return
+		}
+
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			log.Warn("Failed to read request body", zap.Error(err))
+			abortWithError(c, http.StatusBadRequest, codeInvalidBody, "failed to read request body")
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		record := domain.IdempotencyRecord{
+			PrincipalID: idempotencyOwner(c),
+			Key:         key,
+			RequestHash: requestHash(c.Request.Method, c.Request.URL.RequestURI(), c.GetHeader("If-Match"), body),
+		}
+		// Ключ сохраняется и после отмены запроса клиентом, иначе повтор выполнил бы его второй раз
+		ctx := context.WithoutCancel(c.Request.Context())
+		existing, err := store.ReserveIdempotencyKey(ctx, record, ttl, lockTimeout)
+		if err != nil {
+			log.Error("Failed to reserve idempotency key", zap.Error(err))
+			abortWithError(c, http.StatusInternalServerError, codeInternalError, "failed to check idempotency key")
+			return
+		}
+		if existing != nil {
+			replayIdempotent(c, log, record, *existing)
+			return
+		}
+
+		recorder := &responseRecorder{ResponseWriter: c.Writer}
+		c.Writer = recorder
+		c.Next()
+
+		status := recorder.Status()
+		if status >= http.StatusInternalServerError || transientStatuses[status] {
+			if err := store.ReleaseIdempotencyKey(ctx, record.PrincipalID, key); err != nil {
+				log.Error("Failed to release idempotency key", zap.Error(err))
+			}
+			return
+		}
+		record.StatusCode = status
+		record.ContentType = recorder.Header().Get("Content-Type")
+		record.Headers = make(map[string]string, len(replayedHeaders))
+		for _, name := range replayedHeaders {
+			if value := recorder.Header().Get(name); value != "" {
+				record.Headers[name] = value
+			}
+		}
+		record.Body = recorder.body.Bytes()
+		if err := store.CompleteIdempotencyKey(ctx, record); err != nil {
+			log.Error("Failed to save idempotent response", zap.Error(err))
+		}
+	}
+}
+
+// replayIdempotent отвечает на повтор запроса с уже занятым ключом
+func replayIdempotent(c *gin.Context, log *zap.Logger, record, existing domain.IdempotencyRecord) {
+	if existing.RequestHash != record.RequestHash {
+		log.Warn("Idempotency key reused with a different request")
+		abortWithError(c, http.StatusUnprocessableEntity, codeIdempotencyKeyReused, "Idempotency-Key was already used with a different request")
+		return
+	}
+	if !existing.Completed() {
+		log.Warn("Idempotent request is still in progress")
+		abortWithError(c, http.StatusConflict, codeIdempotencyKeyInProgress, "request with this Idempotency-Key is still in progress")
+		return
+	}
+	log.Info("Replaying stored idempotent response", zap.Int("status", existing.StatusCode))
+	for name, value := range existing.Headers {
+		c.Header(name, value)
+	}
+	c.Header(IdempotentReplayedHeader, "true")
+	c.Data(existing.StatusCode, existing.ContentType, existing.Body)
+	c.Abort()
+}
+
+// idempotencyOwner - владелец ключей: ключ одного вызывающего не виден другим
+func idempotencyOwner(c *gin.Context) string {
+	if value, ok := c.Get(PrincipalKey); ok {
+		if principal, ok := value.(domain.Principal); ok {
+			return string(principal.Type) + ":" + principal.ID
+		}
+	}
+	return string(domain.PrincipalAnonymous)
+}
+
+// requestHash связывает ключ с путем и параметрами запроса, If-Match и телом. Шаблон маршрута не подходит:
+// у запросов к разным PR через /pull-requests/:id/merge он одинаковый, а тело может быть пустым
+func requestHash(method, uri, ifMatch string, body []byte) string {
+	hash := sha256.New()
+	hash.Write([]byte(method + " " + uri + "\n" + ifMatch + "\n"))
+	hash.Write(body)
+	return hex.EncodeToString(hash.Sum(nil))
+}
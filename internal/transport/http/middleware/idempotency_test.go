@@ -0,0 +1,265 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"avito/internal/domain"
+	"avito/internal/transport/http/dto"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// fakeIdempotencyStore повторяет поведение хранилища в PostgreSQL: первый Reserve занимает ключ,
+// последующие возвращают занятую запись
+type fakeIdempotencyStore struct {
+	mu      sync.Mutex
+	records map[string]domain.IdempotencyRecord
+}
+
+func newFakeIdempotencyStore() *fakeIdempotencyStore {
+	return &fakeIdempotencyStore{records: make(map[string]domain.IdempotencyRecord)}
+}
+
+func (s *fakeIdempotencyStore) ReserveIdempotencyKey(_ context.Context, record domain.IdempotencyRecord, _, _ time.Duration) (*domain.IdempotencyRecord, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if existing, ok := s.records[record.PrincipalID+"/"+record.Key]; ok {
+		return &existing, nil
+	}
+	s.records[record.PrincipalID+"/"+record.Key] = record
+	return nil, nil
+}
+
+func (s *fakeIdempotencyStore) CompleteIdempotencyKey(_ context.Context, record domain.IdempotencyRecord) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.records[record.PrincipalID+"/"+record.Key] = record
+	return nil
+}
+
+func (s *fakeIdempotencyStore) ReleaseIdempotencyKey(_ context.Context, principalID, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.records, principalID+"/"+key)
+	return nil
+}
+
+// newIdempotencyEngine собирает маршруты POST /items и POST /items/:id/merge за IdempotencyMiddleware.
+// handle отвечает на запрос, прошедший проверку ключа, и считается в calls
+func newIdempotencyEngine(store IdempotencyStore, handle func(c *gin.Context)) (*gin.Engine, *int) {
+	gin.SetMode(gin.ReleaseMode)
+	engine := gin.New()
+	engine.Use(func(c *gin.Context) {
+		c.Set("logger", zap.NewNop())
+	})
+	calls := 0
+	counted := func(c *gin.Context) {
+		calls++
+		handle(c)
+	}
+	idempotency := IdempotencyMiddleware(store, time.Hour, time.Minute)
+	engine.POST("/items", idempotency, counted)
+	engine.POST("/items/:id/merge", idempotency, counted)
+	return engine, &calls
+}
+
+func postItem(engine http.Handler, key, body string) *httptest.ResponseRecorder {
+	return post(engine, "/items", key, body, nil)
+}
+
+func post(engine http.Handler, path, key, body string, headers map[string]string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(IdempotencyKeyHeader, key)
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+	rec := httptest.NewRecorder()
+	engine.ServeHTTP(rec, req)
+	return rec
+}
+
+func errorCode(t *testing.T, rec *httptest.ResponseRecorder) string {
+	t.Helper()
+	var resp dto.ErrorResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to decode error response %q: %v", rec.Body.String(), err)
+	}
+	return resp.Error.Code
+}
+
+func TestIdempotentRequestIsReplayed(t *testing.T) {
+	engine, calls := newIdempotencyEngine(newFakeIdempotencyStore(), func(c *gin.Context) {
+		c.Header("ETag", `"1"`)
+		c.Header("Location", "/items/item-1")
+		c.Header("X-Trace", "first")
+		c.JSON(http.StatusCreated, gin.H{"id": "item-1"})
+	})
+
+	first := postItem(engine, "key-1", `{"name":"item"}`)
+	second := postItem(engine, "key-1", `{"name":"item"}`)
+
+	if *calls != 1 {
+		t.Fatalf("handler called %d times, want 1", *calls)
+	}
+	if second.Code != http.StatusCreated || second.Body.String() != first.Body.String() {
+		t.Fatalf("replay: got %d %q, want %d %q", second.Code, second.Body.String(), first.Code, first.Body.String())
+	}
+	if second.Header().Get(IdempotentReplayedHeader) != "true" || first.Header().Get(IdempotentReplayedHeader) != "" {
+		t.Fatalf("only the replay must carry %s", IdempotentReplayedHeader)
+	}
+	for _, name := range []string{"Content-Type", "ETag", "Location"} {
+		if got, want := second.Header().Get(name), first.Header().Get(name); got != want {
+			t.Fatalf("replay %s %q, want %q", name, got, want)
+		}
+	}
+	if second.Header().Get("X-Trace") != "" {
+		t.Fatalf("headers outside the replayed list must not be stored")
+	}
+}
+
+func TestIdempotencyKeyReusedWithDifferentBody(t *testing.T) {
+	engine, calls := newIdempotencyEngine(newFakeIdempotencyStore(), func(c *gin.Context) {
+		c.JSON(http.StatusCreated, gin.H{"id": "item-1"})
+	})
+
+	postItem(engine, "key-1", `{"name":"item"}`)
+	rec := postItem(engine, "key-1", `{"name":"other"}`)
+
+	if rec.Code != http.StatusUnprocessableEntity || errorCode(t, rec) != codeIdempotencyKeyReused {
+		t.Fatalf("got %d %s, want 422 IDEMPOTENCY_KEY_REUSED", rec.Code, rec.Body.String())
+	}
+	if *calls != 1 {
+		t.Fatalf("handler called %d times, want 1", *calls)
+	}
+}
+
+func TestIdempotencyKeyReusedWithDifferentRequest(t *testing.T) {
+	tests := []struct {
+		name          string
+		path, body    string
+		headers       map[string]string
+		secondPath    string
+		secondHeaders map[string]string
+	}{
+		{
+			name:       "same route template with another id",
+			path:       "/items/pr-1/merge",
+			secondPath: "/items/pr-2/merge",
+		},
+		{
+			name:       "another query",
+			path:       "/items?team=backend",
+			secondPath: "/items?team=frontend",
+		},
+		{
+			name:          "another If-Match",
+			path:          "/items/pr-1/merge",
+			headers:       map[string]string{"If-Match": `"1"`},
+			secondPath:    "/items/pr-1/merge",
+			secondHeaders: map[string]string{"If-Match": `"2"`},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			engine, calls := newIdempotencyEngine(newFakeIdempotencyStore(), func(c *gin.Context) {
+				c.JSON(http.StatusOK, gin.H{"id": c.Param("id")})
+			})
+
+			post(engine, tt.path, "key-1", tt.body, tt.headers)
+			rec := post(engine, tt.secondPath, "key-1", tt.body, tt.secondHeaders)
+
+			if rec.Code != http.StatusUnprocessableEntity || errorCode(t, rec) != codeIdempotencyKeyReused {
+				t.Fatalf("got %d %s, want 422 %s", rec.Code, rec.Body.String(), codeIdempotencyKeyReused)
+			}
+			if *calls != 1 {
+				t.Fatalf("handler called %d times, want 1", *calls)
+			}
+		})
+	}
+}
+
+func TestIdempotentRequestInProgress(t *testing.T) {
+	var engine *gin.Engine
+	var inProgress *httptest.ResponseRecorder
+	// Повтор отправляется, пока первый запрос еще выполняется
+	engine, calls := newIdempotencyEngine(newFakeIdempotencyStore(), func(c *gin.Context) {
+		if inProgress == nil {
+			inProgress = postItem(engine, "key-1", `{"name":"item"}`)
+		}
+		c.JSON(http.StatusCreated, gin.H{"id": "item-1"})
+	})
+
+	first := postItem(engine, "key-1", `{"name":"item"}`)
+
+	if first.Code != http.StatusCreated {
+		t.Fatalf("first request: got %d, want 201", first.Code)
+	}
+	if inProgress.Code != http.StatusConflict || errorCode(t, inProgress) != codeIdempotencyKeyInProgress {
+		t.Fatalf("got %d %s, want 409 IDEMPOTENCY_KEY_IN_PROGRESS", inProgress.Code, inProgress.Body.String())
+	}
+	if *calls != 1 {
+		t.Fatalf("handler called %d times, want 1", *calls)
+	}
+}
+
+func TestIdempotencyKeyReleasedOnNonTerminalStatus(t *testing.T) {
+	statuses := []int{
+		http.StatusInternalServerError,
+		http.StatusServiceUnavailable,
+		http.StatusUnauthorized,
+		http.StatusForbidden,
+		http.StatusRequestTimeout,
+		http.StatusConflict,
+		http.StatusPreconditionFailed,
+		http.StatusTooManyRequests,
+	}
+	for _, status := range statuses {
+		t.Run(http.StatusText(status), func(t *testing.T) {
+			store := newFakeIdempotencyStore()
+			current := status
+			engine, calls := newIdempotencyEngine(store, func(c *gin.Context) {
+				c.JSON(current, gin.H{"id": "item-1"})
+			})
+
+			failed := postItem(engine, "key-1", `{"name":"item"}`)
+			if failed.Code != status {
+				t.Fatalf("first request: got %d, want %d", failed.Code, status)
+			}
+			if len(store.records) != 0 {
+				t.Fatalf("key was not released after %d: %v", status, store.records)
+			}
+
+			current = http.StatusCreated
+			retried := postItem(engine, "key-1", `{"name":"item"}`)
+			if retried.Code != http.StatusCreated || retried.Header().Get(IdempotentReplayedHeader) != "" {
+				t.Fatalf("retry: got %d replayed=%q, want a fresh 201", retried.Code, retried.Header().Get(IdempotentReplayedHeader))
+			}
+			if *calls != 2 {
+				t.Fatalf("handler called %d times, want 2", *calls)
+			}
+		})
+	}
+}
+
+func TestTerminalClientErrorIsReplayed(t *testing.T) {
+	engine, calls := newIdempotencyEngine(newFakeIdempotencyStore(), func(c *gin.Context) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "not found"})
+	})
+
+	postItem(engine, "key-1", `{"name":"item"}`)
+	replayed := postItem(engine, "key-1", `{"name":"item"}`)
+
+	if replayed.Code != http.StatusNotFound || replayed.Header().Get(IdempotentReplayedHeader) != "true" || *calls != 1 {
+		t.Fatalf("got %d replayed=%q after %d calls, want a replayed 404 after 1 call",
+			replayed.Code, replayed.Header().Get(IdempotentReplayedHeader), *calls)
+	}
+}
@@ -2,13 +2,56 @@ package middleware
 
 import (
 	"go.uber.org/zap"
+	"time"
+
+	"avito/pkg/logger"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+const (
+	// RequestIDHeader - заголовок, в котором клиент может передать идентификатор запроса
+	RequestIDHeader = "X-Request-ID"
+	// RequestIDKey - ключ идентификатора запроса в gin.Context
+	RequestIDKey = "request_id"
+	// maxRequestIDLength ограничивает длину идентификатора от клиента, чтобы он не раздувал логи
+	maxRequestIDLength = 128
 )
 
+// RequestIDMiddleware принимает X-Request-ID от клиента или генерирует новый, возвращает его в ответе
+// и сохраняет в контексте запроса, чтобы логи сервисов и репозиториев содержали request_id
+func RequestIDMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		requestID := c.GetHeader(RequestIDHeader)
+		if !validRequestID(requestID) {
+			requestID = uuid.NewString()
+		}
+
+		c.Set(RequestIDKey, requestID)
+		c.Header(RequestIDHeader, requestID)
+		c.Request = c.Request.WithContext(logger.WithFields(c.Request.Context(), zap.String(RequestIDKey, requestID)))
+		c.Next()
+	}
+}
+
+// validRequestID допускает только непустые идентификаторы из печатных ASCII-символов разумной длины
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if id[i] < 0x21 || id[i] > 0x7e {
+			return false
+		}
+	}
+	return true
+}
+
 func LoggingMiddleware(log *zap.Logger) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		requestLog := log.With(
+		start := time.Now()
+		requestLog := logger.FromContext(c.Request.Context(), log).With(
 			zap.String("method", c.Request.Method),
 			zap.String("path", c.Request.URL.Path),
 			zap.String("remote_addr", c.Request.RemoteAddr),
@@ -18,6 +61,30 @@ func LoggingMiddleware(log *zap.Logger) gin.HandlerFunc {
 
 		c.Set("logger", requestLog)
 		c.Next()
-		requestLog.Info("Request completed")
+		requestLog.Info("Request completed",
+			zap.Int("status", c.Writer.Status()),
+			zap.Duration("latency", time.Since(start)),
+			zap.Int("response_size", c.Writer.Size()),
+		)
+	}
+}
+
+// HTTPMetrics записывает длительность обработанных запросов
+type HTTPMetrics interface {
+	ObserveHTTPRequest(method, route string, status int, duration time.Duration)
+}
+
+// MetricsMiddleware измеряет длительность запросов. В метку route попадает шаблон маршрута,
+// а не фактический путь, чтобы число временных рядов не зависело от параметров запроса
+func MetricsMiddleware(m HTTPMetrics) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		route := c.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+		m.ObserveHTTPRequest(c.Request.Method, route, c.Writer.Status(), time.Since(start))
 	}
 }
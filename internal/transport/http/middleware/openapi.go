@@ -0,0 +1,138 @@
+package middleware
+
+import (
+	"errors"
+	"go.uber.org/zap"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"avito/internal/transport/http/dto"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/gin-gonic/gin"
+)
+
+const codeInvalidRequest = "INVALID_REQUEST"
+
+// OpenAPIValidationMiddleware проверяет параметры и JSON-тело запроса по операции спецификации,
+// соответствующей маршруту gin. pathPrefix добавляется к маршруту перед поиском операции: так устаревшие
+// пути без версии проверяются по операциям /api/v1. Маршруты без операции в спецификации пропускаются,
+// тела в других форматах (например, iCalendar) не проверяются. Аутентификацию проверяет AuthMiddleware,
+// а не спецификация
+func OpenAPIValidationMiddleware(spec *openapi3.T, pathPrefix string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		route, ok := findRoute(spec, pathPrefix, c)
+		if !ok {
+			c.Next()
+			return
+		}
+		pathParams := make(map[string]string, len(c.Params))
+		for _, param := range c.Params {
+			pathParams[param.Key] = param.Value
+		}
+		input := &openapi3filter.RequestValidationInput{
+			Request:    c.Request,
+			PathParams: pathParams,
+			Route:      route,
+			Options: &openapi3filter.Options{
+				AuthenticationFunc: openapi3filter.NoopAuthenticationFunc,
+				ExcludeRequestBody: !hasJSONBody(route.Operation),
+			},
+		}
+		if err := openapi3filter.ValidateRequest(c.Request.Context(), input); err != nil {
+			log := c.MustGet("logger").(*zap.Logger)
+			log.Warn("Request does not match API specification", zap.String("route", c.FullPath()), zap.Error(err))
+			abortWithDetails(c, http.StatusBadRequest, codeInvalidRequest, validationMessage(err), validationDetails(err))
+			return
+		}
+		c.Next()
+	}
+}
+
+// findRoute находит операцию спецификации по шаблону маршрута gin: /teams/:name соответствует /teams/{name}
+func findRoute(spec *openapi3.T, pathPrefix string, c *gin.Context) (*routers.Route, bool) {
+	fullPath := c.FullPath()
+	if fullPath == "" {
+		return nil, false
+	}
+	path := SpecPath(pathPrefix + fullPath)
+	pathItem := spec.Paths.Find(path)
+	if pathItem == nil {
+		return nil, false
+	}
+	operation := pathItem.GetOperation(c.Request.Method)
+	if operation == nil {
+		return nil, false
+	}
+	return &routers.Route{
+		Spec:      spec,
+		Path:      path,
+		PathItem:  pathItem,
+		Method:    c.Request.Method,
+		Operation: operation,
+	}, true
+}
+
+// SpecPath переводит шаблон маршрута gin в шаблон пути OpenAPI
+func SpecPath(ginPath string) string {
+	segments := strings.Split(ginPath, "/")
+	for i, segment := range segments {
+		if strings.HasPrefix(segment, ":") || strings.HasPrefix(segment, "*") {
+			segments[i] = "{" + segment[1:] + "}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+func hasJSONBody(operation *openapi3.Operation) bool {
+	if operation.RequestBody == nil || operation.RequestBody.Value == nil {
+		return false
+	}
+	return operation.RequestBody.Value.Content.Get("application/json") != nil
+}
+
+// validationMessage сокращает ошибку kin-openapi до первой строки: полная ошибка содержит схему целиком
+func validationMessage(err error) string {
+	message, _, _ := strings.Cut(err.Error(), "\n")
+	return message
+}
+
+// validationDetails называет поле, не прошедшее проверку: параметр запроса или путь в JSON-теле
+// в той же записи, что и ошибки привязки DTO (members[1].user_id)
+func validationDetails(err error) []dto.FieldError {
+	var requestErr *openapi3filter.RequestError
+	if !errors.As(err, &requestErr) {
+		return nil
+	}
+	var schemaErr *openapi3.SchemaError
+	hasSchemaErr := errors.As(err, &schemaErr)
+	switch {
+	case requestErr.Parameter != nil:
+		reason := requestErr.Reason
+		if hasSchemaErr {
+			reason = schemaErr.Reason
+		}
+		return []dto.FieldError{{Field: requestErr.Parameter.Name, Reason: reason}}
+	case hasSchemaErr && len(schemaErr.JSONPointer()) > 0:
+		return []dto.FieldError{{Field: jsonPath(schemaErr.JSONPointer()), Reason: schemaErr.Reason}}
+	}
+	return nil
+}
+
+func jsonPath(pointer []string) string {
+	var path strings.Builder
+	for _, segment := range pointer {
+		if _, err := strconv.Atoi(segment); err == nil {
+			path.WriteString("[" + segment + "]")
+			continue
+		}
+		if path.Len() > 0 {
+			path.WriteString(".")
+		}
+		path.WriteString(segment)
+	}
+	return path.String()
+}
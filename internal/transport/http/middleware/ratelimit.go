@@ -0,0 +1,71 @@
+package middleware
+
+import (
+	"context"
+	"go.uber.org/zap"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"avito/internal/domain"
+
+	"github.com/gin-gonic/gin"
+)
+
+const codeRateLimited = "RATE_LIMITED"
+
+// RateLimiter списывает токен из корзины key: в памяти процесса или в общей базе
+type RateLimiter interface {
+	TakeRateLimitToken(ctx context.Context, key string, limit domain.RateLimit) (domain.RateLimitDecision, error)
+}
+
+// RateLimitKey выбирает, чья корзина расходуется запросом
+type RateLimitKey func(c *gin.Context) string
+
+// ByClientIP расходует корзину адреса клиента. Используется до аутентификации, чтобы ограничить и подбор ключей
+func ByClientIP(c *gin.Context) string {
+	return "ip:" + c.ClientIP()
+}
+
+// ByPrincipal расходует корзину аутентифицированного вызывающего: API-ключа или пользователя.
+// Анонимные запросы (аутентификация выключена) ограничиваются по адресу клиента
+func ByPrincipal(c *gin.Context) string {
+	value, ok := c.Get(PrincipalKey)
+	if principal, isPrincipal := value.(domain.Principal); ok && isPrincipal && principal.Type != domain.PrincipalAnonymous {
+		return string(principal.Type) + ":" + principal.ID
+	}
+	return ByClientIP(c)
+}
+
+// RateLimitMiddleware ограничивает частоту запросов группы маршрутов group корзиной токенов на каждого вызывающего.
+// Отклоненный запрос получает 429 с Retry-After. Если хранилище недоступно, запрос пропускается:
+// ограничение частоты не должно останавливать сервис вместе с базой
+func RateLimitMiddleware(limiter RateLimiter, group string, limit domain.RateLimit, key RateLimitKey) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		log := c.MustGet("logger").(*zap.Logger)
+		bucket := group + ":" + key(c)
+
+		decision, err := limiter.TakeRateLimitToken(c.Request.Context(), bucket, limit)
+		if err != nil {
+			log.Warn("Rate limit check failed, request allowed", zap.String("group", group), zap.Error(err))
+			c.Next()
+			return
+		}
+
+		c.Header("X-RateLimit-Limit", strconv.Itoa(limit.Burst))
+		c.Header("X-RateLimit-Remaining", strconv.Itoa(decision.Remaining))
+		if !decision.Allowed {
+			retryAfter := int(math.Ceil(decision.RetryAfter.Seconds()))
+			if retryAfter < 1 {
+				retryAfter = 1
+			}
+			c.Header("Retry-After", strconv.Itoa(retryAfter))
+			log.Warn("Rate limit exceeded", zap.String("group", group), zap.String("bucket", bucket),
+				zap.Duration("retry_after", time.Duration(retryAfter)*time.Second))
+			abortWithError(c, http.StatusTooManyRequests, codeRateLimited, "rate limit exceeded, retry after "+strconv.Itoa(retryAfter)+"s")
+			return
+		}
+		c.Next()
+	}
+}
@@ -0,0 +1,45 @@
+// Package openapi содержит спецификацию OpenAPI 3 HTTP API. Спецификация встроена в бинарник,
+// отдается клиентам и используется для проверки входящих запросов.
+package openapi
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/google/uuid"
+)
+
+//go:embed openapi.yaml
+var specYAML []byte
+
+//go:embed docs.html
+var docsHTML []byte
+
+// Формат uuid проверяется тем же разбором, что и в обработчиках: kin-openapi по умолчанию его не проверяет,
+// а встроенное регулярное выражение RFC 4122 отклоняет UUID v6-v8
+func init() {
+	openapi3.DefineStringFormatValidator("uuid", openapi3.NewCallbackValidator(func(value string) error {
+		_, err := uuid.Parse(value)
+		return err
+	}))
+}
+
+// Load разбирает встроенную спецификацию и проверяет ее корректность
+func Load() (*openapi3.T, error) {
+	loader := openapi3.NewLoader()
+	spec, err := loader.LoadFromData(specYAML)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load openapi spec: %w", err)
+	}
+	if err := spec.Validate(context.Background()); err != nil {
+		return nil, fmt.Errorf("invalid openapi spec: %w", err)
+	}
+	return spec, nil
+}
+
+// DocsHTML возвращает страницу документации, которая загружает спецификацию с /openapi.json
+func DocsHTML() []byte {
+	return docsHTML
+}
@@ -1,31 +1,119 @@
 package router
 
 import (
+	"encoding/json"
 	"go.uber.org/zap"
+	"net/http"
+	"time"
 
+	"avito/internal/domain"
+	"avito/internal/metrics"
 	"avito/internal/transport/http/handler"
 	"avito/internal/transport/http/middleware"
+	"avito/internal/transport/http/openapi"
 
+	"github.com/getkin/kin-openapi/openapi3"
 	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
+)
+
+// Security - аутентификация и аудит API. При Enabled=false запросы выполняются анонимно со всеми правами
+type Security struct {
+	Enabled       bool
+	Authenticator middleware.Authenticator
+	// Tokens проверяет bearer JWT, nil отключает аутентификацию по JWT
+	Tokens middleware.TokenAuthenticator
+	Audit  middleware.AuditRecorder
+}
+
+const (
+	// RateLimitIP - лимит адреса клиента, проверяется для всех запросов API до аутентификации
+	RateLimitIP = "ip"
+	// RateLimitDefault - лимит вызывающего для групп маршрутов без собственного лимита
+	RateLimitDefault = "default"
+)
+
+// RateLimits - ограничение частоты запросов. Limits задает корзину для RateLimitIP, RateLimitDefault
+// и групп маршрутов по первому сегменту пути v1 (stats, jobs, users, team, pullRequest, admin) и /graphql (graphql).
+// Маршруты v2 и устаревшие пути без версии расходуют корзины тех же групп.
+// Без лимита группа не ограничивается, Limiter nil отключает ограничение целиком
+type RateLimits struct {
+	Limiter middleware.RateLimiter
+	Limits  map[string]domain.RateLimit
+	// TrustedProxies - прокси, которым доверяется X-Forwarded-For при определении адреса клиента.
+	// Пустой список означает адрес TCP-соединения, иначе клиент подменил бы адрес заголовком
+	TrustedProxies []string
+}
+
+// Idempotency - повтор изменяющих запросов с Idempotency-Key. Store nil отключает поддержку заголовка
+type Idempotency struct {
+	Store middleware.IdempotencyStore
+	// TTL - сколько хранится ответ для повторов
+	TTL time.Duration
+	// LockTimeout - через сколько незавершенный первый запрос перестает занимать ключ
+	LockTimeout time.Duration
+}
+
+// OpenAPI - спецификация API. Она отдается на /openapi.json со страницей документации на /docs,
+// при ValidateRequests запросы проверяются по ней до обработчиков. Spec nil отключает оба эндпоинта
+type OpenAPI struct {
+	Spec             *openapi3.T
+	ValidateRequests bool
+}
+
+// LegacyRoutes - сроки жизни путей без версии, которые обслуживаются как устаревшие псевдонимы v1
+type LegacyRoutes struct {
+	// DeprecatedAt отдается в заголовке Deprecation
+	DeprecatedAt time.Time
+	// SunsetAt - дата отключения путей, отдается в заголовке Sunset. Нулевое значение - дата не назначена
+	SunsetAt time.Time
+}
+
+const (
+	// APIv1 - префикс первой версии API. Те же маршруты без префикса обслуживаются как устаревшие
+	APIv1 = "/api/v1"
+	// APIv2 - префикс ресурсной версии API
+	APIv2 = "/api/v2"
 )
 
 type Router struct {
-	rout *gin.Engine
-	h    *handler.Handler
-	log  *zap.Logger
+	rout        *gin.Engine
+	h           *handler.Handler
+	metrics     *metrics.Metrics
+	security    Security
+	rateLimits  RateLimits
+	idempotency Idempotency
+	openAPI     OpenAPI
+	legacy      LegacyRoutes
+	graphQL     http.Handler
+	serviceName string
+	log         *zap.Logger
 }
 
-func NewRouter(h *handler.Handler, mode string, log *zap.Logger) *Router {
+func NewRouter(h *handler.Handler, m *metrics.Metrics, security Security, rateLimits RateLimits, idempotency Idempotency, openAPI OpenAPI, legacy LegacyRoutes, graphQL http.Handler, serviceName string, mode string, log *zap.Logger) *Router {
 	switch mode {
 	case "debug":
 		gin.SetMode(gin.DebugMode)
 	default:
 		gin.SetMode(gin.ReleaseMode)
 	}
+	engine := gin.Default()
+	if err := engine.SetTrustedProxies(rateLimits.TrustedProxies); err != nil {
+		log.Warn("Invalid trusted proxies, client address is taken from the connection", zap.Error(err))
+		_ = engine.SetTrustedProxies(nil)
+	}
 	router := &Router{
-		rout: gin.Default(),
-		h:    h,
-		log:  log.Named("router"),
+		rout:        engine,
+		h:           h,
+		metrics:     m,
+		security:    security,
+		rateLimits:  rateLimits,
+		idempotency: idempotency,
+		openAPI:     openAPI,
+		legacy:      legacy,
+		graphQL:     graphQL,
+		serviceName: serviceName,
+		log:         log.Named("router"),
 	}
 	router.setupRouter()
 
@@ -33,37 +121,212 @@ func NewRouter(h *handler.Handler, mode string, log *zap.Logger) *Router {
 }
 
 func (r *Router) setupRouter() {
-	r.rout.Use(middleware.LoggingMiddleware(r.log))
-	gr := r.rout.Group("")
+	// otelgin идет первым: он извлекает W3C trace context из заголовков, и следующие middleware уже видят спан
+	r.rout.Use(
+		otelgin.Middleware(r.serviceName),
+		middleware.RequestIDMiddleware(),
+		middleware.MetricsMiddleware(r.metrics),
+		middleware.LoggingMiddleware(r.log),
+	)
+	r.rout.GET("/metrics", gin.WrapH(r.metrics.Handler()))
+	r.rout.GET("/healthz", r.h.Healthz)
+	r.rout.GET("/readyz", r.h.Readyz)
+	r.addDocs()
 
-	gr.GET("/stats", r.h.GetStats)
-	r.addUsers(gr)
-	r.addTeam(gr)
-	r.addPR(gr)
+	// Маршруты API смонтированы под версией. Пути без версии остались от первых клиентов:
+	// они обслуживаются теми же обработчиками, что и v1, и помечены устаревшими
+	v1 := r.rout.Group(APIv1, r.apiMiddleware("")...)
+	r.addV1(v1)
+	legacy := r.rout.Group("", middleware.DeprecationMiddleware(r.legacy.DeprecatedAt, r.legacy.SunsetAt, APIv1))
+	legacy.Use(r.apiMiddleware(APIv1)...)
+	r.addV1(legacy)
+	v2 := r.rout.Group(APIv2, r.apiMiddleware("")...)
+	r.addV2(v2)
 
+	r.addGraphQL()
+}
+
+// apiMiddleware - общая цепочка маршрутов API. Служебные эндпоинты открыты, остальные требуют ключ
+// с нужным правом. specPrefix передается проверке запросов по спецификации
+func (r *Router) apiMiddleware(specPrefix string) []gin.HandlerFunc {
+	// Аудит стоит перед аутентификацией, чтобы в журнал попадали и отклоненные запросы
+	chain := []gin.HandlerFunc{middleware.AuditMiddleware(r.security.Audit)}
+	chain = append(chain, r.ipRateLimit()...)
+	chain = append(chain, r.authenticate())
+	// Ключи идемпотентности принадлежат вызывающему, поэтому проверяются после аутентификации
+	if r.idempotency.Store != nil {
+		chain = append(chain, middleware.IdempotencyMiddleware(r.idempotency.Store, r.idempotency.TTL, r.idempotency.LockTimeout))
+	}
+	if r.openAPI.Spec != nil && r.openAPI.ValidateRequests {
+		chain = append(chain, middleware.OpenAPIValidationMiddleware(r.openAPI.Spec, specPrefix))
+	}
+	return chain
+}
+
+// authenticate проверяет ключ или JWT, а при выключенной аутентификации выполняет запрос анонимно
+func (r *Router) authenticate() gin.HandlerFunc {
+	if r.security.Enabled {
+		return middleware.AuthMiddleware(r.security.Authenticator, r.security.Tokens)
+	}
+	return middleware.AnonymousMiddleware()
+}
+
+func (r *Router) addV1(rg *gin.RouterGroup) {
+	r.addStats(rg)
+	r.addJobs(rg)
+	r.addUsers(rg)
+	r.addTeam(rg)
+	r.addPR(rg)
+	r.addAdmin(rg)
+}
+
+// addV2 регистрирует ресурсные маршруты v2. Группы лимитов и права те же, что у соответствующих групп v1
+func (r *Router) addV2(rg *gin.RouterGroup) {
+	teams := rg.Group("/teams", r.groupRateLimit("team")...)
+	teamsRead, teamsWrite := scope(domain.ScopeTeamsRead), scope(domain.ScopeTeamsWrite)
+	teams.POST("", teamsWrite, r.h.CreateTeam)
+	teams.GET("/:name", teamsRead, r.h.GetTeamByName)
+	teams.PUT("/:name/review-capacity", teamsWrite, r.h.PutTeamReviewCapacity)
+	teams.PUT("/:name/review-sla", teamsWrite, r.h.PutTeamReviewSLA)
+
+	pullRequests := rg.Group("/pull-requests", r.groupRateLimit("pullRequest")...)
+	prsRead, prsWrite := scope(domain.ScopePRsRead), scope(domain.ScopePRsWrite)
+	pullRequests.POST("", prsWrite, r.h.CreatePR)
+	pullRequests.GET("", prsRead, r.h.ListPullRequests)
+	pullRequests.GET("/:id", prsRead, r.h.GetPullRequestByID)
+	pullRequests.POST("/:id/merge", prsWrite, r.h.MergePullRequest)
+	pullRequests.GET("/:id/reviewers", prsRead, r.h.ListPullRequestReviewers)
+	pullRequests.POST("/:id/reviewers/:user_id/reassign", prsWrite, r.h.ReassignPullRequestReviewer)
+	pullRequests.PUT("/:id/reviewers/:user_id/verdict", prsWrite, r.h.PutReviewerVerdict)
+
+	users := rg.Group("/users", r.groupRateLimit("users")...)
+	users.GET("/:id/reviews", scope(domain.ScopeUsersRead), r.h.ListUserReviews)
+	// Как и в v1, роль и право users:write для чужой активности проверяет сервис
+	users.PUT("/:id/active", r.h.PutUserActiveStatus)
+}
+
+// addDocs отдает спецификацию и страницу документации. Они открыты, как и проверки состояния
+func (r *Router) addDocs() {
+	if r.openAPI.Spec == nil {
+		return
+	}
+	spec, err := json.Marshal(r.openAPI.Spec)
+	if err != nil {
+		r.log.Error("Failed to encode OpenAPI spec, documentation is disabled", zap.Error(err))
+		return
+	}
+	r.rout.GET("/openapi.json", func(c *gin.Context) {
+		c.Data(http.StatusOK, "application/json", spec)
+	})
+	r.rout.GET("/docs", func(c *gin.Context) {
+		c.Data(http.StatusOK, "text/html; charset=utf-8", openapi.DocsHTML())
+	})
+}
+
+// addGraphQL регистрирует /graphql, если задан обработчик. Схема только читает данные, а права
+// проверяются на ее полях, поэтому из цепочки API остаются лимиты и аутентификация: аудит и ключи
+// идемпотентности нужны изменяющим запросам, а спецификация OpenAPI запросы GraphQL не описывает
+func (r *Router) addGraphQL() {
+	if r.graphQL == nil {
+		return
+	}
+	chain := append(r.ipRateLimit(), r.authenticate())
+	chain = append(chain, r.groupRateLimit("graphql")...)
+	r.rout.POST("/graphql", append(chain, gin.WrapH(r.graphQL))...)
+}
+
+func (r *Router) addStats(rg *gin.RouterGroup) {
+	stats := rg.Group("/stats", r.groupRateLimit("stats")...)
+	stats.Use(scope(domain.ScopeStatsRead))
+
+	stats.GET("", r.h.GetStats)
+	stats.GET("/teams", r.h.GetTeamStats)
+	stats.GET("/latency", r.h.GetLatencyStats)
+	stats.GET("/fairness", r.h.GetFairnessStats)
+}
+
+func (r *Router) addJobs(rg *gin.RouterGroup) {
+	jobs := rg.Group("/jobs", r.groupRateLimit("jobs")...)
+
+	jobs.GET("/runs", scope(domain.ScopeJobsRead), r.h.GetJobRuns)
 }
 
 func (r *Router) addUsers(rg *gin.RouterGroup) {
-	users := rg.Group("/users")
+	users := rg.Group("/users", r.groupRateLimit("users")...)
+	read, write := scope(domain.ScopeUsersRead), scope(domain.ScopeUsersWrite)
 
+	// Свою активность пользователь меняет без права, поэтому роль и право users:write для чужой активности проверяет сервис
 	users.POST("/setIsActive", r.h.SetUserActiveStatus)
-	users.GET("/getReview", r.h.GetUserReview)
+	users.POST("/setRole", write, r.h.SetUserRole)
+	users.GET("/getReview", read, r.h.GetUserReview)
+	users.POST("/setReviewCapacity", write, r.h.SetUserReviewCapacity)
+	users.GET("/getTags", read, r.h.GetUserTags)
+	users.POST("/addTags", write, r.h.AddUserTags)
+	users.POST("/setTags", write, r.h.SetUserTags)
+	users.POST("/removeTag", write, r.h.RemoveUserTag)
+	users.GET("/getUnavailability", read, r.h.GetUnavailability)
+	users.POST("/addUnavailability", write, r.h.AddUnavailability)
+	users.POST("/deleteUnavailability", write, r.h.DeleteUnavailability)
+	users.POST("/importUnavailability", write, r.h.ImportUnavailability)
 
 }
 
 func (r *Router) addTeam(rg *gin.RouterGroup) {
-	team := rg.Group("/team")
+	team := rg.Group("/team", r.groupRateLimit("team")...)
+	read, write := scope(domain.ScopeTeamsRead), scope(domain.ScopeTeamsWrite)
 
-	team.POST("/add", r.h.CreateTeam)
-	team.GET("/get", r.h.GetTeam)
+	team.POST("/add", write, r.h.CreateTeam)
+	team.GET("/get", read, r.h.GetTeam)
+	team.POST("/setReviewCapacity", write, r.h.SetTeamReviewCapacity)
+	team.POST("/setReviewSLA", write, r.h.SetTeamReviewSLA)
 }
 
 func (r *Router) addPR(rg *gin.RouterGroup) {
-	pullRequest := rg.Group("/pullRequest")
+	pullRequest := rg.Group("/pullRequest", r.groupRateLimit("pullRequest")...)
+	read, write := scope(domain.ScopePRsRead), scope(domain.ScopePRsWrite)
+
+	pullRequest.POST("/create", write, r.h.CreatePR)
+	pullRequest.POST("/merge", write, r.h.SetMerge)
+	pullRequest.POST("/reassign", write, r.h.Reassign)
+	pullRequest.POST("/verdict", write, r.h.SubmitVerdict)
+	pullRequest.GET("/get", read, r.h.GetPullRequest)
+	pullRequest.GET("/list", read, r.h.ListPullRequests)
+}
+
+func (r *Router) addAdmin(rg *gin.RouterGroup) {
+	admin := rg.Group("/admin", r.groupRateLimit("admin")...)
+	admin.Use(scope(domain.ScopeAdmin))
+
+	admin.POST("/apiKeys/create", r.h.CreateAPIKey)
+	admin.GET("/apiKeys/list", r.h.ListAPIKeys)
+	admin.POST("/apiKeys/revoke", r.h.RevokeAPIKey)
+	admin.GET("/audit", r.h.GetAuditEvents)
+}
+
+// groupRateLimit ограничивает группу маршрутов ее лимитом или лимитом по умолчанию, корзина - на вызывающего
+func (r *Router) groupRateLimit(group string) []gin.HandlerFunc {
+	limit, ok := r.rateLimits.Limits[group]
+	if !ok {
+		limit, ok = r.rateLimits.Limits[RateLimitDefault]
+	}
+	if r.rateLimits.Limiter == nil || !ok {
+		return nil
+	}
+	return []gin.HandlerFunc{middleware.RateLimitMiddleware(r.rateLimits.Limiter, group, limit, middleware.ByPrincipal)}
+}
+
+// ipRateLimit ограничивает все запросы API по адресу клиента, если задан лимит RateLimitIP
+func (r *Router) ipRateLimit() []gin.HandlerFunc {
+	limit, ok := r.rateLimits.Limits[RateLimitIP]
+	if r.rateLimits.Limiter == nil || !ok {
+		return nil
+	}
+	return []gin.HandlerFunc{middleware.RateLimitMiddleware(r.rateLimits.Limiter, RateLimitIP, limit, middleware.ByClientIP)}
+}
 
-	pullRequest.POST("/create", r.h.CreatePR)
-	pullRequest.POST("/merge", r.h.SetMerge)
-	pullRequest.POST("/reassign", r.h.Reassign)
+// scope - короткая запись для проверки права на маршруте
+func scope(s domain.Scope) gin.HandlerFunc {
+	return middleware.RequireScope(s)
 }
 
 func (r *Router) GetEngine() *gin.Engine {
@@ -0,0 +1,393 @@
+package router
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"avito/internal/domain"
+	"avito/internal/metrics"
+	"avito/internal/transport/graphql"
+	"avito/internal/transport/http/dto"
+	"avito/internal/transport/http/handler"
+	"avito/internal/transport/http/middleware"
+	"avito/internal/transport/http/openapi"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"go.uber.org/zap"
+)
+
+// undocumentedRoutes - служебные маршруты, которые не входят в контракт API
+var undocumentedRoutes = map[string]bool{
+	"GET /metrics":      true,
+	"GET /openapi.json": true,
+	"GET /docs":         true,
+	// Запросы GraphQL описывает схема, а не спецификация OpenAPI
+	"POST /graphql": true,
+}
+
+// dtoSchemas связывает схемы спецификации с DTO, которые сериализуются по этим схемам
+var dtoSchemas = map[string]any{
+	"ErrorResponse":                dto.ErrorResponse{},
+	"ErrorBody":                    dto.ErrorBody{},
+	"FieldError":                   dto.FieldError{},
+	"UserRequest":                  dto.UserRequest{},
+	"CreateTeamDTO":                dto.CreateTeamDTO{},
+	"SetTeamReviewSLARequest":      dto.SetTeamReviewSLARequest{},
+	"SetTeamReviewCapacityRequest": dto.SetTeamReviewCapacityRequest{},
+	"SetUserReviewCapacityRequest": dto.SetUserReviewCapacityRequest{},
+	"SetUserActiveStatusRequest":   dto.SetUserActiveStatusRequest{},
+	"SetUserRoleRequest":           dto.SetUserRoleRequest{},
+	"PullRequestShort":             dto.PullRequestShort{},
+	"ReviewUserResponse":           dto.ReviewUserResponse{},
+	"CreatePullRequest":            dto.CreatePullRequest{},
+	"PullRequestResponse":          dto.PullRequestResponse{},
+	"AssignmentDTO":                dto.AssignmentDTO{},
+	"CreatePullRequestResponse":    dto.CreatePullRequestResponse{},
+	"SetMergeRequest":              dto.SetMergeRequest{},
+	"ReassignRequest":              dto.ReassignRequest{},
+	"SubmitVerdictRequest":         dto.SubmitVerdictRequest{},
+	"VerdictResponse":              dto.VerdictResponse{},
+	"ReassignResponse":             dto.ReassignResponse{},
+	"ReviewCapacityBody":           dto.ReviewCapacityBody{},
+	"ReviewSLABody":                dto.ReviewSLABody{},
+	"ActiveStatusBody":             dto.ActiveStatusBody{},
+	"VerdictBody":                  dto.VerdictBody{},
+	"PullRequestReviewersResponse": dto.PullRequestReviewersResponse{},
+	"PullRequestListResponse":      dto.PullRequestListResponse{},
+	"UserTagsRequest":              dto.UserTagsRequest{},
+	"RemoveUserTagRequest":         dto.RemoveUserTagRequest{},
+	"UserTagsResponse":             dto.UserTagsResponse{},
+	"AddUnavailabilityRequest":     dto.AddUnavailabilityRequest{},
+	"DeleteUnavailabilityRequest":  dto.DeleteUnavailabilityRequest{},
+	"UnavailabilityDTO":            dto.UnavailabilityDTO{},
+	"UserUnavailabilityResponse":   dto.UserUnavailabilityResponse{},
+	"JobRunDTO":                    dto.JobRunDTO{},
+	"JobRunsResponse":              dto.JobRunsResponse{},
+	"CreateAPIKeyRequest":          dto.CreateAPIKeyRequest{},
+	"RevokeAPIKeyRequest":          dto.RevokeAPIKeyRequest{},
+	"APIKeyDTO":                    dto.APIKeyDTO{},
+	"CreateAPIKeyResponse":         dto.CreateAPIKeyResponse{},
+	"APIKeysResponse":              dto.APIKeysResponse{},
+	"AuditEventDTO":                dto.AuditEventDTO{},
+	"AuditEventsResponse":          dto.AuditEventsResponse{},
+	"HealthResponse":               dto.HealthResponse{},
+	"HealthCheckDTO":               dto.HealthCheckDTO{},
+	"ReadinessResponse":            dto.ReadinessResponse{},
+	"UserStatDTO":                  dto.UserStatDTO{},
+	"StatsResponseDTO":             dto.StatsResponseDTO{},
+	"TeamStatDTO":                  dto.TeamStatDTO{},
+	"TeamStatsResponseDTO":         dto.TeamStatsResponseDTO{},
+	"PercentilesDTO":               dto.PercentilesDTO{},
+	"LatencyBucketDTO":             dto.LatencyBucketDTO{},
+	"LatencySeriesDTO":             dto.LatencySeriesDTO{},
+	"LatencyResponse":              dto.LatencyResponse{},
+	"MemberFairnessDTO":            dto.MemberFairnessDTO{},
+	"TeamFairnessDTO":              dto.TeamFairnessDTO{},
+	"FairnessResponse":             dto.FairnessResponse{},
+}
+
+type nopAuditRecorder struct{}
+
+func (nopAuditRecorder) Record(context.Context, domain.AuditEvent) error { return nil }
+
+var testLegacyRoutes = LegacyRoutes{
+	DeprecatedAt: time.Date(2026, time.October, 18, 0, 0, 0, 0, time.UTC),
+	SunsetAt:     time.Date(2027, time.April, 1, 0, 0, 0, 0, time.UTC),
+}
+
+func newTestRouter(t *testing.T, validate bool) (*Router, *openapi3.T) {
+	t.Helper()
+	spec, err := openapi.Load()
+	if err != nil {
+		t.Fatalf("failed to load spec: %v", err)
+	}
+	r := NewRouter(&handler.Handler{}, metrics.New(), Security{Audit: nopAuditRecorder{}}, RateLimits{}, Idempotency{},
+		OpenAPI{Spec: spec, ValidateRequests: validate}, testLegacyRoutes, graphql.NewHandler(graphql.Services{}, zap.NewNop()), "test", "release", zap.NewNop())
+	return r, spec
+}
+
+func TestRoutesMatchOpenAPISpec(t *testing.T) {
+	r, spec := newTestRouter(t, false)
+
+	documented := make(map[string]bool)
+	for path, item := range spec.Paths.Map() {
+		for method := range item.Operations() {
+			documented[method+" "+path] = true
+		}
+	}
+
+	// Устаревшие пути без версии не описываются, но каждый из них должен повторять маршрут v1
+	registered := make(map[string]bool)
+	legacy := make(map[string]bool)
+	for _, route := range r.GetEngine().Routes() {
+		key := route.Method + " " + middleware.SpecPath(route.Path)
+		if undocumentedRoutes[route.Method+" "+route.Path] {
+			continue
+		}
+		if !documented[key] && !strings.HasPrefix(route.Path, "/api/") {
+			legacy[route.Method+" "+APIv1+middleware.SpecPath(route.Path)] = true
+			continue
+		}
+		registered[key] = true
+	}
+
+	var missing, stale []string
+	for route := range registered {
+		if !documented[route] {
+			missing = append(missing, route)
+		}
+	}
+	for route := range documented {
+		if !registered[route] {
+			stale = append(stale, route)
+		}
+	}
+	var orphaned, unaliased []string
+	for route := range legacy {
+		if !registered[route] {
+			orphaned = append(orphaned, route)
+		}
+	}
+	for route := range registered {
+		if strings.Contains(route, " "+APIv1+"/") && !legacy[route] {
+			unaliased = append(unaliased, route)
+		}
+	}
+	sort.Strings(missing)
+	sort.Strings(stale)
+	sort.Strings(orphaned)
+	sort.Strings(unaliased)
+	if len(missing) > 0 {
+		t.Errorf("routes missing from openapi.yaml: %s", strings.Join(missing, ", "))
+	}
+	if len(stale) > 0 {
+		t.Errorf("openapi.yaml documents routes that are not registered: %s", strings.Join(stale, ", "))
+	}
+	if len(orphaned) > 0 {
+		t.Errorf("legacy routes without a v1 counterpart: %s", strings.Join(orphaned, ", "))
+	}
+	if len(unaliased) > 0 {
+		t.Errorf("v1 routes without a legacy alias: %s", strings.Join(unaliased, ", "))
+	}
+}
+
+func TestSchemasMatchDTOs(t *testing.T) {
+	spec, err := openapi.Load()
+	if err != nil {
+		t.Fatalf("failed to load spec: %v", err)
+	}
+	for name, value := range dtoSchemas {
+		ref, ok := spec.Components.Schemas[name]
+		if !ok {
+			t.Errorf("schema %s is missing from openapi.yaml", name)
+			continue
+		}
+		want := jsonFields(reflect.TypeOf(value))
+		got := schemaProperties(ref.Value)
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("schema %s has properties %v, dto.%s has JSON fields %v", name, got, reflect.TypeOf(value).Name(), want)
+		}
+	}
+}
+
+func TestValidationRejectsRequestsThatDoNotMatchSpec(t *testing.T) {
+	r, _ := newTestRouter(t, true)
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		body   string
+		field  string
+	}{
+		{"v1", http.MethodPost, "/api/v1/pullRequest/reassign", `{"pull_request_id":"pr-1","old_user_id":"not-a-uuid"}`, "old_user_id"},
+		{"legacy", http.MethodPost, "/pullRequest/reassign", `{"pull_request_id":"pr-1","old_user_id":"not-a-uuid"}`, "old_user_id"},
+		{"v2", http.MethodPost, "/api/v2/pull-requests/pr-1/reviewers/not-a-uuid/reassign", "", "user_id"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			rec := httptest.NewRecorder()
+			r.GetEngine().ServeHTTP(rec, req)
+
+			if rec.Code != http.StatusBadRequest {
+				t.Fatalf("got status %d, want %d: %s", rec.Code, http.StatusBadRequest, rec.Body.String())
+			}
+			var body dto.ErrorResponse
+			if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+				t.Fatalf("failed to decode error response: %v", err)
+			}
+			if body.Error.Code != "INVALID_REQUEST" {
+				t.Fatalf("got error code %q, want INVALID_REQUEST", body.Error.Code)
+			}
+			if len(body.Error.Details) != 1 || body.Error.Details[0].Field != tt.field {
+				t.Fatalf("got details %+v, want field %q", body.Error.Details, tt.field)
+			}
+		})
+	}
+}
+
+// Без проверки по спецификации запрос проверяют теги binding в DTO, и ответ перечисляет все неверные поля
+func TestBindingErrorsListInvalidFields(t *testing.T) {
+	r, _ := newTestRouter(t, false)
+
+	tests := []struct {
+		name   string
+		body   string
+		code   string
+		fields []string
+	}{
+		{
+			name: "blank name and duplicate members",
+			body: `{"team_name":"  ","members":[` +
+				`{"user_id":"5b0f1f9e-3c1a-4a53-9b8e-0d6f3b7a2c11","username":"alice","is_active":true},` +
+				`{"user_id":"5b0f1f9e-3c1a-4a53-9b8e-0d6f3b7a2c11","username":" ","is_active":true}]}`,
+			code:   "VALIDATION_FAILED",
+			fields: []string{"team_name", "members"},
+		},
+		{
+			name: "nested field",
+			body: `{"team_name":"backend","members":[` +
+				`{"user_id":"5b0f1f9e-3c1a-4a53-9b8e-0d6f3b7a2c11","username":"alice","is_active":true},` +
+				`{"user_id":"7d2e4a10-8f3b-4c6d-a1e5-9b0c2d4f6a83","username":" ","is_active":true}]}`,
+			code:   "VALIDATION_FAILED",
+			fields: []string{"members[1].username"},
+		},
+		{
+			name:   "missing members",
+			body:   `{"team_name":"backend"}`,
+			code:   "VALIDATION_FAILED",
+			fields: []string{"members"},
+		},
+		{
+			name:   "empty members",
+			body:   `{"team_name":"backend","members":[]}`,
+			code:   "VALIDATION_FAILED",
+			fields: []string{"members"},
+		},
+		{
+			name:   "wrong type",
+			body:   `{"team_name":"backend","members":"alice"}`,
+			code:   "VALIDATION_FAILED",
+			fields: []string{"members"},
+		},
+		{
+			name: "malformed json",
+			body: `{"team_name":`,
+			code: "INVALID_BODY",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, APIv1+"/team/add", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			rec := httptest.NewRecorder()
+			r.GetEngine().ServeHTTP(rec, req)
+
+			if rec.Code != http.StatusBadRequest {
+				t.Fatalf("got status %d, want %d: %s", rec.Code, http.StatusBadRequest, rec.Body.String())
+			}
+			var body dto.ErrorResponse
+			if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+				t.Fatalf("failed to decode error response: %v", err)
+			}
+			if body.Error.Code != tt.code {
+				t.Fatalf("got error code %q, want %q", body.Error.Code, tt.code)
+			}
+			var fields []string
+			for _, detail := range body.Error.Details {
+				fields = append(fields, detail.Field)
+			}
+			sort.Strings(fields)
+			want := append([]string(nil), tt.fields...)
+			sort.Strings(want)
+			if !reflect.DeepEqual(fields, want) {
+				t.Fatalf("got invalid fields %v, want %v", fields, want)
+			}
+		})
+	}
+}
+
+func TestLegacyRoutesAreDeprecated(t *testing.T) {
+	r, _ := newTestRouter(t, true)
+
+	for _, path := range []string{"/pullRequest/reassign", APIv1 + "/pullRequest/reassign"} {
+		req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(`{}`))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		r.GetEngine().ServeHTTP(rec, req)
+
+		deprecated := !strings.HasPrefix(path, "/api/")
+		if got := rec.Header().Get("Deprecation") != ""; got != deprecated {
+			t.Errorf("%s: Deprecation header present = %v, want %v", path, got, deprecated)
+		}
+		if got := rec.Header().Get("Sunset") != ""; got != deprecated {
+			t.Errorf("%s: Sunset header present = %v, want %v", path, got, deprecated)
+		}
+		if deprecated {
+			if got, want := rec.Header().Get("Deprecation"), "@1792281600"; got != want {
+				t.Errorf("%s: got Deprecation %q, want %q", path, got, want)
+			}
+			if got, want := rec.Header().Get("Sunset"), "Thu, 01 Apr 2027 00:00:00 GMT"; got != want {
+				t.Errorf("%s: got Sunset %q, want %q", path, got, want)
+			}
+			want := "<" + APIv1 + path + `>; rel="successor-version"`
+			if got := rec.Header().Get("Link"); got != want {
+				t.Errorf("%s: got Link %q, want %q", path, got, want)
+			}
+		}
+	}
+}
+
+func TestSpecIsServed(t *testing.T) {
+	r, _ := newTestRouter(t, false)
+
+	rec := httptest.NewRecorder()
+	r.GetEngine().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/openapi.json", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("got status %d, want %d", rec.Code, http.StatusOK)
+	}
+	if _, err := openapi3.NewLoader().LoadFromData(rec.Body.Bytes()); err != nil {
+		t.Fatalf("served spec does not load: %v", err)
+	}
+}
+
+// jsonFields возвращает имена JSON-полей структуры, включая поля встроенных структур
+func jsonFields(t reflect.Type) []string {
+	var fields []string
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if field.Anonymous && name == "" {
+			fields = append(fields, jsonFields(field.Type)...)
+			continue
+		}
+		if name == "" || name == "-" {
+			continue
+		}
+		fields = append(fields, name)
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+// schemaProperties возвращает имена свойств схемы, включая свойства частей allOf
+func schemaProperties(schema *openapi3.Schema) []string {
+	var properties []string
+	for name := range schema.Properties {
+		properties = append(properties, name)
+	}
+	for _, part := range schema.AllOf {
+		properties = append(properties, schemaProperties(part.Value)...)
+	}
+	sort.Strings(properties)
+	return properties
+}
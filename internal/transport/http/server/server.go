@@ -0,0 +1,82 @@
+// Package server запускает HTTP-сервер и корректно останавливает его:
+// новые соединения перестают приниматься, а начатые запросы дорабатывают до таймаута.
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go.uber.org/zap"
+	"net"
+	"net/http"
+	"time"
+
+	"avito/internal/config"
+)
+
+type Server struct {
+	srv             *http.Server
+	shutdownTimeout time.Duration
+	log             *zap.Logger
+}
+
+func New(cfg config.HTTPConfig, handler http.Handler, log *zap.Logger) *Server {
+	return &Server{
+		srv: &http.Server{
+			Addr:              cfg.Addr,
+			Handler:           handler,
+			ReadHeaderTimeout: cfg.ReadHeaderTimeout,
+			ReadTimeout:       cfg.ReadTimeout,
+			WriteTimeout:      cfg.WriteTimeout,
+			IdleTimeout:       cfg.IdleTimeout,
+		},
+		shutdownTimeout: cfg.ShutdownTimeout,
+		log:             log.Named("Server"),
+	}
+}
+
+// Run слушает адрес сервера и обслуживает запросы до отмены ctx, после чего останавливает сервер
+func (s *Server) Run(ctx context.Context) error {
+	ln, err := net.Listen("tcp", s.srv.Addr)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", s.srv.Addr, err)
+	}
+	return s.Serve(ctx, ln)
+}
+
+// Serve обслуживает запросы на ln до отмены ctx. После отмены сервер закрывает слушатель
+// и ждет завершения начатых запросов не дольше shutdownTimeout.
+// Возвращает nil, если все запросы успели завершиться
+func (s *Server) Serve(ctx context.Context, ln net.Listener) error {
+	serveErr := make(chan error, 1)
+	go func() {
+		s.log.Info("Starting server", zap.String("addr", ln.Addr().String()))
+		serveErr <- s.srv.Serve(ln)
+	}()
+
+	select {
+	case err := <-serveErr:
+		if errors.Is(err, http.ErrServerClosed) {
+			return nil
+		}
+		return fmt.Errorf("server stopped unexpectedly: %w", err)
+	case <-ctx.Done():
+	}
+
+	s.log.Info("Shutting down server, draining in-flight requests", zap.Duration("timeout", s.shutdownTimeout))
+	shutdownCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), s.shutdownTimeout)
+	defer cancel()
+
+	if err := s.srv.Shutdown(shutdownCtx); err != nil {
+		// Запросы не уложились в таймаут: обрываем оставшиеся соединения
+		if closeErr := s.srv.Close(); closeErr != nil {
+			s.log.Error("Failed to close server", zap.Error(closeErr))
+		}
+		return fmt.Errorf("failed to drain in-flight requests: %w", err)
+	}
+	if err := <-serveErr; err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("server stopped with error: %w", err)
+	}
+	s.log.Info("Server stopped")
+	return nil
+}
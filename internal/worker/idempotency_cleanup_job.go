@@ -0,0 +1,37 @@
+package worker
+
+import (
+	"context"
+	"go.uber.org/zap"
+)
+
+type IdempotencyKeyCleaner interface {
+	DeleteExpiredIdempotencyKeys(ctx context.Context) (int64, error)
+}
+
+// IdempotencyCleanupJob удаляет из базы ключи идемпотентности с истекшим сроком хранения
+type IdempotencyCleanupJob struct {
+	cleaner IdempotencyKeyCleaner
+	log     *zap.Logger
+}
+
+func NewIdempotencyCleanupJob(cleaner IdempotencyKeyCleaner, log *zap.Logger) *IdempotencyCleanupJob {
+	return &IdempotencyCleanupJob{
+		cleaner: cleaner,
+		log:     log.Named("IdempotencyCleanupJob"),
+	}
+}
+
+func (j *IdempotencyCleanupJob) Name() string {
+	return "idempotency_cleanup"
+}
+
+func (j *IdempotencyCleanupJob) Run(ctx context.Context) (map[string]any, error) {
+	deleted, err := j.cleaner.DeleteExpiredIdempotencyKeys(ctx)
+	details := map[string]any{"deleted": deleted}
+	if err != nil {
+		return details, err
+	}
+	j.log.Debug("Expired idempotency keys deleted", zap.Int64("count", deleted))
+	return details, nil
+}
@@ -0,0 +1,45 @@
+package worker
+
+import (
+	"context"
+	"go.uber.org/zap"
+
+	"avito/internal/domain"
+)
+
+type LeaveReassigner interface {
+	ReassignReviewsOfStartedLeaves(ctx context.Context) (domain.LeaveReassignmentReport, error)
+}
+
+// LeaveJob переназначает открытые ревью пользователей, у которых начался период недоступности
+type LeaveJob struct {
+	reassigner LeaveReassigner
+	log        *zap.Logger
+}
+
+func NewLeaveJob(reassigner LeaveReassigner, log *zap.Logger) *LeaveJob {
+	return &LeaveJob{
+		reassigner: reassigner,
+		log:        log.Named("LeaveJob"),
+	}
+}
+
+func (j *LeaveJob) Name() string {
+	return "leave_reassignment"
+}
+
+func (j *LeaveJob) Run(ctx context.Context) (map[string]any, error) {
+	report, err := j.reassigner.ReassignReviewsOfStartedLeaves(ctx)
+	details := map[string]any{
+		"periods":    report.Periods,
+		"reassigned": report.Reassigned,
+		"failed":     report.Failed,
+	}
+	if err != nil {
+		return details, err
+	}
+	if report.Reassigned > 0 || report.Failed > 0 {
+		j.log.Info("Reviews reassigned from unavailable users", zap.Int("count", report.Reassigned), zap.Int("failed", report.Failed))
+	}
+	return details, nil
+}
@@ -0,0 +1,37 @@
+package worker
+
+import (
+	"context"
+	"go.uber.org/zap"
+)
+
+type RateLimitBucketCleaner interface {
+	DeleteFullRateLimitBuckets(ctx context.Context) (int64, error)
+}
+
+// RateLimitCleanupJob удаляет из базы корзины ограничения частоты, которые успели наполниться
+type RateLimitCleanupJob struct {
+	cleaner RateLimitBucketCleaner
+	log     *zap.Logger
+}
+
+func NewRateLimitCleanupJob(cleaner RateLimitBucketCleaner, log *zap.Logger) *RateLimitCleanupJob {
+	return &RateLimitCleanupJob{
+		cleaner: cleaner,
+		log:     log.Named("RateLimitCleanupJob"),
+	}
+}
+
+func (j *RateLimitCleanupJob) Name() string {
+	return "rate_limit_cleanup"
+}
+
+func (j *RateLimitCleanupJob) Run(ctx context.Context) (map[string]any, error) {
+	deleted, err := j.cleaner.DeleteFullRateLimitBuckets(ctx)
+	details := map[string]any{"deleted": deleted}
+	if err != nil {
+		return details, err
+	}
+	j.log.Debug("Full rate limit buckets deleted", zap.Int64("count", deleted))
+	return details, nil
+}
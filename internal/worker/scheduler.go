@@ -0,0 +1,178 @@
+package worker
+
+import (
+	"context"
+	"go.uber.org/zap"
+	"sync"
+	"time"
+
+	"avito/internal/domain"
+	"avito/pkg/logger"
+
+	"github.com/google/uuid"
+)
+
+// Job описывает фоновую задачу, которую планировщик запускает с заданным интервалом.
+// Run возвращает сведения о том, что сделала задача; они сохраняются в истории запусков
+type Job interface {
+	Name() string
+	Run(ctx context.Context) (map[string]any, error)
+}
+
+// RunRecorder сохраняет историю запусков задач
+type RunRecorder interface {
+	SaveJobRun(ctx context.Context, run domain.JobRun) error
+}
+
+// recordTimeout ограничивает время сохранения записи о запуске
+const recordTimeout = 5 * time.Second
+
+type scheduledJob struct {
+	job      Job
+	interval time.Duration
+}
+
+// Scheduler периодически запускает зарегистрированные задачи, каждую в своей горутине
+type Scheduler struct {
+	jobs     []scheduledJob
+	recorder RunRecorder
+	cancel   context.CancelFunc
+	wg       sync.WaitGroup
+	log      *zap.Logger
+
+	// mu защищает running и states, которые читает проверка готовности
+	mu      sync.Mutex
+	running bool
+	states  map[string]*domain.JobState
+}
+
+func NewScheduler(recorder RunRecorder, log *zap.Logger) *Scheduler {
+	return &Scheduler{
+		recorder: recorder,
+		log:      log.Named("Scheduler"),
+		states:   make(map[string]*domain.JobState),
+	}
+}
+
+// Register добавляет задачу. Задачи нужно регистрировать до вызова Start
+func (s *Scheduler) Register(job Job, interval time.Duration) {
+	s.jobs = append(s.jobs, scheduledJob{job: job, interval: interval})
+	s.mu.Lock()
+	s.states[job.Name()] = &domain.JobState{Name: job.Name(), Interval: interval}
+	s.mu.Unlock()
+}
+
+// Start запускает все задачи. Первый запуск каждой задачи происходит сразу
+func (s *Scheduler) Start(ctx context.Context) {
+	ctx, s.cancel = context.WithCancel(ctx)
+	s.setRunning(true)
+	for _, sj := range s.jobs {
+		s.wg.Add(1)
+		go s.loop(ctx, sj)
+	}
+	s.log.Info("Scheduler started", zap.Int("jobs", len(s.jobs)))
+}
+
+// Stop останавливает задачи и ждет завершения текущих запусков. Повторный вызов ничего не делает
+func (s *Scheduler) Stop() {
+	if s.cancel == nil {
+		return
+	}
+	s.cancel()
+	s.cancel = nil
+	s.wg.Wait()
+	s.setRunning(false)
+	s.log.Info("Scheduler stopped")
+}
+
+func (s *Scheduler) loop(ctx context.Context, sj scheduledJob) {
+	defer s.wg.Done()
+	log := s.log.With(zap.String("job", sj.job.Name()))
+
+	ticker := time.NewTicker(sj.interval)
+	defer ticker.Stop()
+
+	for {
+		s.runOnce(ctx, sj.job, log)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *Scheduler) runOnce(ctx context.Context, job Job, log *zap.Logger) {
+	run := domain.JobRun{
+		ID:        uuid.New(),
+		JobName:   job.Name(),
+		StartedAt: time.Now().UTC(),
+		Status:    domain.JobRunSucceeded,
+	}
+	log = log.With(zap.String("run_id", run.ID.String()))
+	log.Debug("Job started")
+	// Поля запуска попадают в логи сервисов и репозиториев, вызванных задачей
+	ctx = logger.WithFields(ctx, zap.String("job", run.JobName), zap.String("run_id", run.ID.String()))
+
+	s.markStarted(run)
+	details, err := job.Run(ctx)
+	run.FinishedAt = time.Now().UTC()
+	run.Details = details
+	if err != nil {
+		run.Status = domain.JobRunFailed
+		run.Error = err.Error()
+		log.Error("Job failed", zap.Duration("duration", run.FinishedAt.Sub(run.StartedAt)), zap.Error(err))
+	} else {
+		log.Debug("Job finished", zap.Duration("duration", run.FinishedAt.Sub(run.StartedAt)), zap.Any("details", details))
+	}
+
+	s.markFinished(run)
+
+	if s.recorder == nil {
+		return
+	}
+	// Запуск записывается даже при отмене контекста задачи, поэтому используется отдельный контекст
+	recordCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), recordTimeout)
+	defer cancel()
+	if err := s.recorder.SaveJobRun(recordCtx, run); err != nil {
+		log.Error("Failed to record job run", zap.Error(err))
+	}
+}
+
+// State возвращает состояние планировщика и его задач в порядке регистрации
+func (s *Scheduler) State() domain.SchedulerState {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	state := domain.SchedulerState{Running: s.running, Jobs: make([]domain.JobState, 0, len(s.jobs))}
+	for _, sj := range s.jobs {
+		state.Jobs = append(state.Jobs, *s.states[sj.job.Name()])
+	}
+	return state
+}
+
+func (s *Scheduler) setRunning(running bool) {
+	s.mu.Lock()
+	s.running = running
+	s.mu.Unlock()
+}
+
+func (s *Scheduler) markStarted(run domain.JobRun) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	state := s.states[run.JobName]
+	startedAt := run.StartedAt
+	state.Running = true
+	state.LastStartedAt = &startedAt
+}
+
+func (s *Scheduler) markFinished(run domain.JobRun) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	state := s.states[run.JobName]
+	finishedAt := run.FinishedAt
+	state.Running = false
+	state.LastFinishedAt = &finishedAt
+	state.LastStatus = run.Status
+	state.LastError = run.Error
+}
@@ -0,0 +1,48 @@
+package worker
+
+import (
+	"context"
+	"go.uber.org/zap"
+
+	"avito/internal/domain"
+)
+
+type StaleReviewProcessor interface {
+	ProcessStaleReviews(ctx context.Context) (domain.StaleReviewReport, error)
+}
+
+// StaleReviewJob напоминает о просроченных ревью и переназначает ревью после второго порога
+type StaleReviewJob struct {
+	processor StaleReviewProcessor
+	log       *zap.Logger
+}
+
+func NewStaleReviewJob(processor StaleReviewProcessor, log *zap.Logger) *StaleReviewJob {
+	return &StaleReviewJob{
+		processor: processor,
+		log:       log.Named("StaleReviewJob"),
+	}
+}
+
+func (j *StaleReviewJob) Name() string {
+	return "stale_review_escalation"
+}
+
+func (j *StaleReviewJob) Run(ctx context.Context) (map[string]any, error) {
+	report, err := j.processor.ProcessStaleReviews(ctx)
+	details := map[string]any{
+		"stale":     report.Stale,
+		"reminded":  report.Reminded,
+		"escalated": report.Escalated,
+		"skipped":   report.Skipped,
+		"failed":    report.Failed,
+	}
+	if err != nil {
+		return details, err
+	}
+	if report.Reminded > 0 || report.Escalated > 0 || report.Failed > 0 {
+		j.log.Info("Stale reviews processed",
+			zap.Int("reminded", report.Reminded), zap.Int("escalated", report.Escalated), zap.Int("skipped", report.Skipped), zap.Int("failed", report.Failed))
+	}
+	return details, nil
+}
@@ -0,0 +1,15 @@
+-- Теги экспертизы пользователей (например, go, postgres, frontend).
+CREATE TABLE IF NOT EXISTS user_tags (
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    tag TEXT NOT NULL,
+    PRIMARY KEY (user_id, tag)
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_tags_tag ON user_tags(tag);
+
+-- Метки Pull Request'ов, по которым подбираются ревьюеры.
+CREATE TABLE IF NOT EXISTS pull_request_labels (
+    pull_request_id TEXT NOT NULL REFERENCES pull_requests(id) ON DELETE CASCADE,
+    label TEXT NOT NULL,
+    PRIMARY KEY (pull_request_id, label)
+);
@@ -0,0 +1,17 @@
+-- Периоды недоступности пользователей (отпуск, больничный и т.д.).
+-- reviews_reassigned_at заполняется фоновой задачей после переназначения открытых ревью.
+-- uid - UID события календаря для импортированных периодов: повторный импорт обновляет период, а не дублирует его.
+CREATE TABLE IF NOT EXISTS user_unavailability (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    starts_at TIMESTAMPTZ NOT NULL,
+    ends_at TIMESTAMPTZ NOT NULL,
+    reason TEXT NOT NULL DEFAULT '',
+    uid TEXT,
+    reviews_reassigned_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT user_unavailability_period_check CHECK (ends_at > starts_at)
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_unavailability_user_id_period ON user_unavailability(user_id, starts_at, ends_at);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_user_unavailability_user_id_uid ON user_unavailability(user_id, uid);
@@ -0,0 +1,2 @@
+ALTER TABLE users DROP COLUMN IF EXISTS review_capacity;
+ALTER TABLE teams DROP COLUMN IF EXISTS default_review_capacity;
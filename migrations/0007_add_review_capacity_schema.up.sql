@@ -0,0 +1,7 @@
+-- Лимит одновременных открытых ревью. NULL означает отсутствие лимита.
+-- Лимит пользователя имеет приоритет над лимитом команды по умолчанию.
+ALTER TABLE teams ADD COLUMN IF NOT EXISTS default_review_capacity INTEGER
+    CONSTRAINT teams_default_review_capacity_check CHECK (default_review_capacity >= 0);
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS review_capacity INTEGER
+    CONSTRAINT users_review_capacity_check CHECK (review_capacity >= 0);
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS job_runs;
+ALTER TABLE teams DROP COLUMN IF EXISTS escalation_minutes;
+ALTER TABLE teams DROP COLUMN IF EXISTS review_sla_minutes;
+ALTER TABLE pull_request_reviewers DROP COLUMN IF EXISTS reminded_at;
+ALTER TABLE pull_request_reviewers DROP COLUMN IF EXISTS assigned_at;
@@ -0,0 +1,23 @@
+-- Время назначения ревьюера и время последнего напоминания о ревью.
+ALTER TABLE pull_request_reviewers ADD COLUMN IF NOT EXISTS assigned_at TIMESTAMPTZ NOT NULL DEFAULT NOW();
+ALTER TABLE pull_request_reviewers ADD COLUMN IF NOT EXISTS reminded_at TIMESTAMPTZ;
+
+-- SLA команды на ревью: через review_sla_minutes ревьюеру отправляется напоминание,
+-- через escalation_minutes ревью переназначается. NULL означает значения из конфигурации.
+ALTER TABLE teams ADD COLUMN IF NOT EXISTS review_sla_minutes INTEGER
+    CONSTRAINT teams_review_sla_minutes_check CHECK (review_sla_minutes > 0);
+ALTER TABLE teams ADD COLUMN IF NOT EXISTS escalation_minutes INTEGER
+    CONSTRAINT teams_escalation_minutes_check CHECK (escalation_minutes > 0);
+
+-- История запусков фоновых задач.
+CREATE TABLE IF NOT EXISTS job_runs (
+    id UUID PRIMARY KEY,
+    job_name TEXT NOT NULL,
+    started_at TIMESTAMPTZ NOT NULL,
+    finished_at TIMESTAMPTZ NOT NULL,
+    status TEXT NOT NULL,
+    details JSONB NOT NULL DEFAULT '{}'::jsonb,
+    error TEXT
+);
+
+CREATE INDEX IF NOT EXISTS idx_job_runs_job_name_started_at ON job_runs(job_name, started_at DESC);
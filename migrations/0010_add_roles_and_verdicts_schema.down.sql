@@ -0,0 +1,3 @@
+ALTER TABLE pull_request_reviewers DROP COLUMN IF EXISTS verdict_at;
+ALTER TABLE pull_request_reviewers DROP COLUMN IF EXISTS verdict;
+ALTER TABLE users DROP COLUMN IF EXISTS role;
@@ -0,0 +1,8 @@
+-- Роль пользователя: admin, team_lead (руководитель своей команды) или member.
+ALTER TABLE users ADD COLUMN IF NOT EXISTS role TEXT NOT NULL DEFAULT 'member'
+    CONSTRAINT users_role_check CHECK (role IN ('admin', 'team_lead', 'member'));
+
+-- Вердикт ревьюера по PR. Повторный вердикт заменяет предыдущий.
+ALTER TABLE pull_request_reviewers ADD COLUMN IF NOT EXISTS verdict TEXT
+    CONSTRAINT pull_request_reviewers_verdict_check CHECK (verdict IN ('APPROVED', 'CHANGES_REQUESTED'));
+ALTER TABLE pull_request_reviewers ADD COLUMN IF NOT EXISTS verdict_at TIMESTAMPTZ;
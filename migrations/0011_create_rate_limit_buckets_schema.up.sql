@@ -0,0 +1,11 @@
+-- Корзины токенов ограничения частоты запросов, общие для всех экземпляров сервиса.
+-- Данные временные, поэтому таблица не пишется в WAL и теряется при сбое базы.
+CREATE UNLOGGED TABLE IF NOT EXISTS rate_limit_buckets (
+    key TEXT PRIMARY KEY,
+    tokens DOUBLE PRECISION NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL,
+    -- full_at - момент, когда корзина снова наполнится и строку можно удалить
+    full_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_rate_limit_buckets_full_at ON rate_limit_buckets (full_at);
@@ -0,0 +1,15 @@
+-- Ответы на изменяющие запросы с заголовком Idempotency-Key. Повтор запроса с тем же ключом получает сохраненный ответ.
+-- Пока status_code пуст, первый запрос еще выполняется.
+CREATE TABLE IF NOT EXISTS idempotency_keys (
+    principal_id TEXT NOT NULL,
+    key TEXT NOT NULL,
+    request_hash TEXT NOT NULL,
+    status_code INT,
+    content_type TEXT,
+    response_body BYTEA,
+    created_at TIMESTAMPTZ NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (principal_id, key)
+);
+
+CREATE INDEX IF NOT EXISTS idx_idempotency_keys_expires_at ON idempotency_keys (expires_at);
@@ -0,0 +1,3 @@
+-- Версии для оптимистичной блокировки: каждое изменение увеличивает версию, клиент передает ее в If-Match.
+ALTER TABLE pull_requests ADD COLUMN IF NOT EXISTS version BIGINT NOT NULL DEFAULT 1;
+ALTER TABLE teams ADD COLUMN IF NOT EXISTS version BIGINT NOT NULL DEFAULT 1;
@@ -0,0 +1,2 @@
+-- Заголовки ответа (ETag, Location, Retry-After), которые повтор запроса отдает вместе с сохраненным телом.
+ALTER TABLE idempotency_keys ADD COLUMN IF NOT EXISTS response_headers JSONB;
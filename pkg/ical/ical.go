@@ -0,0 +1,192 @@
+// Package ical реализует минимальный разбор iCalendar (RFC 5545), достаточный
+// для импорта периодов недоступности: события VEVENT с DTSTART, DTEND и SUMMARY.
+package ical
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	// Встроенная база часовых поясов нужна для TZID в минимальных образах без tzdata
+	_ "time/tzdata"
+)
+
+const (
+	dateLayout        = "20060102"
+	dateTimeLayout    = "20060102T150405"
+	dateTimeUTCLayout = "20060102T150405Z"
+)
+
+var (
+	ErrNoEvents     = errors.New("calendar contains no events")
+	ErrInvalidEvent = errors.New("invalid calendar event")
+)
+
+// Event описывает одно событие календаря
+type Event struct {
+	UID     string
+	Summary string
+	Start   time.Time
+	End     time.Time
+}
+
+type property struct {
+	name   string
+	params map[string]string
+	value  string
+}
+
+// Parse читает календарь и возвращает все события VEVENT.
+// Все время приводится к UTC. Для событий на весь день без DTEND длительность считается равной одному дню.
+func Parse(r io.Reader) ([]Event, error) {
+	lines, err := unfold(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var events []Event
+	var current map[string]property
+	for i, line := range lines {
+		if line == "" {
+			continue
+		}
+		prop, err := parseProperty(line)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", i+1, err)
+		}
+
+		switch {
+		case prop.name == "BEGIN" && strings.EqualFold(prop.value, "VEVENT"):
+			current = make(map[string]property)
+		case prop.name == "END" && strings.EqualFold(prop.value, "VEVENT"):
+			if current == nil {
+				return nil, fmt.Errorf("line %d: %w: END without BEGIN", i+1, ErrInvalidEvent)
+			}
+			event, err := buildEvent(current)
+			if err != nil {
+				return nil, err
+			}
+			events = append(events, event)
+			current = nil
+		case current != nil:
+			current[prop.name] = prop
+		}
+	}
+
+	if current != nil {
+		return nil, fmt.Errorf("%w: unterminated VEVENT", ErrInvalidEvent)
+	}
+	if len(events) == 0 {
+		return nil, ErrNoEvents
+	}
+	return events, nil
+}
+
+// unfold склеивает строки, перенесенные по правилам RFC 5545 (продолжение начинается с пробела или табуляции)
+func unfold(r io.Reader) ([]string, error) {
+	scanner := bufio.NewScanner(r)
+	var lines []string
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		lines = append(lines, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read calendar: %w", err)
+	}
+	return lines, nil
+}
+
+func parseProperty(line string) (property, error) {
+	colon := strings.IndexByte(line, ':')
+	if colon < 0 {
+		return property{}, fmt.Errorf("%w: missing ':' in %q", ErrInvalidEvent, line)
+	}
+	head, value := line[:colon], line[colon+1:]
+
+	parts := strings.Split(head, ";")
+	prop := property{
+		name:   strings.ToUpper(parts[0]),
+		params: make(map[string]string, len(parts)-1),
+		value:  value,
+	}
+	for _, param := range parts[1:] {
+		key, val, _ := strings.Cut(param, "=")
+		prop.params[strings.ToUpper(key)] = strings.Trim(val, `"`)
+	}
+	return prop, nil
+}
+
+func buildEvent(props map[string]property) (Event, error) {
+	startProp, ok := props["DTSTART"]
+	if !ok {
+		return Event{}, fmt.Errorf("%w: missing DTSTART", ErrInvalidEvent)
+	}
+	start, allDay, err := parseTime(startProp)
+	if err != nil {
+		return Event{}, err
+	}
+
+	var end time.Time
+	if endProp, ok := props["DTEND"]; ok {
+		end, _, err = parseTime(endProp)
+		if err != nil {
+			return Event{}, err
+		}
+	} else if allDay {
+		end = start.AddDate(0, 0, 1)
+	} else {
+		return Event{}, fmt.Errorf("%w: missing DTEND", ErrInvalidEvent)
+	}
+
+	return Event{
+		UID:     props["UID"].value,
+		Summary: unescapeText(props["SUMMARY"].value),
+		Start:   start,
+		End:     end,
+	}, nil
+}
+
+// parseTime разбирает значение DATE или DATE-TIME. Второе значение сообщает, что это дата без времени
+func parseTime(prop property) (time.Time, bool, error) {
+	value := strings.TrimSpace(prop.value)
+	if strings.EqualFold(prop.params["VALUE"], "DATE") || len(value) == len(dateLayout) {
+		t, err := time.ParseInLocation(dateLayout, value, time.UTC)
+		if err != nil {
+			return time.Time{}, false, fmt.Errorf("%w: bad %s date %q", ErrInvalidEvent, prop.name, value)
+		}
+		return t, true, nil
+	}
+
+	if strings.HasSuffix(value, "Z") {
+		t, err := time.Parse(dateTimeUTCLayout, value)
+		if err != nil {
+			return time.Time{}, false, fmt.Errorf("%w: bad %s time %q", ErrInvalidEvent, prop.name, value)
+		}
+		return t, false, nil
+	}
+
+	loc := time.UTC
+	if tzid := prop.params["TZID"]; tzid != "" {
+		l, err := time.LoadLocation(tzid)
+		if err != nil {
+			return time.Time{}, false, fmt.Errorf("%w: unknown TZID %q", ErrInvalidEvent, tzid)
+		}
+		loc = l
+	}
+	t, err := time.ParseInLocation(dateTimeLayout, value, loc)
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("%w: bad %s time %q", ErrInvalidEvent, prop.name, value)
+	}
+	return t.UTC(), false, nil
+}
+
+func unescapeText(s string) string {
+	replacer := strings.NewReplacer(`\n`, "\n", `\N`, "\n", `\,`, ",", `\;`, ";", `\\`, `\`)
+	return replacer.Replace(s)
+}
@@ -0,0 +1,119 @@
+package ical
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// calendar собирает календарь из строк с переводами строк CRLF, как их отдают почтовые клиенты
+func calendar(lines ...string) string {
+	all := append([]string{"BEGIN:VCALENDAR", "VERSION:2.0"}, lines...)
+	all = append(all, "END:VCALENDAR")
+	return strings.Join(all, "\r\n") + "\r\n"
+}
+
+func TestParse(t *testing.T) {
+	day := func(y int, m time.Month, d int) time.Time { return time.Date(y, m, d, 0, 0, 0, 0, time.UTC) }
+
+	tests := []struct {
+		name    string
+		input   string
+		want    []Event
+		wantErr error
+	}{
+		{
+			name: "utc date-time",
+			input: calendar("BEGIN:VEVENT", "UID:1", "SUMMARY:Conference",
+				"DTSTART:20250310T090000Z", "DTEND:20250312T180000Z", "END:VEVENT"),
+			want: []Event{{UID: "1", Summary: "Conference",
+				Start: time.Date(2025, 3, 10, 9, 0, 0, 0, time.UTC), End: time.Date(2025, 3, 12, 18, 0, 0, 0, time.UTC)}},
+		},
+		{
+			name: "all-day event without DTEND lasts one day",
+			input: calendar("BEGIN:VEVENT", "SUMMARY:Day off",
+				"DTSTART;VALUE=DATE:20250501", "END:VEVENT"),
+			want: []Event{{Summary: "Day off", Start: day(2025, 5, 1), End: day(2025, 5, 2)}},
+		},
+		{
+			name: "TZID is converted to UTC",
+			input: calendar("BEGIN:VEVENT", "SUMMARY:Vacation",
+				`DTSTART;TZID="Europe/Moscow":20250701T100000`, "DTEND;TZID=Europe/Moscow:20250714T100000", "END:VEVENT"),
+			want: []Event{{Summary: "Vacation",
+				Start: time.Date(2025, 7, 1, 7, 0, 0, 0, time.UTC), End: time.Date(2025, 7, 14, 7, 0, 0, 0, time.UTC)}},
+		},
+		{
+			name:  "floating time is treated as UTC",
+			input: calendar("BEGIN:VEVENT", "DTSTART:20250701T100000", "DTEND:20250701T120000", "END:VEVENT"),
+			want:  []Event{{Start: time.Date(2025, 7, 1, 10, 0, 0, 0, time.UTC), End: time.Date(2025, 7, 1, 12, 0, 0, 0, time.UTC)}},
+		},
+		{
+			name: "folded lines and escaped text",
+			input: calendar("BEGIN:VEVENT", "SUMMARY:Team offsite\\, day one\\;", " and two\\nback on Monday",
+				"DTSTART:20250310", "DTEND:20250312", "END:VEVENT"),
+			want: []Event{{Summary: "Team offsite, day one;and two\nback on Monday", Start: day(2025, 3, 10), End: day(2025, 3, 12)}},
+		},
+		{
+			name: "lower-case names and several events",
+			input: calendar("begin:vevent", "dtstart:20250101", "end:vevent",
+				"BEGIN:VEVENT", "DTSTART:20250201", "DTEND:20250203", "END:VEVENT"),
+			want: []Event{
+				{Start: day(2025, 1, 1), End: day(2025, 1, 2)},
+				{Start: day(2025, 2, 1), End: day(2025, 2, 3)},
+			},
+		},
+		{
+			name:    "no events",
+			input:   calendar("PRODID:-//test//EN"),
+			wantErr: ErrNoEvents,
+		},
+		{
+			name:    "missing DTSTART",
+			input:   calendar("BEGIN:VEVENT", "DTEND:20250102", "END:VEVENT"),
+			wantErr: ErrInvalidEvent,
+		},
+		{
+			name:    "date-time without DTEND",
+			input:   calendar("BEGIN:VEVENT", "DTSTART:20250101T100000Z", "END:VEVENT"),
+			wantErr: ErrInvalidEvent,
+		},
+		{
+			name:    "malformed date",
+			input:   calendar("BEGIN:VEVENT", "DTSTART:2025-01-01", "DTEND:20250102", "END:VEVENT"),
+			wantErr: ErrInvalidEvent,
+		},
+		{
+			name:    "unknown TZID",
+			input:   calendar("BEGIN:VEVENT", "DTSTART;TZID=Mars/Olympus:20250101T100000", "DTEND:20250102", "END:VEVENT"),
+			wantErr: ErrInvalidEvent,
+		},
+		{
+			name:    "line without colon",
+			input:   calendar("BEGIN:VEVENT", "DTSTART 20250101", "END:VEVENT"),
+			wantErr: ErrInvalidEvent,
+		},
+		{
+			name:    "END without BEGIN",
+			input:   calendar("END:VEVENT"),
+			wantErr: ErrInvalidEvent,
+		},
+		{
+			name:    "unterminated event",
+			input:   "BEGIN:VCALENDAR\r\nBEGIN:VEVENT\r\nDTSTART:20250101\r\n",
+			wantErr: ErrInvalidEvent,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(strings.NewReader(tt.input))
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("got error %v, want %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,114 @@
+// Package jwtauth проверяет JWT, подписанные провайдером удостоверений, по набору ключей JWKS.
+// JWKS загружается по URL с периодическим обновлением или из локального файла для разработки и тестов.
+package jwtauth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/MicahParks/jwkset"
+	"github.com/MicahParks/keyfunc/v3"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// ErrInvalidToken - токен не прошел проверку подписи, срока действия, издателя или аудитории
+var ErrInvalidToken = errors.New("invalid token")
+
+// signingMethods - асимметричные алгоритмы, которые принимает проверка. HMAC исключен:
+// открытый ключ из JWKS не должен использоваться как общий секрет
+var signingMethods = []string{"RS256", "RS384", "RS512", "PS256", "PS384", "PS512", "ES256", "ES384", "ES512", "EdDSA"}
+
+type Config struct {
+	// JWKSURL и JWKSFile взаимоисключающие, один из них обязателен
+	JWKSURL  string
+	JWKSFile string
+	// Issuer и Audience проверяются, если заданы
+	Issuer   string
+	Audience string
+	// RefreshInterval - как часто обновлять JWKS, загруженный по URL
+	RefreshInterval time.Duration
+	// Leeway - допустимое расхождение часов при проверке exp, nbf и iat
+	Leeway time.Duration
+	// OnRefreshError вызывается при ошибке фонового обновления JWKS
+	OnRefreshError func(err error)
+}
+
+// Claims - утверждения проверенного токена
+type Claims map[string]any
+
+// String возвращает строковое утверждение или пустую строку
+func (c Claims) String(name string) string {
+	value, _ := c[name].(string)
+	return value
+}
+
+type Verifier struct {
+	keyfunc jwt.Keyfunc
+	parser  *jwt.Parser
+}
+
+// New загружает JWKS. Для URL запускается фоновое обновление, которое завершается вместе с ctx
+func New(ctx context.Context, cfg Config) (*Verifier, error) {
+	var kf keyfunc.Keyfunc
+	var err error
+	switch {
+	case cfg.JWKSURL != "" && cfg.JWKSFile != "":
+		return nil, errors.New("only one of JWKS URL and JWKS file can be set")
+	case cfg.JWKSFile != "":
+		raw, readErr := os.ReadFile(cfg.JWKSFile)
+		if readErr != nil {
+			return nil, fmt.Errorf("failed to read JWKS file: %w", readErr)
+		}
+		kf, err = keyfunc.NewJWKSetJSON(raw)
+	case cfg.JWKSURL != "":
+		var storage jwkset.Storage
+		storage, err = jwkset.NewStorageFromHTTP(cfg.JWKSURL, jwkset.HTTPClientStorageOptions{
+			Ctx:             ctx,
+			HTTPTimeout:     10 * time.Second,
+			RefreshInterval: cfg.RefreshInterval,
+			RefreshErrorHandler: func(_ context.Context, err error) {
+				if cfg.OnRefreshError != nil {
+					cfg.OnRefreshError(err)
+				}
+			},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to load JWKS from %s: %w", cfg.JWKSURL, err)
+		}
+		kf, err = keyfunc.New(keyfunc.Options{Ctx: ctx, Storage: storage})
+	default:
+		return nil, errors.New("JWKS URL or JWKS file is required")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create JWKS keyfunc: %w", err)
+	}
+
+	options := []jwt.ParserOption{
+		jwt.WithValidMethods(signingMethods),
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(cfg.Leeway),
+	}
+	if cfg.Issuer != "" {
+		options = append(options, jwt.WithIssuer(cfg.Issuer))
+	}
+	if cfg.Audience != "" {
+		options = append(options, jwt.WithAudience(cfg.Audience))
+	}
+
+	return &Verifier{
+		keyfunc: kf.Keyfunc,
+		parser:  jwt.NewParser(options...),
+	}, nil
+}
+
+// Verify проверяет подпись и стандартные утверждения токена. Любая ошибка проверки оборачивает ErrInvalidToken
+func (v *Verifier) Verify(token string) (Claims, error) {
+	claims := jwt.MapClaims{}
+	if _, err := v.parser.ParseWithClaims(token, claims, v.keyfunc); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidToken, err)
+	}
+	return Claims(claims), nil
+}
@@ -0,0 +1,138 @@
+package jwtauth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	testKeyID    = "test-key"
+	testIssuer   = "https://id.example.com"
+	testAudience = "pr-reviewer"
+)
+
+// writeJWKS сохраняет открытый ключ в JWKS-файл и возвращает путь к нему
+func writeJWKS(t *testing.T, key *rsa.PublicKey) string {
+	t.Helper()
+	encode := func(b []byte) string { return base64.RawURLEncoding.EncodeToString(b) }
+	set := map[string]any{"keys": []map[string]string{{
+		"kty": "RSA",
+		"kid": testKeyID,
+		"alg": "RS256",
+		"use": "sig",
+		"n":   encode(key.N.Bytes()),
+		"e":   encode(big.NewInt(int64(key.E)).Bytes()),
+	}}}
+	raw, err := json.Marshal(set)
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	if err := os.WriteFile(path, raw, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func sign(t *testing.T, method jwt.SigningMethod, key any, claims jwt.MapClaims) string {
+	t.Helper()
+	token := jwt.NewWithClaims(method, claims)
+	token.Header["kid"] = testKeyID
+	signed, err := token.SignedString(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signed
+}
+
+func TestVerify(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	verifier, err := New(context.Background(), Config{
+		JWKSFile: writeJWKS(t, &key.PublicKey),
+		Issuer:   testIssuer,
+		Audience: testAudience,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Now()
+	claims := func(overrides jwt.MapClaims) jwt.MapClaims {
+		c := jwt.MapClaims{
+			"sub": "6f1c1c9e-6c1d-4e0b-9a57-2f1f5b1b8f10",
+			"iss": testIssuer,
+			"aud": testAudience,
+			"exp": now.Add(time.Hour).Unix(),
+			"iat": now.Unix(),
+		}
+		for name, value := range overrides {
+			if value == nil {
+				delete(c, name)
+				continue
+			}
+			c[name] = value
+		}
+		return c
+	}
+	// Открытый ключ в роли общего секрета HMAC: классическая подмена алгоритма
+	publicKeyAsSecret := key.PublicKey.N.Bytes()
+
+	tests := []struct {
+		name    string
+		token   string
+		wantErr bool
+	}{
+		{"valid RS256", sign(t, jwt.SigningMethodRS256, key, claims(nil)), false},
+		{"HS256 is rejected", sign(t, jwt.SigningMethodHS256, publicKeyAsSecret, claims(nil)), true},
+		{"signed with another key", sign(t, jwt.SigningMethodRS256, otherKey, claims(nil)), true},
+		{"expired", sign(t, jwt.SigningMethodRS256, key, claims(jwt.MapClaims{"exp": now.Add(-time.Minute).Unix()})), true},
+		{"without exp", sign(t, jwt.SigningMethodRS256, key, claims(jwt.MapClaims{"exp": nil})), true},
+		{"wrong audience", sign(t, jwt.SigningMethodRS256, key, claims(jwt.MapClaims{"aud": "another-service"})), true},
+		{"wrong issuer", sign(t, jwt.SigningMethodRS256, key, claims(jwt.MapClaims{"iss": "https://evil.example.com"})), true},
+		{"malformed", "not.a.token", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := verifier.Verify(tt.token)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidToken) {
+					t.Fatalf("got error %v, want %v", err, ErrInvalidToken)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("got error %v, want nil", err)
+			}
+			if got.String("sub") != "6f1c1c9e-6c1d-4e0b-9a57-2f1f5b1b8f10" {
+				t.Fatalf("got sub %q", got.String("sub"))
+			}
+		})
+	}
+}
+
+func TestNewRequiresOneKeySource(t *testing.T) {
+	if _, err := New(context.Background(), Config{}); err == nil {
+		t.Fatal("got nil error without JWKS source")
+	}
+	if _, err := New(context.Background(), Config{JWKSURL: "https://id.example.com/jwks", JWKSFile: "jwks.json"}); err == nil {
+		t.Fatal("got nil error with both JWKS URL and file")
+	}
+}
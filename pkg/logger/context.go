@@ -0,0 +1,27 @@
+package logger
+
+import (
+	"context"
+	"go.uber.org/zap"
+)
+
+type fieldsKey struct{}
+
+// WithFields сохраняет в контексте поля, которые FromContext добавит к логгеру (например, request_id).
+// Поля накапливаются: уже сохраненные в контексте не теряются
+func WithFields(ctx context.Context, fields ...zap.Field) context.Context {
+	existing, _ := ctx.Value(fieldsKey{}).([]zap.Field)
+	merged := make([]zap.Field, 0, len(existing)+len(fields))
+	merged = append(merged, existing...)
+	merged = append(merged, fields...)
+	return context.WithValue(ctx, fieldsKey{}, merged)
+}
+
+// FromContext возвращает логгер log, дополненный полями запроса из контекста и идентификаторами трассировки.
+// Имя логгера (Named) сохраняется, поэтому строки сервисов и репозиториев остаются узнаваемыми
+func FromContext(ctx context.Context, log *zap.Logger) *zap.Logger {
+	if fields, ok := ctx.Value(fieldsKey{}).([]zap.Field); ok && len(fields) > 0 {
+		log = log.With(fields...)
+	}
+	return WithTrace(ctx, log)
+}
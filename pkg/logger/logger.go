@@ -21,6 +21,14 @@ func NewLogger(logLevel string) (*zap.Logger, error) {
 		level = zapcore.InfoLevel
 		encoding = "json"
 		encodeLevel = zapcore.LowercaseLevelEncoder
+	case "warn":
+		level = zapcore.WarnLevel
+		encoding = "json"
+		encodeLevel = zapcore.LowercaseLevelEncoder
+	case "error":
+		level = zapcore.ErrorLevel
+		encoding = "json"
+		encodeLevel = zapcore.LowercaseLevelEncoder
 	default:
 		level = zapcore.InfoLevel
 		encoding = "json"
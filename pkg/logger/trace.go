@@ -0,0 +1,20 @@
+package logger
+
+import (
+	"context"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// WithTrace добавляет к логгеру trace_id и span_id текущего спана, если он есть в контексте
+func WithTrace(ctx context.Context, log *zap.Logger) *zap.Logger {
+	spanCtx := trace.SpanContextFromContext(ctx)
+	if !spanCtx.IsValid() {
+		return log
+	}
+	return log.With(
+		zap.String("trace_id", spanCtx.TraceID().String()),
+		zap.String("span_id", spanCtx.SpanID().String()),
+	)
+}